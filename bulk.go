@@ -0,0 +1,36 @@
+package translatable
+
+import (
+	"fmt"
+
+	"github.com/gofiber/fiber/v3"
+	"github.com/google/uuid"
+)
+
+// parseBulkIDs de-duplicates and validates the ids of a bulk read, keeping
+// their first-seen order, and rejects lists larger than limit before any query
+// is issued.
+func parseBulkIDs(raw []string, limit int) ([]uuid.UUID, error) {
+	if len(raw) == 0 {
+		return nil, fiber.NewError(fiber.StatusBadRequest, "ids cannot be empty")
+	}
+
+	seen := make(map[uuid.UUID]bool, len(raw))
+	ids := make([]uuid.UUID, 0, len(raw))
+	for _, value := range raw {
+		id, err := uuid.Parse(value)
+		if err != nil {
+			return nil, fiber.NewError(fiber.StatusBadRequest, fmt.Sprintf("invalid id: %q", value))
+		}
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+		ids = append(ids, id)
+	}
+
+	if len(ids) > limit {
+		return nil, fiber.NewError(fiber.StatusBadRequest, fmt.Sprintf("too many ids: at most %d allowed", limit))
+	}
+	return ids, nil
+}
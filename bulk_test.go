@@ -0,0 +1,25 @@
+package translatable
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseBulkIDs(t *testing.T) {
+	a, b := uuid.New(), uuid.New()
+
+	ids, err := parseBulkIDs([]string{a.String(), b.String(), a.String()}, 2)
+	assert.NoError(t, err)
+	assert.Equal(t, []uuid.UUID{a, b}, ids)
+
+	_, err = parseBulkIDs([]string{a.String(), "bad-1", "bad-2"}, 10)
+	assert.EqualError(t, err, `invalid id: "bad-1"`)
+
+	_, err = parseBulkIDs([]string{a.String(), b.String(), uuid.NewString()}, 2)
+	assert.EqualError(t, err, "too many ids: at most 2 allowed")
+
+	_, err = parseBulkIDs(nil, 2)
+	assert.Error(t, err)
+}
@@ -0,0 +1,216 @@
+package translatable
+
+import (
+	"bytes"
+	"container/list"
+	"context"
+	"encoding/gob"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Cache stores the single-translation reads of Config.CacheTTL, serialized, by
+// key. Get reports whether key holds a live value, Set stores value for ttl
+// and Delete drops keys, present or not. Implementations backed by a shared
+// store such as Redis keep the replicas of an application consistent, where
+// the default MemoryCache only sees the writes of its own process.
+type Cache interface {
+	Get(ctx context.Context, key string) (value []byte, found bool, err error)
+	Set(ctx context.Context, key string, value []byte, ttl time.Duration) error
+	Delete(ctx context.Context, keys ...string) error
+}
+
+// MemoryCache is an LRU Cache local to the process, holding up to size
+// values until their TTL runs out.
+type MemoryCache struct {
+	mu      sync.Mutex
+	size    int
+	order   *list.List
+	entries map[string]*list.Element
+	now     func() time.Time
+}
+
+type memoryCacheEntry struct {
+	key     string
+	value   []byte
+	expires time.Time
+}
+
+func NewMemoryCache(size int) *MemoryCache {
+	return &MemoryCache{size: size, order: list.New(), entries: make(map[string]*list.Element), now: time.Now}
+}
+
+func (m *MemoryCache) Get(ctx context.Context, key string) ([]byte, bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	element, ok := m.entries[key]
+	if !ok {
+		return nil, false, nil
+	}
+	entry := element.Value.(*memoryCacheEntry)
+	if !m.now().Before(entry.expires) {
+		m.remove(element)
+		return nil, false, nil
+	}
+	m.order.MoveToFront(element)
+	return entry.value, true, nil
+}
+
+func (m *MemoryCache) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if element, ok := m.entries[key]; ok {
+		m.remove(element)
+	}
+	m.entries[key] = m.order.PushFront(&memoryCacheEntry{key: key, value: value, expires: m.now().Add(ttl)})
+	for m.order.Len() > m.size {
+		m.remove(m.order.Back())
+	}
+	return nil
+}
+
+func (m *MemoryCache) Delete(ctx context.Context, keys ...string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, key := range keys {
+		if element, ok := m.entries[key]; ok {
+			m.remove(element)
+		}
+	}
+	return nil
+}
+
+func (m *MemoryCache) remove(element *list.Element) {
+	delete(m.entries, m.order.Remove(element).(*memoryCacheEntry).key)
+}
+
+// cachedRead is what the read cache stores: the translation as served, with
+// the fields JSON leaves out, and its entity tag.
+type cachedRead struct {
+	Translation Translatable
+	ETag        string
+}
+
+func (c *Config) cacheEnabled() bool {
+	return c.Cache != nil && c.CacheTTL > 0
+}
+
+// cachedRead returns the translation cached under key and its entity tag. A
+// read opting out with Cache-Control: no-cache, an expired translation or a
+// failing store are misses.
+func (c *Config) cachedRead(ctx context.Context, key string) (*Translatable, string, bool) {
+	if !c.cacheEnabled() || cacheBypassFromContext(ctx) {
+		return nil, "", false
+	}
+	value, found, err := c.Cache.Get(ctx, key)
+	if err != nil {
+		requestLogger(ctx).Warn("read cache failed", "key", key, "error", err)
+		return nil, "", false
+	}
+	if !found {
+		return nil, "", false
+	}
+
+	var read cachedRead
+	if err := gob.NewDecoder(bytes.NewReader(value)).Decode(&read); err != nil {
+		requestLogger(ctx).Warn("read cache entry is corrupt", "key", key, "error", err)
+		return nil, "", false
+	}
+	if t := read.Translation; t.ExpiresAt != nil && !t.ExpiresAt.After(time.Now()) {
+		return nil, "", false
+	}
+	return &read.Translation, read.ETag, true
+}
+
+// cacheRead stores t, as served, and its entity tag under key for CacheTTL,
+// or until t expires when that comes first.
+func (c *Config) cacheRead(ctx context.Context, key string, t *Translatable, etag string) {
+	if !c.cacheEnabled() || t == nil {
+		return
+	}
+	ttl := c.CacheTTL
+	if t.ExpiresAt != nil {
+		ttl = min(ttl, time.Until(*t.ExpiresAt))
+	}
+	if ttl <= 0 {
+		return
+	}
+
+	var value bytes.Buffer
+	if err := gob.NewEncoder(&value).Encode(cachedRead{Translation: *t, ETag: etag}); err != nil {
+		requestLogger(ctx).Warn("read cache entry cannot be encoded", "key", key, "error", err)
+		return
+	}
+	if err := c.Cache.Set(ctx, key, value.Bytes(), ttl); err != nil {
+		requestLogger(ctx).Warn("read cache failed", "key", key, "error", err)
+	}
+}
+
+// invalidateReads drops every cached read of the translations, by id and by
+// entity, type and locale, in every read state.
+func (c *Config) invalidateReads(ctx context.Context, translations ...*Translatable) {
+	if !c.cacheEnabled() {
+		return
+	}
+	var keys []string
+	for _, t := range translations {
+		if t == nil {
+			continue
+		}
+		tenantID := ""
+		if c.TenantScoped && t.TenantID != nil {
+			tenantID = *t.TenantID
+		}
+		translatable, _ := c.CanonicalType(t.Translatable)
+		for _, state := range []string{ReadStateDraft, ReadStatePublished} {
+			for _, raw := range []bool{false, true} {
+				keys = append(keys,
+					cacheKey(tenantID, state, raw, "id", t.ID.String()),
+					cacheKey(tenantID, state, raw, "key", translatable, t.TranslatableID.String(), t.Locale))
+			}
+		}
+	}
+	if len(keys) == 0 {
+		return
+	}
+	if err := c.Cache.Delete(ctx, keys...); err != nil {
+		requestLogger(ctx).Warn("read cache invalidation failed", "error", err)
+	}
+}
+
+// idCacheKey keys the read of translation id made with ctx.
+func (c *Config) idCacheKey(ctx context.Context, id uuid.UUID) string {
+	return c.readCacheKey(ctx, "id", id.String())
+}
+
+// naturalCacheKey keys the read of the translation of an entity in locale
+// made with ctx.
+func (c *Config) naturalCacheKey(ctx context.Context, translatableID uuid.UUID, translatable, locale string) string {
+	translatable, _ = c.CanonicalType(translatable)
+	return c.readCacheKey(ctx, "key", translatable, translatableID.String(), locale)
+}
+
+func (c *Config) readCacheKey(ctx context.Context, kind string, parts ...string) string {
+	tenantID := ""
+	if c.TenantScoped {
+		tenantID = getTenantIDFromContext(ctx)
+	}
+	state := readStateFromContext(ctx)
+	if state == "" {
+		state = ReadStateDraft
+	}
+	return cacheKey(tenantID, state, rawContentFromContext(ctx), kind, parts...)
+}
+
+// cacheKey keys a read by what decides the served translation besides parts:
+// the tenant, the read state and raw content.
+func cacheKey(tenantID, state string, raw bool, kind string, parts ...string) string {
+	return "translatable:" + kind + ":" + state + ":" + strconv.FormatBool(raw) + ":" + tenantID + ":" + strings.Join(parts, ":")
+}
@@ -0,0 +1,247 @@
+package translatable
+
+import (
+	"context"
+	"encoding/json"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v3"
+	"github.com/google/uuid"
+	"github.com/nicolasbonnici/gorest-translatable/mocks"
+	"github.com/nicolasbonnici/gorest/database"
+	"github.com/stretchr/testify/assert"
+)
+
+// countingDatabase wraps db, counting the single-row reads of a translation.
+func countingDatabase(db *mocks.MockDatabase, reads *int) *mocks.MockDatabase {
+	queryRow := db.QueryRowFunc
+	db.QueryRowFunc = func(ctx context.Context, query string, args ...interface{}) database.Row {
+		*reads++
+		return queryRow(ctx, query, args...)
+	}
+	return db
+}
+
+func cachedConfig(t *testing.T) Config {
+	config := DefaultConfig()
+	config.CacheSize = 10
+	config.CacheTTL = time.Minute
+	if err := config.Validate(); err != nil {
+		t.Fatal(err)
+	}
+	return config
+}
+
+func getTranslation(t *testing.T, app *fiber.App, path string, header ...string) (*TranslatableResponseDTO, string) {
+	req := httptest.NewRequest(fiber.MethodGet, path, nil)
+	for i := 0; i+1 < len(header); i += 2 {
+		req.Header.Set(header[i], header[i+1])
+	}
+	resp, err := app.Test(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+	var body TranslatableResponseDTO
+	assert.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+	return &body, resp.Header.Get(fiber.HeaderETag)
+}
+
+func TestReadCache_GetByID(t *testing.T) {
+	id := uuid.New()
+	reads := 0
+	config := cachedConfig(t)
+	app, resource := setupTestApp(countingDatabase(etagTestDatabase(id, time.Now().UTC(), new([]string)), &reads), &config)
+	app.Get("/translations/:id", resource.GetByID)
+
+	fresh, freshETag := getTranslation(t, app, "/translations/"+id.String())
+	cached, cachedETag := getTranslation(t, app, "/translations/"+id.String())
+
+	assert.Equal(t, 1, reads)
+	assert.Equal(t, fresh, cached)
+	assert.Equal(t, freshETag, cachedETag)
+	assert.Equal(t, "Bonjour", cached.Content)
+
+	getTranslation(t, app, "/translations/"+id.String(), fiber.HeaderCacheControl, "no-cache")
+	getTranslation(t, app, "/translations/"+id.String()+"?raw=true")
+	assert.Equal(t, 3, reads)
+}
+
+func TestReadCache_InvalidatedByUpdate(t *testing.T) {
+	id := uuid.New()
+	reads := 0
+	config := cachedConfig(t)
+	app, resource := setupTestApp(countingDatabase(etagTestDatabase(id, time.Now().UTC(), new([]string)), &reads), &config)
+	app.Get("/translations/:id", resource.GetByID)
+	app.Put("/translations/:id", resource.Update)
+
+	getTranslation(t, app, "/translations/"+id.String())
+	getTranslation(t, app, "/translations/"+id.String())
+	assert.Equal(t, 1, reads)
+
+	req := httptest.NewRequest(fiber.MethodPut, "/translations/"+id.String(), strings.NewReader(`{"locale":"fr","content":"Salut"}`))
+	req.Header.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+	resp, err := app.Test(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+	reads = 0
+	getTranslation(t, app, "/translations/"+id.String())
+	assert.Equal(t, 1, reads)
+}
+
+func TestReadCache_Disabled(t *testing.T) {
+	id := uuid.New()
+	reads := 0
+	config := DefaultConfig()
+	if err := config.Validate(); err != nil {
+		t.Fatal(err)
+	}
+	app, resource := setupTestApp(countingDatabase(etagTestDatabase(id, time.Now().UTC(), new([]string)), &reads), &config)
+	app.Get("/translations/:id", resource.GetByID)
+
+	for range 3 {
+		body, _ := getTranslation(t, app, "/translations/"+id.String())
+		assert.Equal(t, "Bonjour", body.Content)
+	}
+	assert.Equal(t, 3, reads)
+}
+
+func TestReadCache_GetByNaturalKey(t *testing.T) {
+	id := uuid.New()
+	translatableID := uuid.New()
+	queries := 0
+	db := &mocks.MockDatabase{
+		QueryFunc: func(ctx context.Context, query string, args ...interface{}) (database.Rows, error) {
+			queries++
+			rows := mocks.NewMockRows(1)
+			rows.ScanFunc = func(row int, dest ...interface{}) error {
+				*dest[0].(*uuid.UUID) = id
+				*dest[2].(*uuid.UUID) = translatableID
+				*dest[3].(*string) = "post"
+				*dest[4].(*string) = "fr"
+				*dest[5].(*string) = "Bonjour"
+				return nil
+			}
+			return rows, nil
+		},
+	}
+	config := DefaultConfig()
+	config.TenantScoped = true
+	config.CacheSize = 10
+	config.CacheTTL = time.Minute
+	if err := config.Validate(); err != nil {
+		t.Fatal(err)
+	}
+	service := NewTranslatableService(db, &config)
+	acme := WithTenantID(context.Background(), "acme")
+
+	for range 2 {
+		found, err := service.GetByNaturalKey(acme, translatableID, "post", "fr")
+		assert.NoError(t, err)
+		assert.Equal(t, id, found.ID)
+	}
+	assert.Equal(t, 1, queries)
+
+	_, err := service.GetByNaturalKey(WithTenantID(context.Background(), "globex"), translatableID, "post", "fr")
+	assert.NoError(t, err)
+	assert.Equal(t, 2, queries)
+
+	tenantID := "acme"
+	emitDeleted(acme, &config, &Translatable{ID: id, TranslatableID: translatableID, Translatable: "post", Locale: "fr", TenantID: &tenantID})
+	_, err = service.GetByNaturalKey(acme, translatableID, "post", "fr")
+	assert.NoError(t, err)
+	assert.Equal(t, 3, queries)
+}
+
+func TestMemoryCache(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+	cache := NewMemoryCache(2)
+	cache.now = func() time.Time { return now }
+
+	assert.NoError(t, cache.Set(ctx, "first", []byte("1"), time.Minute))
+	assert.NoError(t, cache.Set(ctx, "second", []byte("2"), time.Minute))
+	value, found, err := cache.Get(ctx, "first")
+	assert.NoError(t, err)
+	assert.True(t, found)
+	assert.Equal(t, []byte("1"), value)
+
+	assert.NoError(t, cache.Set(ctx, "third", []byte("3"), time.Second))
+	_, found, _ = cache.Get(ctx, "second")
+	assert.False(t, found, "least recently used entry is evicted")
+	_, found, _ = cache.Get(ctx, "first")
+	assert.True(t, found)
+
+	now = now.Add(2 * time.Second)
+	_, found, _ = cache.Get(ctx, "third")
+	assert.False(t, found, "entry outlived its TTL")
+
+	assert.NoError(t, cache.Delete(ctx, "first", "missing"))
+	_, found, _ = cache.Get(ctx, "first")
+	assert.False(t, found)
+}
+
+// fakeCache is a Cache recording the keys it is asked to set and delete.
+type fakeCache struct {
+	values  map[string][]byte
+	sets    []string
+	deletes []string
+}
+
+func (f *fakeCache) Get(ctx context.Context, key string) ([]byte, bool, error) {
+	value, ok := f.values[key]
+	return value, ok, nil
+}
+
+func (f *fakeCache) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	f.values[key] = value
+	f.sets = append(f.sets, key)
+	return nil
+}
+
+func (f *fakeCache) Delete(ctx context.Context, keys ...string) error {
+	for _, key := range keys {
+		delete(f.values, key)
+	}
+	f.deletes = append(f.deletes, keys...)
+	return nil
+}
+
+func TestReadCache_CustomCache(t *testing.T) {
+	id := uuid.New()
+	reads := 0
+	cache := &fakeCache{values: make(map[string][]byte)}
+	config := DefaultConfig()
+	config.CacheTTL = time.Minute
+	config.Cache = cache
+	if err := config.Validate(); err != nil {
+		t.Fatal(err)
+	}
+	app, resource := setupTestApp(countingDatabase(etagTestDatabase(id, time.Now().UTC(), new([]string)), &reads), &config)
+	app.Get("/translations/:id", resource.GetByID)
+	app.Put("/translations/:id", resource.Update)
+	key := config.idCacheKey(context.Background(), id)
+
+	getTranslation(t, app, "/translations/"+id.String())
+	assert.Equal(t, []string{key}, cache.sets, "a miss is stored")
+	getTranslation(t, app, "/translations/"+id.String())
+	assert.Equal(t, 1, reads)
+	assert.Len(t, cache.sets, 1)
+
+	req := httptest.NewRequest(fiber.MethodPut, "/translations/"+id.String(), strings.NewReader(`{"locale":"fr","content":"Salut"}`))
+	req.Header.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+	resp, err := app.Test(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+	assert.Contains(t, cache.deletes, key, "an update deletes through the cache")
+	assert.NotContains(t, cache.values, key)
+}
@@ -0,0 +1,101 @@
+package translatable
+
+import "slices"
+
+// CapabilitiesResponse describes the content rules and optional features of a
+// deployment so clients can adapt their forms without hardcoding them.
+type CapabilitiesResponse struct {
+	ContentFormat    string         `json:"content_format"`
+	MaxContentLength map[string]int `json:"max_content_length"`
+	// ContentLengthUnit is "characters" or "bytes".
+	ContentLengthUnit string   `json:"content_length_unit"`
+	DefaultLocale     string   `json:"default_locale"`
+	SupportedLocales  []string `json:"supported_locales"`
+	AllowedTypes      []string `json:"allowed_types"`
+	SanitizerMode     string   `json:"sanitizer_mode"`
+	// TypeSanitizerModes lists the types whose mode differs from SanitizerMode.
+	TypeSanitizerModes map[string]string `json:"type_sanitizer_modes,omitempty"`
+	FallbackStrategy   string            `json:"fallback_strategy"`
+	Features           []string          `json:"features"`
+}
+
+// capabilities is built from the configuration alone. autoTranslation reports
+// whether a Translator was registered on the plugin.
+func (c *Config) capabilities(autoTranslation bool) CapabilitiesResponse {
+	maxLengths := make(map[string]int, len(c.AllowedTypes))
+	for _, t := range c.AllowedTypes {
+		maxLengths[t] = c.MaxContentLength
+	}
+
+	sanitizer := c.SanitizeMode
+	if c.Sanitizer != nil {
+		sanitizer = SanitizerModeCustom
+	}
+	var typeModes map[string]string
+	for _, typeName := range c.AllowedTypes {
+		mode, ok := c.TypeSanitizeModes[typeName]
+		switch {
+		case c.contentFormat(typeName) == ContentFormatJSON:
+			mode, ok = SanitizerModeNone, true
+		case c.TypeValidators[typeName] == ValidatorHTML && !ok:
+			mode, ok = SanitizerModeAllowlist, true
+		case c.ContentFormat == ContentFormatJSON && !ok:
+			mode, ok = c.SanitizeMode, true
+			if c.Sanitizer != nil {
+				mode = SanitizerModeCustom
+			}
+		}
+		if ok {
+			if typeModes == nil {
+				typeModes = make(map[string]string)
+			}
+			typeModes[typeName] = mode
+		}
+	}
+	if c.ContentFormat == ContentFormatJSON {
+		sanitizer = SanitizerModeNone
+	}
+
+	var features []string
+	for feature, enabled := range map[string]bool{
+		"default_locale_fallback": c.FallbackStrategy != FallbackChainOnly,
+		"auto_translation":        autoTranslation,
+		"machine_translation":     c.textTranslator() != nil,
+		"translate_on_miss":       c.TranslateOnMiss && autoTranslation,
+		"content_schemas":         len(c.ContentSchemas) > 0 || len(c.DefaultContentSchema) > 0,
+		"default_locale_first":    c.DefaultLocaleFirst,
+		"raw_content":             c.StoreRawContent,
+		"soft_delete":             c.SoftDelete,
+		"received_at":             c.TrackReceivedAt,
+		"entity_expansion":        c.EntityMetadataResolver != nil,
+		"hydra_docs":              c.HydraDocs,
+		"etag":                    true,
+		"versioning":              true,
+		"patch":                   true,
+		"status_workflow":         true,
+		"require_review":          c.RequireReview,
+		"tenant_scoping":          c.TenantScoped,
+		"webhooks":                len(c.Webhooks) > 0,
+		"metrics":                 c.EnableMetrics,
+		"rate_limiting":           c.rateLimits(),
+		"cors":                    len(c.CORS.AllowOrigins) > 0,
+	} {
+		if enabled {
+			features = append(features, feature)
+		}
+	}
+	slices.Sort(features)
+
+	return CapabilitiesResponse{
+		ContentFormat:      c.ContentFormat,
+		MaxContentLength:   maxLengths,
+		ContentLengthUnit:  c.contentLengthUnit(),
+		DefaultLocale:      c.DefaultLocale,
+		SupportedLocales:   c.SupportedLocales,
+		AllowedTypes:       c.AllowedTypes,
+		SanitizerMode:      sanitizer,
+		TypeSanitizerModes: typeModes,
+		FallbackStrategy:   c.FallbackStrategy,
+		Features:           features,
+	}
+}
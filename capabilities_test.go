@@ -0,0 +1,64 @@
+package translatable
+
+import (
+	"encoding/json"
+	"io"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v3"
+	"github.com/nicolasbonnici/gorest-translatable/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTranslatableResource_GetCapabilities(t *testing.T) {
+	config := DefaultConfig()
+	config.AllowedTypes = []string{"post", "page"}
+	config.SoftDelete = true
+	config.DeepLAPIKey = "key:fx"
+	app, resource := setupTestApp(&mocks.MockDatabase{}, &config)
+	app.Get("/translations/capabilities", resource.GetCapabilities)
+
+	resp, err := app.Test(httptest.NewRequest(fiber.MethodGet, "/translations/capabilities", nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	body, _ := io.ReadAll(resp.Body)
+
+	var capabilities CapabilitiesResponse
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+	assert.NoError(t, json.Unmarshal(body, &capabilities))
+	assert.Equal(t, CapabilitiesResponse{
+		ContentFormat:     ContentFormatText,
+		MaxContentLength:  map[string]int{"post": config.MaxContentLength, "page": config.MaxContentLength},
+		ContentLengthUnit: ContentLengthCharacters,
+		DefaultLocale:     "en",
+		SupportedLocales:  []string{"en", "fr", "es"},
+		AllowedTypes:      []string{"post", "page"},
+		SanitizerMode:     SanitizerModeEscape,
+		FallbackStrategy:  FallbackChainThenDefault,
+		Features: []string{
+			"default_locale_fallback", "etag", "machine_translation", "patch", "soft_delete", "status_workflow", "versioning",
+		},
+	}, capabilities)
+}
+
+func TestCapabilities_Features(t *testing.T) {
+	config := DefaultConfig()
+	config.RequireReview = true
+	config.TenantScoped = true
+	config.Webhooks = []string{"https://hooks.example.com/translations"}
+	config.EnableMetrics = true
+	config.RateLimit = RateLimitConfig{Requests: 60, Window: time.Minute}
+	config.RateLimitStore = NewMemoryRateLimitStore()
+	config.CORS.AllowOrigins = []string{"https://app.example.com"}
+
+	assert.Equal(t, []string{
+		"cors", "default_locale_fallback", "etag", "metrics", "patch", "rate_limiting", "require_review",
+		"status_workflow", "tenant_scoping", "versioning", "webhooks",
+	}, config.capabilities(false).Features)
+
+	config.RateLimitStore = nil
+	assert.NotContains(t, config.capabilities(false).Features, "rate_limiting", "no store, no limit")
+}
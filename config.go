@@ -1,23 +1,266 @@
 package translatable
 
 import (
+	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"slices"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
 
 	"github.com/nicolasbonnici/gorest/database"
+	"github.com/nicolasbonnici/gorest/rbac"
+	"golang.org/x/text/unicode/norm"
+)
+
+const (
+	ContentFormatText = "text"
+	ContentFormatJSON = "json"
+)
+
+const (
+	SanitizerModeEscape    = "escape"
+	SanitizerModeStrip     = "strip"
+	SanitizerModeAllowlist = "allowlist"
+	// SanitizerModeCustom and SanitizerModeNone are only reported by
+	// capabilities, for a Config.Sanitizer and for JSON content.
+	SanitizerModeCustom = "custom"
+	SanitizerModeNone   = "none"
+)
+
+var sanitizerModes = []string{SanitizerModeEscape, SanitizerModeStrip, SanitizerModeAllowlist}
+
+// Validator kinds of Config.TypeValidators.
+const (
+	ValidatorText = "text"
+	ValidatorJSON = "json"
+	ValidatorHTML = "html"
+)
+
+var typeValidators = []string{ValidatorText, ValidatorJSON, ValidatorHTML}
+
+const (
+	ResponseFormatHydra = "hydra"
+	ResponseFormatPlain = "plain"
+)
+
+const (
+	ContentLengthCharacters = "characters"
+	ContentLengthBytes      = "bytes"
 )
 
 type Config struct {
-	Database           database.Database
-	AllowedTypes       []string `json:"allowed_types" yaml:"allowed_types"`
-	SupportedLocales   []string `json:"supported_locales" yaml:"supported_locales"`
-	DefaultLocale      string   `json:"default_locale" yaml:"default_locale"`
-	PaginationLimit    int      `json:"pagination_limit" yaml:"pagination_limit"`
-	MaxPaginationLimit int      `json:"max_pagination_limit" yaml:"max_pagination_limit"`
-	MaxContentLength   int      `json:"max_content_length" yaml:"max_content_length"`
+	Database     database.Database
+	AllowedTypes []string `json:"allowed_types" yaml:"allowed_types"`
+	// Deprecated: AllowedTables is an alias of AllowedTypes, merged into it by Validate.
+	AllowedTables    []string `json:"allowed_tables" yaml:"allowed_tables"`
+	SupportedLocales []string `json:"supported_locales" yaml:"supported_locales"`
+	DefaultLocale    string   `json:"default_locale" yaml:"default_locale"`
+	// StrictLocales requires SupportedLocales to be canonical BCP 47 tags and
+	// reports malformed request locales as such. It is on unless set to false;
+	// turn it off to keep custom locale codes.
+	StrictLocales *bool `json:"strict_locales" yaml:"strict_locales"`
+	// CanonicalLocales, with StrictLocales, stores and looks up every locale
+	// in its canonical spelling, so fr_FR, fr-fr and fr-FR name the same
+	// translation. Rows stored in other spellings are not rewritten: enable it
+	// once the locale column only holds canonical tags.
+	CanonicalLocales   bool `json:"canonical_locales" yaml:"canonical_locales"`
+	PaginationLimit    int  `json:"pagination_limit" yaml:"pagination_limit"`
+	MaxPaginationLimit int  `json:"max_pagination_limit" yaml:"max_pagination_limit"`
+	MaxContentLength   int  `json:"max_content_length" yaml:"max_content_length"`
+	// CountRunes measures MaxContentLength in characters rather than bytes, so
+	// multi-byte scripts get the same allowance as ASCII. Unset, it does; set
+	// it to false to measure bytes.
+	CountRunes    *bool  `json:"count_runes" yaml:"count_runes"`
+	ContentFormat string `json:"content_format" yaml:"content_format"`
+	MaxJSONDepth  int    `json:"max_json_depth" yaml:"max_json_depth"`
+	MaxJSONKeys   int    `json:"max_json_keys" yaml:"max_json_keys"`
+	// SanitizeMode decides how text content is made safe to render: escape
+	// all markup (default), strip it, or keep an allowlist of formatting tags
+	// and links. TypeSanitizeModes overrides it per type.
+	SanitizeMode      string            `json:"sanitize_mode" yaml:"sanitize_mode"`
+	TypeSanitizeModes map[string]string `json:"type_sanitize_modes" yaml:"type_sanitize_modes"`
+	// TypeValidators sets, per type, what its content must be: text, the
+	// default, JSON that parses, as with ContentFormat json, or HTML, kept
+	// with the allowlist sanitizer unless TypeSanitizeModes says otherwise.
+	TypeValidators map[string]string `json:"type_validators" yaml:"type_validators"`
+	// Sanitizer, when set, replaces the sanitizer of SanitizeMode. Types listed
+	// in TypeSanitizeModes keep theirs.
+	Sanitizer Sanitizer `json:"-" yaml:"-"`
+	// FieldKeys lists, per type, the named fields its content is made of, e.g.
+	// title, body and excerpt. Types listed store content as a JSON object
+	// of their fields; plain content is stored as the "value" field.
+	FieldKeys map[string][]string `json:"field_keys" yaml:"field_keys"`
+	// TypeTTLs expires translations of the listed types after the given duration.
+	TypeTTLs          map[string]time.Duration `json:"type_ttls" yaml:"type_ttls"`
+	TranslatorTimeout time.Duration            `json:"translator_timeout" yaml:"translator_timeout"`
+	MaxLocalesPerPage int                      `json:"max_locales_per_page" yaml:"max_locales_per_page"`
+	MaxBulkLookup     int                      `json:"max_bulk_lookup" yaml:"max_bulk_lookup"`
+	// SortableColumns lists the fields collections can be sorted by with ?sort=.
+	SortableColumns []string `json:"sortable_columns" yaml:"sortable_columns"`
+	// MaxSnapshots bounds the number of open read snapshots, each of which holds
+	// a database transaction for at most SnapshotTTL.
+	MaxSnapshots int           `json:"max_snapshots" yaml:"max_snapshots"`
+	SnapshotTTL  time.Duration `json:"snapshot_ttl" yaml:"snapshot_ttl"`
+	// ContentSchemas holds a JSON Schema document per type, served to clients
+	// that build edit forms. DefaultContentSchema applies to types without one.
+	ContentSchemas       map[string]json.RawMessage `json:"content_schemas" yaml:"content_schemas"`
+	DefaultContentSchema json.RawMessage            `json:"default_content_schema" yaml:"default_content_schema"`
+	// PartialIndexTypes lists high-volume types that get their own partial
+	// index when migrations run (Postgres and SQLite only).
+	PartialIndexTypes []string `json:"partial_index_types" yaml:"partial_index_types"`
+	// HydraDocs serves a Hydra ApiDocumentation on GET /translations when
+	// JSON-LD is requested without query parameters.
+	HydraDocs bool `json:"hydra_docs" yaml:"hydra_docs"`
+	// ResponseFormat shapes collections as Hydra collections (hydra, default)
+	// or as {"data", "total", "limit", "offset"} with plain members (plain).
+	ResponseFormat string `json:"response_format" yaml:"response_format"`
+	// TrimContent strips leading and trailing whitespace from content, as it
+	// does when unset. Set it to false for catalogs where surrounding
+	// whitespace is meaningful.
+	TrimContent *bool `json:"trim_content" yaml:"trim_content"`
+	// CollapseWhitespace turns every run of spaces and tabs inside content
+	// into a single space, keeping line breaks. TrimLines strips the
+	// whitespace around each line. Neither touches json or html types.
+	CollapseWhitespace bool `json:"collapse_whitespace" yaml:"collapse_whitespace"`
+	TrimLines          bool `json:"trim_lines" yaml:"trim_lines"`
+	// NormalizeUnicode stores content in Unicode normalization form C, so
+	// strings that render the same, such as a precomposed é and e followed by
+	// a combining accent, are stored, measured and compared the same.
+	NormalizeUnicode bool `json:"normalize_unicode" yaml:"normalize_unicode"`
+	// FallbackChain lists, per requested locale, the locales to try next when it
+	// has no translation (e.g. "fr-CA": ["fr"]).
+	FallbackChain map[string][]string `json:"fallback_chain" yaml:"fallback_chain"`
+	// FallbackStrategy decides whether the default locale is tried after the
+	// requested locale chain (chain_then_default) or never (chain_only).
+	FallbackStrategy string `json:"fallback_strategy" yaml:"fallback_strategy"`
+	// TranslateOnMiss asks the Translator for a missing locale while resolving
+	// it, waiting at most TranslateOnMissTimeout before serving the fallback.
+	TranslateOnMiss        bool          `json:"translate_on_miss" yaml:"translate_on_miss"`
+	TranslateOnMissTimeout time.Duration `json:"translate_on_miss_timeout" yaml:"translate_on_miss_timeout"`
+	// StoreRawContent keeps the content as submitted, before HTML escaping, so
+	// reads with ?raw=true can return it for re-editing.
+	StoreRawContent bool `json:"store_raw_content" yaml:"store_raw_content"`
+	// DefaultStatus is the editorial status new translations start in, draft
+	// or review. RequireReview only lets translations under review be
+	// published. DefaultStatusFilter restricts collection reads without
+	// ?status= to one status, e.g. published for public consumers.
+	DefaultStatus       string `json:"default_status" yaml:"default_status"`
+	RequireReview       bool   `json:"require_review" yaml:"require_review"`
+	DefaultStatusFilter string `json:"default_status_filter" yaml:"default_status_filter"`
+	// DefaultLocaleFirst rejects new translations in other locales until the
+	// entity has one in DefaultLocale, which fallbacks rely on. Users holding
+	// AdminRole can bypass it with ?force=true.
+	DefaultLocaleFirst bool   `json:"default_locale_first" yaml:"default_locale_first"`
+	AdminRole          string `json:"admin_role" yaml:"admin_role"`
+	// OwnershipPolicy sets who may change a translation owned by a user:
+	// anyone (none), only its owner (owner-only) or its owner and admins
+	// (owner-or-admin), anonymous callers being rejected by the last two.
+	// Unset, only authenticated callers are held to their own translations.
+	// AdminCheck, when set, replaces the AdminRole check of IsAdmin.
+	OwnershipPolicy string                         `json:"ownership_policy" yaml:"ownership_policy"`
+	AdminCheck      func(ctx context.Context) bool `json:"-" yaml:"-"`
+	// WarnOnUntranslated flags writes outside DefaultLocale whose content is
+	// identical to the source-locale content with HeaderTranslationWarning.
+	WarnOnUntranslated bool `json:"warn_on_untranslated" yaml:"warn_on_untranslated"`
+	// DeepLAPIKey enables DeepL as the TextTranslator unless one is set.
+	DeepLAPIKey    string         `json:"deepl_api_key" yaml:"deepl_api_key"`
+	TextTranslator TextTranslator `json:"-" yaml:"-"`
+	// EventHandler, when set, is notified of every created, updated and deleted
+	// translation.
+	EventHandler EventHandler `json:"-" yaml:"-"`
+	// Hooks are integrator callbacks run before and after every write.
+	Hooks LifecycleHooks `json:"-" yaml:"-"`
+	// EventSink, when set, is called with each created, updated and deleted
+	// translation after the write has been committed.
+	EventSink EventSink `json:"-" yaml:"-"`
+	// TrackReceivedAt stores, next to the database-assigned created_at, when the
+	// plugin received the request that created a translation, to measure the
+	// lag between the two.
+	TrackReceivedAt bool `json:"track_received_at" yaml:"track_received_at"`
+	// SoftDelete marks deleted translations with deleted_at instead of removing
+	// them, so they can be restored. Reads always skip marked rows.
+	SoftDelete bool `json:"soft_delete" yaml:"soft_delete"`
+	// TrashHidesContent leaves content out of the trash listing, which then
+	// only tells what was deleted, when and by whom.
+	TrashHidesContent bool `json:"trash_hides_content" yaml:"trash_hides_content"`
+	// EntityMetadataResolver, when set, attaches an entity object to each
+	// translation of a list read with ?expand=entity.
+	EntityMetadataResolver EntityMetadataResolver `json:"-" yaml:"-"`
+	// SecondaryWriter, when set, mirrors successful writes to an external store.
+	SecondaryWriter SecondaryWriter `json:"-" yaml:"-"`
+	// Webhooks are URLs each translation event is POSTed to, in the
+	// background, signed with WebhookSecret when set. Failed deliveries are
+	// retried WebhookRetries times with exponential backoff, 3 when unset
+	// and never when 0.
+	Webhooks       []string      `json:"webhooks" yaml:"webhooks"`
+	WebhookSecret  string        `json:"webhook_secret" yaml:"webhook_secret"`
+	WebhookRetries *int          `json:"webhook_retries" yaml:"webhook_retries"`
+	WebhookWorkers int           `json:"webhook_workers" yaml:"webhook_workers"`
+	WebhookTimeout time.Duration `json:"webhook_timeout" yaml:"webhook_timeout"`
+	// QueryTimeout bounds each database statement; a request whose query runs
+	// past it is answered 504. Zero, the default, leaves statements bounded by
+	// the request context only.
+	QueryTimeout time.Duration `json:"query_timeout" yaml:"query_timeout"`
+	// CacheTTL enables a cache of single-translation reads, by id and by
+	// entity and locale, each kept for CacheTTL at most and dropped when its
+	// translation is written. Cache defaults to a MemoryCache of CacheSize
+	// entries; use a shared store when several instances serve translations.
+	// Zero, the default, disables it.
+	CacheSize int           `json:"cache_size" yaml:"cache_size"`
+	CacheTTL  time.Duration `json:"cache_ttl" yaml:"cache_ttl"`
+	Cache     Cache         `json:"-" yaml:"-"`
+	// EnableMetrics collects Prometheus metrics, which the plugin does not
+	// serve itself: the host registers TranslatablePlugin.MetricsCollector in
+	// its own registry, or mounts TranslatablePlugin.MetricsHandler behind its
+	// own access control. The per-locale translation counts are refreshed at
+	// most once every MetricsRefreshInterval.
+	EnableMetrics          bool          `json:"enable_metrics" yaml:"enable_metrics"`
+	MetricsRefreshInterval time.Duration `json:"metrics_refresh_interval" yaml:"metrics_refresh_interval"`
+	// RateLimit caps the create, update and delete requests of each client.
+	// RateLimitStore defaults to a MemoryRateLimitStore when a limit is set;
+	// use a shared store to apply the limit across instances.
+	RateLimit      RateLimitConfig `json:"rate_limit" yaml:"rate_limit"`
+	RateLimitStore RateLimitStore  `json:"-" yaml:"-"`
+	// CORS lets browser front-ends on other origins call the routes. It is
+	// disabled until origins are listed.
+	CORS CORSConfig `json:"cors" yaml:"cors"`
+	// LegacyErrors serves error bodies as {"error": "..."} instead of RFC 7807
+	// problem documents, for clients written against earlier versions.
+	LegacyErrors bool `json:"legacy_errors" yaml:"legacy_errors"`
+	// JSONNaming spells the keys of request and response bodies in snake
+	// (translatable_id) or camel (translatableId) case. Left empty, bodies keep
+	// the keys documented below, for clients written against them.
+	JSONNaming string `json:"json_naming" yaml:"json_naming"`
+	// Messages overrides the built-in error messages, per locale and message
+	// key, e.g. {"de": {"content_empty": "Inhalt darf nicht leer sein"}}. The
+	// language is picked from Accept-Language, falling back to DefaultLocale.
+	Messages map[string]map[string]string `json:"messages" yaml:"messages"`
+	// TableName is the table translations are stored in. It must be a plain
+	// SQL identifier, as it is written into queries as is.
+	TableName string `json:"table_name" yaml:"table_name"`
+	// RoutePrefix is the path the translation routes are mounted under,
+	// relative to the plugin router, e.g. "/i18n/translations".
+	RoutePrefix string `json:"route_prefix" yaml:"route_prefix"`
+	// TenantScoped isolates translations per tenant: every request must carry
+	// a tenant in the tenant_id local, which new translations are stamped
+	// with and every read and write is restricted to.
+	TenantScoped bool `json:"tenant_scoped" yaml:"tenant_scoped"`
+
+	webhooks *webhookDispatcher
+	metrics  *translatableMetrics
 }
 
 func (c *Config) Validate() error {
+	for _, table := range c.AllowedTables {
+		if !slices.Contains(c.AllowedTypes, table) {
+			c.AllowedTypes = append(c.AllowedTypes, table)
+		}
+	}
+
 	if err := c.validateAllowedTypes(); err != nil {
 		return err
 	}
@@ -30,12 +273,125 @@ func (c *Config) Validate() error {
 		return err
 	}
 
+	if err := c.validateTypeTTLs(); err != nil {
+		return err
+	}
+
+	if err := c.validateContentSchemas(); err != nil {
+		return err
+	}
+
+	if err := c.validateFieldKeys(); err != nil {
+		return err
+	}
+
+	c.normalizeFallbackChain()
+	for locale, chain := range c.FallbackChain {
+		for _, fallback := range chain {
+			if !c.IsSupportedLocale(fallback) {
+				return fmt.Errorf("fallback_chain for %s references unsupported locale: %s", locale, fallback)
+			}
+		}
+	}
+
+	if c.JSONNaming != "" && c.JSONNaming != JSONNamingSnake && c.JSONNaming != JSONNamingCamel {
+		return fmt.Errorf("json_naming must be %s or %s", JSONNamingSnake, JSONNamingCamel)
+	}
+
+	if c.DefaultStatus == "" {
+		c.DefaultStatus = StatusDraft
+	}
+	if c.DefaultStatus != StatusDraft && c.DefaultStatus != StatusReview {
+		return fmt.Errorf("default_status must be %s or %s", StatusDraft, StatusReview)
+	}
+	if c.DefaultStatusFilter != "" && !isStatus(c.DefaultStatusFilter) {
+		return fmt.Errorf("default_status_filter must be %s, %s or %s", StatusDraft, StatusReview, StatusPublished)
+	}
+
+	for _, field := range c.SortableColumns {
+		if _, ok := translatableFieldMap[field]; !ok {
+			return fmt.Errorf("sortable_columns references unknown field: %s", field)
+		}
+	}
+
+	for _, typeName := range c.PartialIndexTypes {
+		if !c.IsAllowedType(typeName) {
+			return fmt.Errorf("partial_index_types references unknown type: %s", typeName)
+		}
+	}
+
 	c.applyDefaults()
 
+	if err := c.validateNaming(); err != nil {
+		return err
+	}
+
 	if c.MaxContentLength < 1 || c.MaxContentLength > 1048576 {
 		return errors.New("max_content_length must be between 1 and 1048576 bytes")
 	}
 
+	if c.ContentFormat != ContentFormatText && c.ContentFormat != ContentFormatJSON {
+		return fmt.Errorf("content_format must be %q or %q", ContentFormatText, ContentFormatJSON)
+	}
+
+	if c.ResponseFormat != ResponseFormatHydra && c.ResponseFormat != ResponseFormatPlain {
+		return fmt.Errorf("response_format must be %q or %q", ResponseFormatHydra, ResponseFormatPlain)
+	}
+
+	if !slices.Contains(sanitizerModes, c.SanitizeMode) {
+		return fmt.Errorf("sanitize_mode must be one of %s", strings.Join(sanitizerModes, ", "))
+	}
+
+	if c.OwnershipPolicy != "" && !slices.Contains(ownershipPolicies, c.OwnershipPolicy) {
+		return fmt.Errorf("ownership_policy must be one of %s", strings.Join(ownershipPolicies, ", "))
+	}
+	for typeName, mode := range c.TypeSanitizeModes {
+		if !c.IsAllowedType(typeName) {
+			return fmt.Errorf("type_sanitize_modes references unknown type: %s", typeName)
+		}
+		if !slices.Contains(sanitizerModes, mode) {
+			return fmt.Errorf("type_sanitize_modes for %s must be one of %s", typeName, strings.Join(sanitizerModes, ", "))
+		}
+	}
+
+	for typeName, kind := range c.TypeValidators {
+		if !c.IsAllowedType(typeName) {
+			return fmt.Errorf("type_validators references unknown type: %s", typeName)
+		}
+		if !slices.Contains(typeValidators, kind) {
+			return fmt.Errorf("type_validators for %s must be one of %s", typeName, strings.Join(typeValidators, ", "))
+		}
+	}
+
+	if c.FallbackStrategy != FallbackChainThenDefault && c.FallbackStrategy != FallbackChainOnly {
+		return fmt.Errorf("fallback_strategy must be %q or %q", FallbackChainThenDefault, FallbackChainOnly)
+	}
+
+	if err := c.validateWebhooks(); err != nil {
+		return err
+	}
+	if len(c.Webhooks) > 0 && c.webhooks == nil {
+		c.webhooks = newWebhookDispatcher(c)
+	}
+	if err := c.validateCORS(); err != nil {
+		return err
+	}
+	if err := c.validateRateLimit(); err != nil {
+		return err
+	}
+	if c.RateLimit.Requests > 0 && c.RateLimitStore == nil {
+		c.RateLimitStore = NewMemoryRateLimitStore()
+	}
+	if c.EnableMetrics && c.metrics == nil {
+		c.metrics = newTranslatableMetrics(c)
+	}
+	if c.CacheSize < 0 || c.CacheTTL < 0 {
+		return errors.New("cache_size and cache_ttl cannot be negative")
+	}
+	if c.CacheTTL > 0 && c.CacheSize > 0 && c.Cache == nil {
+		c.Cache = NewMemoryCache(c.CacheSize)
+	}
+
 	return nil
 }
 
@@ -64,15 +420,28 @@ func (c *Config) validateSupportedLocales() error {
 	}
 
 	seen := make(map[string]bool)
+	locales := make([]string, 0, len(c.SupportedLocales))
 	for _, locale := range c.SupportedLocales {
 		if locale == "" {
 			return errors.New("supported_locales cannot contain empty strings")
 		}
+		if c.strictLocales() {
+			canonical, err := canonicalLocale(locale)
+			if err != nil {
+				return fmt.Errorf("supported_locales contains a malformed BCP 47 tag: %s", locale)
+			}
+			if canonical != locale && !c.CanonicalLocales {
+				return fmt.Errorf("supported_locales contains a non-canonical BCP 47 tag: %s (use %s)", locale, canonical)
+			}
+			locale = canonical
+		}
 		if seen[locale] {
 			return fmt.Errorf("duplicate locale in supported_locales: %s", locale)
 		}
 		seen[locale] = true
+		locales = append(locales, locale)
 	}
+	c.SupportedLocales = locales
 
 	return nil
 }
@@ -81,6 +450,7 @@ func (c *Config) validateDefaultLocale() error {
 	if c.DefaultLocale == "" {
 		return errors.New("default_locale cannot be empty")
 	}
+	c.DefaultLocale = c.normalizeLocale(c.DefaultLocale)
 
 	for _, locale := range c.SupportedLocales {
 		if locale == c.DefaultLocale {
@@ -91,6 +461,164 @@ func (c *Config) validateDefaultLocale() error {
 	return errors.New("default_locale must be one of the supported_locales")
 }
 
+func (c *Config) validateTypeTTLs() error {
+	for typeName, ttl := range c.TypeTTLs {
+		if !c.IsAllowedType(typeName) {
+			return fmt.Errorf("type_ttls references unknown type: %s", typeName)
+		}
+		if ttl <= 0 {
+			return fmt.Errorf("type_ttls for %s must be positive", typeName)
+		}
+	}
+
+	return nil
+}
+
+func (c *Config) validateContentSchemas() error {
+	for typeName, schema := range c.ContentSchemas {
+		if !c.IsAllowedType(typeName) {
+			return fmt.Errorf("content_schemas references unknown type: %s", typeName)
+		}
+		if !isJSONObject(schema) {
+			return fmt.Errorf("content_schemas for %s must be a JSON object", typeName)
+		}
+	}
+
+	if c.DefaultContentSchema != nil && !isJSONObject(c.DefaultContentSchema) {
+		return errors.New("default_content_schema must be a JSON object")
+	}
+
+	return nil
+}
+
+// contentFormat returns the format the content of a type is validated and
+// stored in: JSON or text, per TypeValidators and then ContentFormat.
+func (c *Config) contentFormat(typeName string) string {
+	switch c.TypeValidators[typeName] {
+	case ValidatorJSON:
+		return ContentFormatJSON
+	case ValidatorText, ValidatorHTML:
+		return ContentFormatText
+	}
+	return c.ContentFormat
+}
+
+// sanitizeMode returns the mode the text content of a type is sanitized with,
+// SanitizerModeCustom when Config.Sanitizer applies.
+func (c *Config) sanitizeMode(typeName string) string {
+	if mode, ok := c.TypeSanitizeModes[typeName]; ok {
+		return mode
+	}
+	if c.TypeValidators[typeName] == ValidatorHTML {
+		return SanitizerModeAllowlist
+	}
+	if c.Sanitizer != nil {
+		return SanitizerModeCustom
+	}
+	return c.SanitizeMode
+}
+
+// sanitizer returns the Sanitizer applied to the text content of a type.
+func (c *Config) sanitizer(typeName string) Sanitizer {
+	switch c.sanitizeMode(typeName) {
+	case SanitizerModeCustom:
+		return c.Sanitizer
+	case SanitizerModeStrip:
+		return stripSanitizer
+	case SanitizerModeAllowlist:
+		return allowlistSanitizer
+	default:
+		return escapeSanitizer
+	}
+}
+
+// trimContent reports whether content is trimmed: unless TrimContent is false.
+func (c *Config) trimContent() bool {
+	return c.TrimContent == nil || *c.TrimContent
+}
+
+// normalizeContent applies TrimContent, the whitespace options and
+// NormalizeUnicode to content of a type as submitted.
+func (c *Config) normalizeContent(typeName, content string) string {
+	if c.trimContent() {
+		content = strings.TrimSpace(content)
+	}
+	if (c.CollapseWhitespace || c.TrimLines) && c.contentFormat(typeName) != ContentFormatJSON && c.TypeValidators[typeName] != ValidatorHTML {
+		content = normalizeWhitespace(content, c.CollapseWhitespace, c.TrimLines)
+	}
+	if c.NormalizeUnicode {
+		content = norm.NFC.String(content)
+	}
+	return content
+}
+
+// normalizeWhitespace collapses the runs of whitespace inside the lines of
+// content to single spaces and trims each line, as asked. Line breaks are
+// kept, CRLF ones as LF.
+func normalizeWhitespace(content string, collapse, trimLines bool) string {
+	lines := strings.Split(strings.ReplaceAll(content, "\r\n", "\n"), "\n")
+	for i, line := range lines {
+		if trimLines {
+			line = strings.TrimSpace(line)
+		}
+		if collapse {
+			var b strings.Builder
+			space := false
+			for _, r := range line {
+				if unicode.IsSpace(r) {
+					if !space {
+						b.WriteByte(' ')
+					}
+					space = true
+					continue
+				}
+				space = false
+				b.WriteRune(r)
+			}
+			line = b.String()
+		}
+		lines[i] = line
+	}
+	return strings.Join(lines, "\n")
+}
+
+// countRunes reports whether MaxContentLength counts characters: unless
+// CountRunes is false.
+func (c *Config) countRunes() bool {
+	return c.CountRunes == nil || *c.CountRunes
+}
+
+// contentLength measures content the way MaxContentLength is expressed, in
+// characters or in bytes depending on CountRunes.
+func (c *Config) contentLength(content string) int {
+	if c.countRunes() {
+		return utf8.RuneCountInString(content)
+	}
+	return len(content)
+}
+
+// contentLengthUnit names the unit of MaxContentLength, for messages.
+func (c *Config) contentLengthUnit() string {
+	if c.countRunes() {
+		return ContentLengthCharacters
+	}
+	return ContentLengthBytes
+}
+
+// ContentSchema returns the JSON Schema configured for a type, falling back to
+// DefaultContentSchema, or nil when neither is set.
+func (c *Config) ContentSchema(typeName string) json.RawMessage {
+	if schema, ok := c.ContentSchemas[typeName]; ok {
+		return schema
+	}
+	return c.DefaultContentSchema
+}
+
+func isJSONObject(raw json.RawMessage) bool {
+	var object map[string]json.RawMessage
+	return json.Unmarshal(raw, &object) == nil && object != nil
+}
+
 func (c *Config) applyDefaults() {
 	if c.PaginationLimit <= 0 {
 		c.PaginationLimit = 20
@@ -103,6 +631,86 @@ func (c *Config) applyDefaults() {
 	if c.MaxContentLength <= 0 {
 		c.MaxContentLength = 10240
 	}
+
+	if c.ContentFormat == "" {
+		c.ContentFormat = ContentFormatText
+	}
+
+	if c.ResponseFormat == "" {
+		c.ResponseFormat = ResponseFormatHydra
+	}
+
+	if c.MaxJSONDepth <= 0 {
+		c.MaxJSONDepth = 32
+	}
+
+	if c.MaxJSONKeys <= 0 {
+		c.MaxJSONKeys = 1000
+	}
+
+	if c.TranslatorTimeout <= 0 {
+		c.TranslatorTimeout = 30 * time.Second
+	}
+
+	if c.MaxLocalesPerPage <= 0 {
+		c.MaxLocalesPerPage = 100
+	}
+
+	if c.MaxBulkLookup <= 0 {
+		c.MaxBulkLookup = 200
+	}
+
+	if len(c.SortableColumns) == 0 {
+		c.SortableColumns = defaultSortableColumns
+	}
+
+	if c.MaxSnapshots <= 0 {
+		c.MaxSnapshots = 10
+	}
+
+	if c.SnapshotTTL <= 0 {
+		c.SnapshotTTL = 5 * time.Minute
+	}
+
+	if c.SanitizeMode == "" {
+		c.SanitizeMode = SanitizerModeEscape
+	}
+
+	if c.FallbackStrategy == "" {
+		c.FallbackStrategy = FallbackChainThenDefault
+	}
+
+	if c.AdminRole == "" {
+		c.AdminRole = "admin"
+	}
+
+	if c.TableName == "" {
+		c.TableName = DefaultTableName
+	}
+
+	if c.RoutePrefix == "" {
+		c.RoutePrefix = DefaultRoutePrefix
+	}
+
+	if c.TranslateOnMissTimeout <= 0 {
+		c.TranslateOnMissTimeout = 2 * time.Second
+	}
+
+	if c.WebhookWorkers <= 0 {
+		c.WebhookWorkers = defaultWebhookWorkers
+	}
+
+	if c.WebhookTimeout <= 0 {
+		c.WebhookTimeout = defaultWebhookTimeout
+	}
+
+	if c.MetricsRefreshInterval <= 0 {
+		c.MetricsRefreshInterval = defaultMetricsRefreshInterval
+	}
+
+	if c.RateLimit.Window <= 0 {
+		c.RateLimit.Window = time.Minute
+	}
 }
 
 func (c *Config) IsAllowedType(typeName string) bool {
@@ -114,6 +722,44 @@ func (c *Config) IsAllowedType(typeName string) bool {
 	return false
 }
 
+// Deprecated: use IsAllowedType.
+func (c *Config) IsAllowedTable(tableName string) bool {
+	return c.IsAllowedType(tableName)
+}
+
+// CanonicalType maps a stored type name to its spelling in AllowedTypes,
+// ignoring case. Unknown names are returned unchanged with ok set to false.
+func (c *Config) CanonicalType(typeName string) (canonical string, ok bool) {
+	for _, allowed := range c.AllowedTypes {
+		if strings.EqualFold(allowed, typeName) {
+			return allowed, true
+		}
+	}
+	return typeName, false
+}
+
+// textTranslator returns the configured TextTranslator, falling back to DeepL
+// when an API key is set, or nil when machine translation is not configured.
+func (c *Config) textTranslator() TextTranslator {
+	if c.TextTranslator != nil {
+		return c.TextTranslator
+	}
+	if c.DeepLAPIKey != "" {
+		return NewDeepLTranslator(c.DeepLAPIKey)
+	}
+	return nil
+}
+
+// IsAdmin reports whether the user on ctx is an admin: per AdminCheck when set,
+// otherwise when they hold AdminRole.
+func (c *Config) IsAdmin(ctx context.Context) bool {
+	if c.AdminCheck != nil {
+		return c.AdminCheck(ctx)
+	}
+	roles, _ := rbac.GetRoles(ctx)
+	return slices.Contains(roles, c.AdminRole)
+}
+
 func (c *Config) IsSupportedLocale(locale string) bool {
 	for _, supported := range c.SupportedLocales {
 		if supported == locale {
@@ -125,11 +771,28 @@ func (c *Config) IsSupportedLocale(locale string) bool {
 
 func DefaultConfig() Config {
 	return Config{
-		AllowedTypes:       []string{"post"},
-		SupportedLocales:   []string{"en", "fr", "es"},
-		DefaultLocale:      "en",
-		PaginationLimit:    20,
-		MaxPaginationLimit: 100,
-		MaxContentLength:   10240,
+		AllowedTypes:           []string{"post"},
+		SupportedLocales:       []string{"en", "fr", "es"},
+		DefaultLocale:          "en",
+		PaginationLimit:        20,
+		MaxPaginationLimit:     100,
+		MaxContentLength:       10240,
+		SanitizeMode:           SanitizerModeEscape,
+		ContentFormat:          ContentFormatText,
+		ResponseFormat:         ResponseFormatHydra,
+		MaxJSONDepth:           32,
+		MaxJSONKeys:            1000,
+		TranslatorTimeout:      30 * time.Second,
+		MaxLocalesPerPage:      100,
+		MaxBulkLookup:          200,
+		SortableColumns:        defaultSortableColumns,
+		MaxSnapshots:           10,
+		SnapshotTTL:            5 * time.Minute,
+		FallbackStrategy:       FallbackChainThenDefault,
+		DefaultStatus:          StatusDraft,
+		TranslateOnMissTimeout: 2 * time.Second,
+		AdminRole:              "admin",
+		TableName:              DefaultTableName,
+		RoutePrefix:            DefaultRoutePrefix,
 	}
 }
@@ -1,7 +1,10 @@
 package translatable
 
 import (
+	"encoding/json"
+	"reflect"
 	"testing"
+	"time"
 )
 
 func TestConfig_Validate(t *testing.T) {
@@ -83,6 +86,156 @@ func TestConfig_Validate(t *testing.T) {
 			wantErr: true,
 			errMsg:  "default_locale must be one of the supported_locales",
 		},
+		{
+			name: "unknown content format",
+			config: Config{
+				AllowedTypes:     []string{"posts"},
+				SupportedLocales: []string{"en"},
+				DefaultLocale:    "en",
+				ContentFormat:    "xml",
+			},
+			wantErr: true,
+			errMsg:  `content_format must be "text" or "json"`,
+		},
+		{
+			name: "unknown response format",
+			config: Config{
+				AllowedTypes:     []string{"posts"},
+				SupportedLocales: []string{"en"},
+				DefaultLocale:    "en",
+				ResponseFormat:   "xml",
+			},
+			wantErr: true,
+			errMsg:  `response_format must be "hydra" or "plain"`,
+		},
+		{
+			name: "unknown fallback strategy",
+			config: Config{
+				AllowedTypes:     []string{"posts"},
+				SupportedLocales: []string{"en"},
+				DefaultLocale:    "en",
+				FallbackStrategy: "default_only",
+			},
+			wantErr: true,
+			errMsg:  `fallback_strategy must be "chain_then_default" or "chain_only"`,
+		},
+		{
+			name: "negative rate limit",
+			config: Config{
+				AllowedTypes:     []string{"posts"},
+				SupportedLocales: []string{"en"},
+				DefaultLocale:    "en",
+				RateLimit:        RateLimitConfig{Requests: -1},
+			},
+			wantErr: true,
+			errMsg:  "rate_limit requests cannot be negative",
+		},
+		{
+			name: "schema for unknown type",
+			config: Config{
+				AllowedTypes:     []string{"posts"},
+				SupportedLocales: []string{"en"},
+				DefaultLocale:    "en",
+				ContentSchemas:   map[string]json.RawMessage{"articles": json.RawMessage(`{"type":"object"}`)},
+			},
+			wantErr: true,
+			errMsg:  "content_schemas references unknown type: articles",
+		},
+		{
+			name: "schema that is not an object",
+			config: Config{
+				AllowedTypes:     []string{"posts"},
+				SupportedLocales: []string{"en"},
+				DefaultLocale:    "en",
+				ContentSchemas:   map[string]json.RawMessage{"posts": json.RawMessage(`[1, 2]`)},
+			},
+			wantErr: true,
+			errMsg:  "content_schemas for posts must be a JSON object",
+		},
+		{
+			name: "partial index for unknown type",
+			config: Config{
+				AllowedTypes:      []string{"posts"},
+				SupportedLocales:  []string{"en"},
+				DefaultLocale:     "en",
+				PartialIndexTypes: []string{"articles"},
+			},
+			wantErr: true,
+			errMsg:  "partial_index_types references unknown type: articles",
+		},
+		{
+			name: "fallback chain to unsupported locale",
+			config: Config{
+				AllowedTypes:     []string{"posts"},
+				SupportedLocales: []string{"en", "fr"},
+				DefaultLocale:    "en",
+				FallbackChain:    map[string][]string{"fr-CA": {"fr", "de"}},
+			},
+			wantErr: true,
+			errMsg:  "fallback_chain for fr-CA references unsupported locale: de",
+		},
+		{
+			name: "ttl for unknown type",
+			config: Config{
+				AllowedTypes:     []string{"posts"},
+				SupportedLocales: []string{"en"},
+				DefaultLocale:    "en",
+				TypeTTLs:         map[string]time.Duration{"notification": time.Hour},
+			},
+			wantErr: true,
+			errMsg:  "type_ttls references unknown type: notification",
+		},
+		{
+			name: "non-positive ttl",
+			config: Config{
+				AllowedTypes:     []string{"notification"},
+				SupportedLocales: []string{"en"},
+				DefaultLocale:    "en",
+				TypeTTLs:         map[string]time.Duration{"notification": 0},
+			},
+			wantErr: true,
+			errMsg:  "type_ttls for notification must be positive",
+		},
+		{
+			name: "strict locales with the same tag spelled twice",
+			config: Config{
+				AllowedTypes:     []string{"posts"},
+				SupportedLocales: []string{"en", "en-US", "en_US"},
+				DefaultLocale:    "en",
+				CanonicalLocales: true,
+			},
+			wantErr: true,
+			errMsg:  "duplicate locale in supported_locales: en-US",
+		},
+		{
+			name: "strict locales with non-canonical tag",
+			config: Config{
+				AllowedTypes:     []string{"posts"},
+				SupportedLocales: []string{"en", "en_US"},
+				DefaultLocale:    "en",
+			},
+			wantErr: true,
+			errMsg:  "supported_locales contains a non-canonical BCP 47 tag: en_US (use en-US)",
+		},
+		{
+			name: "strict locales with malformed tag",
+			config: Config{
+				AllowedTypes:     []string{"posts"},
+				SupportedLocales: []string{"en", "frr-nonsense"},
+				DefaultLocale:    "en",
+			},
+			wantErr: true,
+			errMsg:  "supported_locales contains a malformed BCP 47 tag: frr-nonsense",
+		},
+		{
+			name: "custom locale codes without strict locales",
+			config: Config{
+				AllowedTypes:     []string{"posts"},
+				SupportedLocales: []string{"en", "en_US", "klingon"},
+				DefaultLocale:    "en",
+				StrictLocales:    new(false),
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -151,6 +304,64 @@ func TestConfig_IsAllowedType(t *testing.T) {
 	}
 }
 
+func TestConfig_AllowedTablesAlias(t *testing.T) {
+	tests := []struct {
+		name   string
+		config Config
+	}{
+		{name: "allowed types", config: Config{AllowedTypes: []string{"posts", "articles"}}},
+		{name: "deprecated allowed tables", config: Config{AllowedTables: []string{"posts", "articles"}}},
+		{name: "both, merged", config: Config{AllowedTypes: []string{"posts"}, AllowedTables: []string{"posts", "articles"}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := tt.config
+			config.SupportedLocales = []string{"en"}
+			config.DefaultLocale = "en"
+
+			if err := config.Validate(); err != nil {
+				t.Fatalf("Validate() error = %v", err)
+			}
+			if len(config.AllowedTypes) != 2 {
+				t.Errorf("AllowedTypes = %v, want [posts articles]", config.AllowedTypes)
+			}
+			for _, name := range []string{"posts", "articles"} {
+				if !config.IsAllowedType(name) || !config.IsAllowedTable(name) {
+					t.Errorf("%s should be allowed", name)
+				}
+			}
+			if config.IsAllowedTable("users") {
+				t.Error("users should not be allowed")
+			}
+		})
+	}
+}
+
+func TestConfig_CanonicalType(t *testing.T) {
+	config := Config{AllowedTypes: []string{"posts", "BlogEntry"}}
+
+	tests := []struct {
+		stored    string
+		canonical string
+		ok        bool
+	}{
+		{stored: "posts", canonical: "posts", ok: true},
+		{stored: "POSTS", canonical: "posts", ok: true},
+		{stored: "blogentry", canonical: "BlogEntry", ok: true},
+		{stored: "users", canonical: "users", ok: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.stored, func(t *testing.T) {
+			canonical, ok := config.CanonicalType(tt.stored)
+			if canonical != tt.canonical || ok != tt.ok {
+				t.Errorf("CanonicalType(%q) = (%q, %v), want (%q, %v)", tt.stored, canonical, ok, tt.canonical, tt.ok)
+			}
+		})
+	}
+}
+
 func TestConfig_IsSupportedLocale(t *testing.T) {
 	config := Config{
 		SupportedLocales: []string{"en", "fr", "es"},
@@ -223,3 +434,38 @@ func TestDefaultConfig(t *testing.T) {
 		t.Errorf("DefaultConfig() should be valid, got error: %v", err)
 	}
 }
+
+func TestConfig_Validate_NormalizesLocales(t *testing.T) {
+	config := Config{
+		AllowedTypes:     []string{"posts"},
+		SupportedLocales: []string{"EN-us", "fr_fr", "de"},
+		DefaultLocale:    "en_US",
+		FallbackChain:    map[string][]string{"fr_FR": {"en-us"}},
+		CanonicalLocales: true,
+	}
+	if err := config.Validate(); err != nil {
+		t.Fatalf("Validate() unexpected error = %v", err)
+	}
+	if want := []string{"en-US", "fr-FR", "de"}; !reflect.DeepEqual(config.SupportedLocales, want) {
+		t.Errorf("SupportedLocales = %v, want %v", config.SupportedLocales, want)
+	}
+	if config.DefaultLocale != "en-US" {
+		t.Errorf("DefaultLocale = %v, want en-US", config.DefaultLocale)
+	}
+	if want := map[string][]string{"fr-FR": {"en-US"}}; !reflect.DeepEqual(config.FallbackChain, want) {
+		t.Errorf("FallbackChain = %v, want %v", config.FallbackChain, want)
+	}
+
+	legacy := Config{
+		AllowedTypes:     []string{"posts"},
+		SupportedLocales: []string{"en_US", "fr_fr"},
+		DefaultLocale:    "en_US",
+		StrictLocales:    new(false),
+	}
+	if err := legacy.Validate(); err != nil {
+		t.Fatalf("Validate() unexpected error = %v", err)
+	}
+	if want := []string{"en_US", "fr_fr"}; !reflect.DeepEqual(legacy.SupportedLocales, want) {
+		t.Errorf("SupportedLocales = %v, want %v", legacy.SupportedLocales, want)
+	}
+}
@@ -0,0 +1,71 @@
+package translatable
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"strings"
+)
+
+var (
+	errInvalidJSON     = errors.New("content must be valid JSON")
+	errJSONTooDeep     = errors.New("content exceeds maximum JSON depth")
+	errJSONTooManyKeys = errors.New("content exceeds maximum number of JSON keys")
+)
+
+type jsonFrame struct {
+	object    bool
+	expectKey bool
+}
+
+// validateJSONContent walks the token stream of a JSON document and rejects it
+// as soon as it nests deeper than maxDepth or declares more than maxKeys object
+// keys, so oversized structures are never fully materialized.
+func validateJSONContent(content string, maxDepth, maxKeys int) error {
+	if !json.Valid([]byte(content)) {
+		return errInvalidJSON
+	}
+
+	dec := json.NewDecoder(strings.NewReader(content))
+	dec.UseNumber()
+
+	var stack []jsonFrame
+	keys := 0
+
+	for {
+		tok, err := dec.Token()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return errInvalidJSON
+		}
+
+		if delim, ok := tok.(json.Delim); ok {
+			switch delim {
+			case '{', '[':
+				if len(stack) >= maxDepth {
+					return errJSONTooDeep
+				}
+				stack = append(stack, jsonFrame{object: delim == '{', expectKey: delim == '{'})
+				continue
+			case '}', ']':
+				stack = stack[:len(stack)-1]
+			}
+		} else if len(stack) > 0 {
+			top := &stack[len(stack)-1]
+			if top.object && top.expectKey {
+				keys++
+				if keys > maxKeys {
+					return errJSONTooManyKeys
+				}
+				top.expectKey = false
+				continue
+			}
+		}
+
+		if len(stack) > 0 && stack[len(stack)-1].object {
+			stack[len(stack)-1].expectKey = true
+		}
+	}
+}
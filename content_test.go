@@ -0,0 +1,65 @@
+package translatable
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestValidateJSONContent(t *testing.T) {
+	tests := []struct {
+		name     string
+		content  string
+		maxDepth int
+		maxKeys  int
+		wantErr  error
+	}{
+		{
+			name:     "flat object",
+			content:  `{"title":"Hello","body":"World"}`,
+			maxDepth: 4,
+			maxKeys:  10,
+		},
+		{
+			name:     "nested arrays within depth",
+			content:  `{"blocks":[{"type":"p","text":"a"},{"type":"p","text":"b"}]}`,
+			maxDepth: 3,
+			maxKeys:  10,
+		},
+		{
+			name:     "invalid json",
+			content:  `{bad`,
+			maxDepth: 4,
+			maxKeys:  10,
+			wantErr:  errInvalidJSON,
+		},
+		{
+			name:     "too deep",
+			content:  strings.Repeat("[", 5) + strings.Repeat("]", 5),
+			maxDepth: 4,
+			maxKeys:  10,
+			wantErr:  errJSONTooDeep,
+		},
+		{
+			name:     "too many keys",
+			content:  `{"a":1,"b":2,"c":{"d":3}}`,
+			maxDepth: 4,
+			maxKeys:  3,
+			wantErr:  errJSONTooManyKeys,
+		},
+		{
+			name:     "string values are not counted as keys",
+			content:  `{"a":"b","c":["d","e","f"]}`,
+			maxDepth: 4,
+			maxKeys:  2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateJSONContent(tt.content, tt.maxDepth, tt.maxKeys)
+			if err != tt.wantErr {
+				t.Errorf("validateJSONContent() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -1,12 +1,18 @@
 package translatable
 
 import (
+	"html"
 	"time"
+	"unicode/utf8"
 
 	"github.com/google/uuid"
 )
 
-type TranslatableConverter struct{}
+// TranslatableConverter maps translations to and from their DTOs. Config
+// decides how content_length is measured; without one it counts characters.
+type TranslatableConverter struct {
+	config *Config
+}
 
 func (c *TranslatableConverter) CreateDTOToModel(dto TranslatableCreateDTO) Translatable {
 	translatableID, _ := uuid.Parse(dto.TranslatableID)
@@ -29,7 +35,30 @@ func (c *TranslatableConverter) UpdateDTOToModel(dto TranslatableUpdateDTO) Tran
 }
 
 func (c *TranslatableConverter) ModelToResponseDTO(model Translatable) TranslatableResponseDTO {
-	return TranslatableResponseDTO(model)
+	return TranslatableResponseDTO{
+		ID:             model.ID,
+		UserID:         model.UserID,
+		TranslatableID: model.TranslatableID,
+		Translatable:   model.Translatable,
+		Locale:         model.Locale,
+		Content:        model.Content,
+		Fields:         model.Fields,
+		ContentLength:  c.contentLength(&model),
+		Checksum:       ContentChecksum(model.Content),
+		PublishedAt:    model.PublishedAt,
+		ExpiresAt:      model.ExpiresAt,
+		AutoTranslated: model.AutoTranslated,
+		SourceChecksum: model.SourceChecksum,
+		UpdatedAt:      model.UpdatedAt,
+		CreatedAt:      model.CreatedAt,
+		ReceivedAt:     model.ReceivedAt,
+		Version:        model.Version,
+		Status:         translationStatus(&model),
+		ReviewedBy:     model.ReviewedBy,
+		ReviewedAt:     model.ReviewedAt,
+		Entity:         model.Entity,
+		User:           model.User,
+	}
 }
 
 func (c *TranslatableConverter) ModelsToResponseDTOs(models []Translatable) []TranslatableResponseDTO {
@@ -39,3 +68,17 @@ func (c *TranslatableConverter) ModelsToResponseDTOs(models []Translatable) []Tr
 	}
 	return dtos
 }
+
+// contentLength measures the content of model as MaxContentLength does: on
+// the text as submitted, before HTML escaping, in characters or bytes per
+// CountRunes.
+func (c *TranslatableConverter) contentLength(model *Translatable) int {
+	content := model.Content
+	if c.config == nil {
+		return utf8.RuneCountInString(html.UnescapeString(content))
+	}
+	if c.config.contentFormat(model.Translatable) != ContentFormatJSON {
+		content = html.UnescapeString(content)
+	}
+	return c.config.contentLength(content)
+}
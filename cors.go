@@ -0,0 +1,71 @@
+package translatable
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"slices"
+
+	"github.com/gofiber/fiber/v3"
+	"github.com/gofiber/fiber/v3/middleware/cors"
+)
+
+// CORSConfig lets browser front-ends on AllowOrigins call the routes. An empty
+// AllowOrigins disables CORS; "*" allows any origin, but not with
+// AllowCredentials. AllowMethods defaults to the methods the routes serve and
+// an empty AllowHeaders allows the headers a preflight asks for.
+type CORSConfig struct {
+	AllowOrigins     []string `json:"allow_origins" yaml:"allow_origins"`
+	AllowMethods     []string `json:"allow_methods" yaml:"allow_methods"`
+	AllowHeaders     []string `json:"allow_headers" yaml:"allow_headers"`
+	AllowCredentials bool     `json:"allow_credentials" yaml:"allow_credentials"`
+}
+
+// corsExposedHeaders are the response headers of the plugin scripts on other
+// origins may read.
+var corsExposedHeaders = []string{
+	fiber.HeaderETag,
+	fiber.HeaderLocation,
+	fiber.HeaderLink,
+	fiber.HeaderRetryAfter,
+	fiber.HeaderContentDisposition,
+	"X-Total-Count",
+	HeaderRequestID,
+	HeaderLocaleFallback,
+	HeaderTranslationSource,
+	HeaderTranslationWarning,
+}
+
+// corsMiddleware adds the Access-Control-* headers to the responses of
+// allowed origins and answers their preflight requests with 204.
+func corsMiddleware(config CORSConfig) fiber.Handler {
+	return cors.New(cors.Config{
+		AllowOrigins:     config.AllowOrigins,
+		AllowMethods:     config.AllowMethods,
+		AllowHeaders:     config.AllowHeaders,
+		AllowCredentials: config.AllowCredentials,
+		ExposeHeaders:    corsExposedHeaders,
+	})
+}
+
+func (c *Config) validateCORS() error {
+	if len(c.CORS.AllowOrigins) == 0 {
+		return nil
+	}
+	if c.CORS.AllowCredentials && slices.Contains(c.CORS.AllowOrigins, "*") {
+		return errors.New("cors allow_credentials cannot be used with the * origin")
+	}
+	for _, origin := range c.CORS.AllowOrigins {
+		if origin == "*" {
+			continue
+		}
+		parsed, err := url.Parse(origin)
+		if err != nil || parsed.Scheme == "" || parsed.Host == "" || parsed.Path != "" {
+			return fmt.Errorf("cors origin must be a scheme and host: %s", origin)
+		}
+	}
+	if len(c.CORS.AllowMethods) == 0 {
+		c.CORS.AllowMethods = []string{fiber.MethodGet, fiber.MethodHead, fiber.MethodPost, fiber.MethodPut, fiber.MethodPatch, fiber.MethodDelete}
+	}
+	return nil
+}
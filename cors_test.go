@@ -0,0 +1,109 @@
+package translatable
+
+import (
+	"context"
+	"database/sql"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v3"
+	"github.com/google/uuid"
+	"github.com/nicolasbonnici/gorest-translatable/mocks"
+	"github.com/nicolasbonnici/gorest/database"
+	"github.com/stretchr/testify/assert"
+)
+
+func corsTestApp(t *testing.T, cors CORSConfig) *fiber.App {
+	db := &mocks.MockDatabase{
+		QueryRowFunc: func(ctx context.Context, query string, args ...interface{}) database.Row {
+			return &mocks.MockRow{ScanFunc: func(dest ...interface{}) error { return sql.ErrNoRows }}
+		},
+	}
+	config := DefaultConfig()
+	config.CORS = cors
+	if err := config.Validate(); err != nil {
+		t.Fatal(err)
+	}
+	app := fiber.New()
+	RegisterTranslatableRoutes(app, db, &config, nil, nil)
+	return app
+}
+
+func TestCORS_Preflight(t *testing.T) {
+	app := corsTestApp(t, CORSConfig{AllowOrigins: []string{"https://app.example.com"}, AllowCredentials: true})
+
+	req := httptest.NewRequest(fiber.MethodOptions, "/translations/"+uuid.NewString(), nil)
+	req.Header.Set(fiber.HeaderOrigin, "https://app.example.com")
+	req.Header.Set(fiber.HeaderAccessControlRequestMethod, fiber.MethodPut)
+	req.Header.Set(fiber.HeaderAccessControlRequestHeaders, "If-Match")
+	resp, err := app.Test(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, fiber.StatusNoContent, resp.StatusCode)
+	assert.Equal(t, "https://app.example.com", resp.Header.Get(fiber.HeaderAccessControlAllowOrigin))
+	assert.Contains(t, resp.Header.Get(fiber.HeaderAccessControlAllowMethods), fiber.MethodPut)
+	assert.Equal(t, "If-Match", resp.Header.Get(fiber.HeaderAccessControlAllowHeaders))
+	assert.Equal(t, "true", resp.Header.Get(fiber.HeaderAccessControlAllowCredentials))
+}
+
+func TestCORS_ActualRequest(t *testing.T) {
+	tests := []struct {
+		name    string
+		cors    CORSConfig
+		origin  string
+		allowed string
+	}{
+		{name: "allowed origin", cors: CORSConfig{AllowOrigins: []string{"https://app.example.com"}}, origin: "https://app.example.com", allowed: "https://app.example.com"},
+		{name: "any origin", cors: CORSConfig{AllowOrigins: []string{"*"}}, origin: "https://app.example.com", allowed: "*"},
+		{name: "other origin", cors: CORSConfig{AllowOrigins: []string{"https://app.example.com"}}, origin: "https://evil.example.com"},
+		{name: "disabled", origin: "https://app.example.com"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := corsTestApp(t, tt.cors)
+
+			req := httptest.NewRequest(fiber.MethodGet, "/translations/"+uuid.NewString(), nil)
+			req.Header.Set(fiber.HeaderOrigin, tt.origin)
+			resp, err := app.Test(req)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			assert.Equal(t, fiber.StatusNotFound, resp.StatusCode)
+			assert.Equal(t, tt.allowed, resp.Header.Get(fiber.HeaderAccessControlAllowOrigin))
+			if tt.allowed != "" {
+				assert.Contains(t, resp.Header.Get(fiber.HeaderAccessControlExposeHeaders), HeaderRequestID)
+			}
+		})
+	}
+}
+
+func TestCORSConfig_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		cors    CORSConfig
+		wantErr string
+	}{
+		{name: "disabled"},
+		{name: "origins", cors: CORSConfig{AllowOrigins: []string{"https://app.example.com", "http://localhost:3000"}}},
+		{name: "any origin with credentials", cors: CORSConfig{AllowOrigins: []string{"*"}, AllowCredentials: true}, wantErr: "cors allow_credentials cannot be used with the * origin"},
+		{name: "origin with a path", cors: CORSConfig{AllowOrigins: []string{"https://app.example.com/admin"}}, wantErr: "cors origin must be a scheme and host: https://app.example.com/admin"},
+		{name: "bare host", cors: CORSConfig{AllowOrigins: []string{"app.example.com"}}, wantErr: "cors origin must be a scheme and host: app.example.com"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := DefaultConfig()
+			config.CORS = tt.cors
+			err := config.Validate()
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
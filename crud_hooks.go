@@ -0,0 +1,266 @@
+package translatable
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/gofiber/fiber/v3"
+	"github.com/google/uuid"
+	"github.com/nicolasbonnici/gorest/database"
+	"github.com/nicolasbonnici/gorest/hooks"
+	"github.com/nicolasbonnici/gorest/query"
+)
+
+const (
+	ReadStateDraft     = "draft"
+	ReadStatePublished = "published"
+)
+
+type contextKey string
+
+const (
+	readStateKey     contextKey = "translatable_read_state"
+	pendingDeleteKey contextKey = "translatable_pending_delete"
+	cacheBypassKey   contextKey = "translatable_cache_bypass"
+	rawContentKey    contextKey = "translatable_raw_content"
+	previousKey      contextKey = "translatable_previous"
+	writeLocaleKey   contextKey = "translatable_write_locale"
+	deletedByKey     contextKey = "translatable_deleted_by"
+)
+
+// translatableCRUDHooks plugs into the gorest CRUD layer to apply request-scoped
+// read rules that the processor hooks cannot express on their own.
+type translatableCRUDHooks struct {
+	*hooks.NoOpHooks[Translatable]
+	config *Config
+	// db, when set, is the database of the CRUD layer, which soft deletes are
+	// built for and the authors of ?include=user are read from.
+	db database.Database
+}
+
+func newTranslatableCRUDHooks(config *Config) *translatableCRUDHooks {
+	return &translatableCRUDHooks{
+		NoOpHooks: hooks.NewNoOpHooks[Translatable](),
+		config:    config,
+	}
+}
+
+func withReadState(ctx context.Context, state string) context.Context {
+	return context.WithValue(ctx, readStateKey, state)
+}
+
+func readStateFromContext(ctx context.Context) string {
+	state, _ := ctx.Value(readStateKey).(string)
+	return state
+}
+
+func withRawContent(ctx context.Context) context.Context {
+	return context.WithValue(ctx, rawContentKey, true)
+}
+
+func rawContentFromContext(ctx context.Context) bool {
+	raw, _ := ctx.Value(rawContentKey).(bool)
+	return raw
+}
+
+func withCacheBypass(ctx context.Context) context.Context {
+	return context.WithValue(ctx, cacheBypassKey, true)
+}
+
+// cacheBypassFromContext reports whether the read must skip cached entries and
+// go to the database. The fresh value may still be written back to the cache.
+func cacheBypassFromContext(ctx context.Context) bool {
+	bypass, _ := ctx.Value(cacheBypassKey).(bool)
+	return bypass
+}
+
+// withPendingDelete records the translation a delete request targets so it can
+// be mirrored once the row is actually gone.
+func withPendingDelete(ctx context.Context, id uuid.UUID) context.Context {
+	return context.WithValue(ctx, pendingDeleteKey, id)
+}
+
+// withDeletedBy records the user a delete request comes from, stored as
+// updated_by by soft deletes.
+func withDeletedBy(ctx context.Context, userID *uuid.UUID) context.Context {
+	return context.WithValue(ctx, deletedByKey, userID)
+}
+
+// deletedByFromContext returns the user recorded by withDeletedBy, nil when
+// the request is anonymous.
+func deletedByFromContext(ctx context.Context) *uuid.UUID {
+	userID, _ := ctx.Value(deletedByKey).(*uuid.UUID)
+	return userID
+}
+
+// withPreviousVersion records the row an update or delete request is about to
+// change, read before the write, so events can describe the transition.
+func withPreviousVersion(ctx context.Context, t *Translatable) context.Context {
+	return context.WithValue(ctx, previousKey, t)
+}
+
+func previousVersionFromContext(ctx context.Context) *Translatable {
+	t, _ := ctx.Value(previousKey).(*Translatable)
+	return t
+}
+
+// withWriteLocale records the locale a create or update request writes, which
+// a unique constraint violation is reported against.
+func withWriteLocale(ctx context.Context, locale string) context.Context {
+	return context.WithValue(ctx, writeLocaleKey, locale)
+}
+
+func writeLocaleFromContext(ctx context.Context) string {
+	locale, _ := ctx.Value(writeLocaleKey).(string)
+	return locale
+}
+
+func (h *translatableCRUDHooks) ModifySelectQuery(ctx context.Context, operation hooks.Operation, builder *query.SelectBuilder) (*query.SelectBuilder, bool) {
+	builder = builder.Where(query.IsNull("deleted_at"))
+	builder = builder.Where(query.Or(query.IsNull("expires_at"), query.Gt("expires_at", time.Now())))
+	if readStateFromContext(ctx) == ReadStatePublished {
+		builder = builder.Where(query.IsNotNull("published_at"))
+	}
+	if h.config.TenantScoped {
+		builder = builder.Where(query.Eq("tenant_id", getTenantIDFromContext(ctx)))
+	}
+	return builder, true
+}
+
+func (h *translatableCRUDHooks) SerializeOne(ctx context.Context, operation hooks.Operation, model *Translatable) error {
+	switch operation {
+	case hooks.OperationCreate:
+		mirrorUpsert(ctx, h.config.SecondaryWriter, model)
+		emitCreated(ctx, h.config, model)
+		recordETag(ctx, model)
+	case hooks.OperationUpdate:
+		mirrorUpsert(ctx, h.config.SecondaryWriter, model)
+		emitUpdated(ctx, h.config, previousVersionFromContext(ctx), model)
+	case hooks.OperationGetByID:
+		recordETag(ctx, model)
+	}
+
+	model.Translatable, _ = h.config.CanonicalType(model.Translatable)
+	if readStateFromContext(ctx) == ReadStatePublished {
+		servePublished(model)
+	} else if rawContentFromContext(ctx) {
+		serveRaw(model)
+	}
+	h.config.serveFields(model)
+	if h.db != nil && includeUserFromContext(ctx) {
+		models := []Translatable{*model}
+		attachUsers(ctx, h.db, models)
+		model.User = models[0].User
+	}
+	return nil
+}
+
+// ModifyUpdateQuery only lets an update through if the translation is still at
+// the version UpdateHook read, and belongs to the request's tenant.
+func (h *translatableCRUDHooks) ModifyUpdateQuery(ctx context.Context, operation hooks.Operation, id any, model *Translatable, builder *query.UpdateBuilder) (*query.UpdateBuilder, bool) {
+	guard := versionGuardFromContext(ctx)
+	if guard == nil && !h.config.TenantScoped {
+		return builder, false
+	}
+	if guard != nil {
+		builder = builder.Where(query.Eq("version", guard.version))
+	}
+	if h.config.TenantScoped {
+		builder = builder.Where(query.Eq("tenant_id", getTenantIDFromContext(ctx)))
+	}
+	return builder, true
+}
+
+// ModifyDeleteQuery restricts deletes to the request's tenant.
+func (h *translatableCRUDHooks) ModifyDeleteQuery(ctx context.Context, operation hooks.Operation, id any, builder *query.DeleteBuilder) (*query.DeleteBuilder, bool) {
+	if !h.config.TenantScoped {
+		return builder, false
+	}
+	return builder.Where(query.Eq("tenant_id", getTenantIDFromContext(ctx))), true
+}
+
+// BeforeQuery turns deletes into soft deletes when Config.SoftDelete is set:
+// the translation DeleteHook read is marked deleted by the requesting user
+// rather than removed, by a statement built for the dialect of the database
+// like the CRUD layer's own.
+func (h *translatableCRUDHooks) BeforeQuery(ctx context.Context, operation hooks.Operation, sql string, args []any) (string, []any, error) {
+	if operation != hooks.OperationDelete || !h.config.SoftDelete {
+		return sql, args, nil
+	}
+	id, ok := ctx.Value(pendingDeleteKey).(uuid.UUID)
+	if !ok || h.db == nil {
+		return "", nil, errors.New("soft delete of a translation DeleteHook did not read")
+	}
+	builder := query.New(h.db.Dialect()).Update(h.config.table()).
+		Set("deleted_at", time.Now()).
+		Set("updated_by", deletedByFromContext(ctx)).
+		Where(query.Eq("id", id)).
+		Where(query.IsNull("deleted_at"))
+	if h.config.TenantScoped {
+		builder = builder.Where(query.Eq("tenant_id", getTenantIDFromContext(ctx)))
+	}
+	return builder.Build()
+}
+
+func (h *translatableCRUDHooks) AfterQuery(ctx context.Context, operation hooks.Operation, query string, args []any, result any, err error) error {
+	if (operation == hooks.OperationCreate || operation == hooks.OperationUpdate) && isUniqueViolation(err) {
+		return errTranslationExists(writeLocaleFromContext(ctx))
+	}
+	if guard := versionGuardFromContext(ctx); operation == hooks.OperationUpdate && err == nil && guard != nil && guard.affected == 0 {
+		current, reloadErr := guard.reload()
+		if reloadErr != nil {
+			return fiber.NewError(fiber.StatusNotFound, "Translation not found")
+		}
+		return &VersionConflictError{Current: current}
+	}
+	if operation == hooks.OperationDelete && err == nil {
+		if id, ok := ctx.Value(pendingDeleteKey).(uuid.UUID); ok {
+			mirrorDelete(ctx, h.config.SecondaryWriter, id)
+		}
+		if previous := previousVersionFromContext(ctx); previous != nil {
+			emitDeleted(ctx, h.config, previous)
+		}
+	}
+	if err == nil {
+		h.config.metrics.countOperation(operation)
+	}
+	return nil
+}
+
+func (h *translatableCRUDHooks) SerializeMany(ctx context.Context, operation hooks.Operation, models *[]Translatable) error {
+	published := readStateFromContext(ctx) == ReadStatePublished
+	raw := rawContentFromContext(ctx)
+	for i := range *models {
+		model := &(*models)[i]
+		model.Translatable, _ = h.config.CanonicalType(model.Translatable)
+		if published {
+			servePublished(model)
+		} else if raw {
+			serveRaw(model)
+		}
+		h.config.serveFields(model)
+	}
+	if h.config.EntityMetadataResolver != nil && expandEntityFromContext(ctx) {
+		attachEntities(ctx, h.config.EntityMetadataResolver, *models)
+	}
+	if h.db != nil && includeUserFromContext(ctx) {
+		attachUsers(ctx, h.db, *models)
+	}
+	return nil
+}
+
+// serveRaw swaps the escaped working content for the content as submitted,
+// when it was kept. Rows written without StoreRawContent keep their content.
+func serveRaw(model *Translatable) {
+	if model.ContentRaw != nil {
+		model.Content = *model.ContentRaw
+	}
+}
+
+// servePublished swaps the working content for the snapshot taken at publish time.
+func servePublished(model *Translatable) {
+	if model.PublishedContent != nil {
+		model.Content = *model.PublishedContent
+	}
+}
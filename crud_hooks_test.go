@@ -0,0 +1,107 @@
+package translatable
+
+import (
+	"context"
+	"testing"
+
+	"github.com/nicolasbonnici/gorest-translatable/mocks"
+	"github.com/nicolasbonnici/gorest/hooks"
+	"github.com/nicolasbonnici/gorest/query"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTranslatableCRUDHooks_SerializeMany(t *testing.T) {
+	snapshot := "Published"
+	tests := []struct {
+		name     string
+		ctx      context.Context
+		expected []string
+	}{
+		{
+			name:     "working content by default",
+			ctx:      context.Background(),
+			expected: []string{"Draft", "Unpublished"},
+		},
+		{
+			name:     "published snapshot when requested",
+			ctx:      withReadState(context.Background(), ReadStatePublished),
+			expected: []string{"Published", "Unpublished"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			models := []Translatable{
+				{Content: "Draft", PublishedContent: &snapshot},
+				{Content: "Unpublished"},
+			}
+
+			err := newTranslatableCRUDHooks(&Config{}).SerializeMany(tt.ctx, hooks.OperationGetAll, &models)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, []string{models[0].Content, models[1].Content})
+		})
+	}
+}
+
+func TestTranslatableCRUDHooks_ModifySelectQuery(t *testing.T) {
+	tests := []struct {
+		name     string
+		ctx      context.Context
+		expected string
+	}{
+		{
+			name:     "excludes expired rows",
+			ctx:      context.Background(),
+			expected: "SELECT id FROM translations WHERE deleted_at IS NULL AND (expires_at IS NULL OR expires_at > $1)",
+		},
+		{
+			name:     "restricts to published rows",
+			ctx:      withReadState(context.Background(), ReadStatePublished),
+			expected: "SELECT id FROM translations WHERE deleted_at IS NULL AND (expires_at IS NULL OR expires_at > $1) AND published_at IS NOT NULL",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			builder := query.New(&mocks.MockDialect{}).Select("id").From("translations")
+
+			builder, modified := newTranslatableCRUDHooks(&Config{}).ModifySelectQuery(tt.ctx, hooks.OperationGetAll, builder)
+			sql, _, err := builder.Build()
+
+			assert.True(t, modified)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, sql)
+		})
+	}
+}
+
+func TestTranslatableCRUDHooks_CanonicalizesType(t *testing.T) {
+	h := newTranslatableCRUDHooks(&Config{AllowedTypes: []string{"posts"}})
+
+	one := Translatable{Translatable: "Posts"}
+	assert.NoError(t, h.SerializeOne(context.Background(), hooks.OperationGetByID, &one))
+	assert.Equal(t, "posts", one.Translatable)
+
+	many := []Translatable{{Translatable: "POSTS"}, {Translatable: "legacy"}}
+	assert.NoError(t, h.SerializeMany(context.Background(), hooks.OperationGetAll, &many))
+	assert.Equal(t, []string{"posts", "legacy"}, []string{many[0].Translatable, many[1].Translatable})
+}
+
+func TestTranslatableCRUDHooks_SerializeRaw(t *testing.T) {
+	raw := "<b>Hi</b>"
+	snapshot := "&lt;i&gt;Live&lt;/i&gt;"
+	h := newTranslatableCRUDHooks(&Config{})
+
+	models := []Translatable{
+		{Content: "&lt;b&gt;Hi&lt;/b&gt;", ContentRaw: &raw},
+		{Content: "Legacy &amp; escaped"},
+	}
+	assert.NoError(t, h.SerializeMany(withRawContent(context.Background()), hooks.OperationGetAll, &models))
+	assert.Equal(t, []string{"<b>Hi</b>", "Legacy &amp; escaped"}, []string{models[0].Content, models[1].Content})
+
+	published := Translatable{Content: "&lt;b&gt;Hi&lt;/b&gt;", ContentRaw: &raw, PublishedContent: &snapshot}
+	ctx := withRawContent(withReadState(context.Background(), ReadStatePublished))
+	assert.NoError(t, h.SerializeOne(ctx, hooks.OperationGetByID, &published))
+	assert.Equal(t, snapshot, published.Content)
+}
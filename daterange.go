@@ -0,0 +1,40 @@
+package translatable
+
+import (
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/nicolasbonnici/gorest/query"
+)
+
+// dateRangeParams maps the date range query parameters to the condition each
+// adds. Translations never updated count as updated when created, so polling
+// with ?updated_after= also picks up new rows.
+var dateRangeParams = []struct {
+	name      string
+	condition func(time.Time) query.Condition
+}{
+	{"created_after", func(t time.Time) query.Condition { return query.Gt("created_at", t) }},
+	{"created_before", func(t time.Time) query.Condition { return query.Lt("created_at", t) }},
+	{"updated_after", func(t time.Time) query.Condition { return query.Raw("COALESCE(updated_at, created_at) > ?", t) }},
+	{"updated_before", func(t time.Time) query.Condition { return query.Raw("COALESCE(updated_at, created_at) < ?", t) }},
+}
+
+// dateRangeConditions reads ?created_after=, ?created_before=, ?updated_after=
+// and ?updated_before= as RFC 3339 timestamps into exclusive range conditions.
+func dateRangeConditions(params url.Values) ([]query.Condition, error) {
+	var conditions []query.Condition
+	for _, param := range dateRangeParams {
+		value := params.Get(param.name)
+		if value == "" {
+			continue
+		}
+		t, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			return nil, fmt.Errorf("%s must be an RFC 3339 timestamp", param.name)
+		}
+		conditions = append(conditions, param.condition(t))
+	}
+	return conditions, nil
+}
@@ -0,0 +1,62 @@
+package translatable
+
+import (
+	"context"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v3"
+	"github.com/nicolasbonnici/gorest-translatable/mocks"
+	"github.com/nicolasbonnici/gorest/database"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDateRangeConditions(t *testing.T) {
+	params, _ := url.ParseQuery("created_after=2026-01-01T00:00:00Z&updated_before=2026-02-01T00:00:00%2B02:00")
+	conditions, err := dateRangeConditions(params)
+	assert.NoError(t, err)
+	assert.Len(t, conditions, 2)
+
+	for _, name := range []string{"created_after", "created_before", "updated_after", "updated_before"} {
+		_, err := dateRangeConditions(url.Values{name: {"2026-01-01"}})
+		assert.EqualError(t, err, name+" must be an RFC 3339 timestamp")
+	}
+}
+
+func TestTranslatableResource_GetAll_DateRange(t *testing.T) {
+	var listSQL string
+	var listArgs []interface{}
+	db := &mocks.MockDatabase{
+		QueryFunc: func(ctx context.Context, query string, args ...interface{}) (database.Rows, error) {
+			listSQL, listArgs = query, args
+			return mocks.NewMockRows(0), nil
+		},
+		QueryRowFunc: func(ctx context.Context, query string, args ...interface{}) database.Row {
+			return &mocks.MockRow{ScanFunc: func(dest ...interface{}) error {
+				*dest[0].(*int) = 0
+				return nil
+			}}
+		},
+	}
+	config := DefaultConfig()
+	app, resource := setupTestApp(db, &config)
+	app.Get("/translations", resource.GetAll)
+
+	resp, err := app.Test(httptest.NewRequest(fiber.MethodGet, "/translations?locale=fr&updated_after=2026-10-01T12:00:00Z&limit=5&page=2", nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+	assert.Contains(t, listSQL, " WHERE deleted_at IS NULL AND (expires_at IS NULL OR expires_at > $1) AND locale = $2 AND COALESCE(updated_at, created_at) > $3 ORDER BY created_at DESC LIMIT 5 OFFSET 5")
+	assert.Equal(t, "fr", listArgs[1])
+	assert.Equal(t, time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC), listArgs[2])
+
+	resp, err = app.Test(httptest.NewRequest(fiber.MethodGet, "/translations?updated_after=yesterday", nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+}
@@ -0,0 +1,87 @@
+package translatable
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+const (
+	deepLEndpoint     = "https://api.deepl.com/v2/translate"
+	deepLFreeEndpoint = "https://api-free.deepl.com/v2/translate"
+)
+
+// DeepLTranslator translates text with the DeepL API.
+type DeepLTranslator struct {
+	APIKey   string
+	Endpoint string
+	Client   *http.Client
+}
+
+// NewDeepLTranslator targets the free or pro API depending on the key, free
+// keys being suffixed with ":fx".
+func NewDeepLTranslator(apiKey string) *DeepLTranslator {
+	endpoint := deepLEndpoint
+	if strings.HasSuffix(apiKey, ":fx") {
+		endpoint = deepLFreeEndpoint
+	}
+	return &DeepLTranslator{APIKey: apiKey, Endpoint: endpoint, Client: http.DefaultClient}
+}
+
+type deepLRequest struct {
+	Text       []string `json:"text"`
+	SourceLang string   `json:"source_lang"`
+	TargetLang string   `json:"target_lang"`
+}
+
+type deepLResponse struct {
+	Translations []struct {
+		Text string `json:"text"`
+	} `json:"translations"`
+}
+
+func (d *DeepLTranslator) Translate(ctx context.Context, text, sourceLocale, targetLocale string) (string, error) {
+	if err := ValidateProviderEndpoint(d.Endpoint); err != nil {
+		return "", err
+	}
+
+	// DeepL only takes a language as source but accepts regional targets.
+	source, _, _ := strings.Cut(sourceLocale, "-")
+	payload, err := json.Marshal(deepLRequest{
+		Text:       []string{text},
+		SourceLang: strings.ToUpper(source),
+		TargetLang: strings.ToUpper(targetLocale),
+	})
+	if err != nil {
+		return "", err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, d.Endpoint, bytes.NewReader(payload))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Authorization", "DeepL-Auth-Key "+d.APIKey)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := d.Client.Do(req)
+	if err != nil {
+		if ctx.Err() != nil {
+			return "", ctx.Err()
+		}
+		return "", fmt.Errorf("%w: %v", ErrProviderUnavailable, err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("%w: status %d", ErrProviderUnavailable, resp.StatusCode)
+	}
+
+	var body deepLResponse
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil || len(body.Translations) == 0 {
+		return "", fmt.Errorf("%w: unexpected response", ErrProviderUnavailable)
+	}
+	return body.Translations[0].Text, nil
+}
@@ -0,0 +1,75 @@
+package translatable
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newDeepLTestServer(t *testing.T, handler http.HandlerFunc) *DeepLTranslator {
+	server := httptest.NewTLSServer(handler)
+	t.Cleanup(server.Close)
+	return &DeepLTranslator{APIKey: "key:fx", Endpoint: server.URL, Client: server.Client()}
+}
+
+func TestNewDeepLTranslator_Endpoint(t *testing.T) {
+	assert.Equal(t, deepLFreeEndpoint, NewDeepLTranslator("abc:fx").Endpoint)
+	assert.Equal(t, deepLEndpoint, NewDeepLTranslator("abc").Endpoint)
+}
+
+func TestDeepLTranslator_Translate(t *testing.T) {
+	translator := newDeepLTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		var req deepLRequest
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		assert.Equal(t, "DeepL-Auth-Key key:fx", r.Header.Get("Authorization"))
+		assert.Equal(t, deepLRequest{Text: []string{"Hello"}, SourceLang: "EN", TargetLang: "PT-BR"}, req)
+		_, _ = w.Write([]byte(`{"translations":[{"detected_source_language":"EN","text":"Olá"}]}`))
+	})
+
+	translated, err := translator.Translate(context.Background(), "Hello", "en-US", "pt-BR")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "Olá", translated)
+}
+
+func TestDeepLTranslator_Errors(t *testing.T) {
+	t.Run("provider error status", func(t *testing.T) {
+		translator := newDeepLTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		})
+		_, err := translator.Translate(context.Background(), "Hello", "en", "de")
+		assert.ErrorIs(t, err, ErrProviderUnavailable)
+	})
+
+	t.Run("network error", func(t *testing.T) {
+		server := httptest.NewTLSServer(http.NotFoundHandler())
+		translator := &DeepLTranslator{Endpoint: server.URL, Client: server.Client()}
+		server.Close()
+		_, err := translator.Translate(context.Background(), "Hello", "en", "de")
+		assert.ErrorIs(t, err, ErrProviderUnavailable)
+	})
+
+	t.Run("context deadline", func(t *testing.T) {
+		release := make(chan struct{})
+		translator := newDeepLTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+			<-release
+		})
+		t.Cleanup(func() { close(release) })
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+		_, err := translator.Translate(ctx, "Hello", "en", "de")
+		assert.True(t, errors.Is(err, context.DeadlineExceeded))
+	})
+
+	t.Run("insecure endpoint", func(t *testing.T) {
+		translator := &DeepLTranslator{Endpoint: "http://api.deepl.com/v2/translate"}
+		_, err := translator.Translate(context.Background(), "Hello", "en", "de")
+		assert.ErrorIs(t, err, ErrInsecureProviderEndpoint)
+	})
+}
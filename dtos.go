@@ -1,6 +1,7 @@
 package translatable
 
 import (
+	"encoding/json"
 	"time"
 
 	"github.com/google/uuid"
@@ -11,20 +12,153 @@ type TranslatableCreateDTO struct {
 	Translatable   string `json:"translatable"`
 	Locale         string `json:"locale"`
 	Content        string `json:"content"`
+	// Fields replaces Content for types listed in Config.FieldKeys.
+	Fields map[string]string `json:"fields,omitempty"`
 }
 
 type TranslatableUpdateDTO struct {
 	Locale  string `json:"locale"`
 	Content string `json:"content"`
+	// Fields replaces Content for types listed in Config.FieldKeys.
+	Fields map[string]string `json:"fields,omitempty"`
+	// Version, when set, must be the current version of the translation.
+	Version *int `json:"version,omitempty"`
+}
+
+// TranslatablePatchDTO is a partial update: only the fields it sets are
+// changed, so an omitted field is told apart from an empty one.
+type TranslatablePatchDTO struct {
+	Locale  *string           `json:"locale,omitempty"`
+	Content *string           `json:"content,omitempty"`
+	Fields  map[string]string `json:"fields,omitempty"`
+	// Version, when set, must be the current version of the translation.
+	Version *int `json:"version,omitempty"`
+}
+
+type CloneEntityDTO struct {
+	FromTranslatableID string `json:"from_translatable_id"`
+	ToTranslatableID   string `json:"to_translatable_id"`
+	Translatable       string `json:"translatable"`
+}
+
+// CopyLocaleDTO selects the translations of a type to copy between locales.
+type CopyLocaleDTO struct {
+	Translatable string `json:"translatable"`
+	FromLocale   string `json:"from_locale"`
+	ToLocale     string `json:"to_locale"`
+	Overwrite    bool   `json:"overwrite"`
+}
+
+// CopyLocaleResponse reports how many translations a locale copy wrote.
+type CopyLocaleResponse struct {
+	Copied int `json:"copied"`
+}
+
+// ReplaceLocalesDTO maps locales to the content an entity should have in them.
+type ReplaceLocalesDTO struct {
+	Translations map[string]string `json:"translations"`
+}
+
+// EntityLocalesResponse holds what an entity reads in each locale it is
+// translated in, for building a language switcher.
+type EntityLocalesResponse struct {
+	TranslatableID uuid.UUID      `json:"translatable_id"`
+	Translatable   string         `json:"translatable"`
+	Default        string         `json:"default"`
+	Translations   LocaleContents `json:"translations"`
+}
+
+// LocaleContent is the content of one locale, or its fields for multi-field
+// types.
+type LocaleContent struct {
+	Locale  string
+	Content any
+}
+
+// LocaleContents encodes as a JSON object keyed by locale, keeping the order
+// of its entries.
+type LocaleContents []LocaleContent
+
+func (l LocaleContents) MarshalJSON() ([]byte, error) {
+	buf := []byte{'{'}
+	for i, entry := range l {
+		if i > 0 {
+			buf = append(buf, ',')
+		}
+		key, err := json.Marshal(entry.Locale)
+		if err != nil {
+			return nil, err
+		}
+		value, err := json.Marshal(entry.Content)
+		if err != nil {
+			return nil, err
+		}
+		buf = append(append(append(buf, key...), ':'), value...)
+	}
+	return append(buf, '}'), nil
+}
+
+// ValidationResultDTO reports whether a create would accept a translation and
+// the content it would be stored with, sanitized, or the message of each
+// invalid field.
+type ValidationResultDTO struct {
+	Valid   bool              `json:"valid"`
+	Locale  string            `json:"locale"`
+	Content string            `json:"content,omitempty"`
+	Fields  map[string]string `json:"fields,omitempty"`
+	Errors  map[string]string `json:"errors,omitempty"`
+}
+
+type BatchGetDTO struct {
+	IDs []string `json:"ids"`
+}
+
+type CountResponseDTO struct {
+	Count int `json:"count"`
+}
+
+type BatchGetResponseDTO struct {
+	Data    []TranslatableResponseDTO `json:"data"`
+	Missing []uuid.UUID               `json:"missing"`
+}
+
+type SnapshotResponseDTO struct {
+	Token     string    `json:"snapshot_token"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// TrashedTranslationDTO is an item of GET /translations/trash, without
+// content when Config.TrashHidesContent is set.
+type TrashedTranslationDTO struct {
+	ID           uuid.UUID  `json:"id"`
+	Translatable string     `json:"translatable"`
+	Locale       string     `json:"locale"`
+	Content      *string    `json:"content,omitempty"`
+	DeletedAt    time.Time  `json:"deleted_at"`
+	UpdatedBy    *uuid.UUID `json:"updated_by"`
 }
 
 type TranslatableResponseDTO struct {
-	ID             uuid.UUID  `json:"id"`
-	UserID         *uuid.UUID `json:"user_id,omitempty"`
-	TranslatableID uuid.UUID  `json:"translatable_id"`
-	Translatable   string     `json:"translatable"`
-	Locale         string     `json:"locale"`
-	Content        string     `json:"content"`
-	UpdatedAt      *time.Time `json:"updated_at,omitempty"`
-	CreatedAt      time.Time  `json:"created_at"`
+	ID             uuid.UUID         `json:"id"`
+	UserID         *uuid.UUID        `json:"user_id,omitempty"`
+	TranslatableID uuid.UUID         `json:"translatable_id"`
+	Translatable   string            `json:"translatable"`
+	Locale         string            `json:"locale"`
+	Content        string            `json:"content"`
+	Fields         map[string]string `json:"fields,omitempty"`
+	ContentLength  int               `json:"content_length"`
+	Checksum       string            `json:"checksum"`
+	PublishedAt    *time.Time        `json:"published_at,omitempty"`
+	ExpiresAt      *time.Time        `json:"expires_at,omitempty"`
+	AutoTranslated bool              `json:"auto_translated"`
+	SourceChecksum *string           `json:"source_checksum,omitempty"`
+	UpdatedAt      *time.Time        `json:"updated_at,omitempty"`
+	CreatedAt      time.Time         `json:"created_at"`
+	ReceivedAt     *time.Time        `json:"received_at,omitempty"`
+	Version        int               `json:"version"`
+	Status         string            `json:"status"`
+	ReviewedBy     *uuid.UUID        `json:"reviewed_by,omitempty"`
+	ReviewedAt     *time.Time        `json:"reviewed_at,omitempty"`
+	Entity         json.RawMessage   `json:"entity,omitempty"`
+	User           *UserSummary      `json:"user,omitempty"`
 }
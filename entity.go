@@ -0,0 +1,60 @@
+package translatable
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+
+	"github.com/gofiber/fiber/v3"
+	"github.com/google/uuid"
+)
+
+// EntityMetadataResolver returns metadata, such as a title, for the entities of
+// one translatable type. Entities missing from the result are served without it.
+type EntityMetadataResolver func(ctx context.Context, translatable string, ids []uuid.UUID) (map[uuid.UUID]json.RawMessage, error)
+
+const expandEntityKey contextKey = "translatable_expand_entity"
+
+// applyExpand records ?expand=entity on the request context.
+func applyExpand(c fiber.Ctx) {
+	for _, expand := range strings.Split(c.Query("expand"), ",") {
+		if strings.TrimSpace(expand) == "entity" {
+			c.SetContext(context.WithValue(c.Context(), expandEntityKey, true))
+			return
+		}
+	}
+}
+
+func expandEntityFromContext(ctx context.Context) bool {
+	expand, _ := ctx.Value(expandEntityKey).(bool)
+	return expand
+}
+
+// attachEntities sets Entity on models with one resolver call per translatable
+// type. A failing resolver is logged and leaves the models unchanged.
+func attachEntities(ctx context.Context, resolver EntityMetadataResolver, models []Translatable) {
+	idsByType := make(map[string][]uuid.UUID)
+	seen := make(map[string]map[uuid.UUID]bool)
+	for _, model := range models {
+		if seen[model.Translatable] == nil {
+			seen[model.Translatable] = make(map[uuid.UUID]bool)
+		}
+		if !seen[model.Translatable][model.TranslatableID] {
+			seen[model.Translatable][model.TranslatableID] = true
+			idsByType[model.Translatable] = append(idsByType[model.Translatable], model.TranslatableID)
+		}
+	}
+
+	for translatable, ids := range idsByType {
+		entities, err := resolver(ctx, translatable, ids)
+		if err != nil {
+			requestLogger(ctx).Warn("entity metadata resolution failed", "translatable", translatable, "error", err)
+			continue
+		}
+		for i := range models {
+			if models[i].Translatable == translatable {
+				models[i].Entity = entities[models[i].TranslatableID]
+			}
+		}
+	}
+}
@@ -0,0 +1,49 @@
+package translatable
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/nicolasbonnici/gorest/hooks"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTranslatableCRUDHooks_ExpandEntity(t *testing.T) {
+	post, page := uuid.New(), uuid.New()
+	calls := make(map[string][]uuid.UUID)
+	config := &Config{
+		EntityMetadataResolver: func(ctx context.Context, translatable string, ids []uuid.UUID) (map[uuid.UUID]json.RawMessage, error) {
+			calls[translatable] = ids
+			if translatable == "page" {
+				return nil, errors.New("unavailable")
+			}
+			return map[uuid.UUID]json.RawMessage{post: json.RawMessage(`{"title":"Hello"}`)}, nil
+		},
+	}
+	h := newTranslatableCRUDHooks(config)
+
+	newModels := func() []Translatable {
+		return []Translatable{
+			{Translatable: "post", TranslatableID: post, Locale: "en"},
+			{Translatable: "post", TranslatableID: post, Locale: "fr"},
+			{Translatable: "page", TranslatableID: page, Locale: "en"},
+		}
+	}
+
+	models := newModels()
+	assert.NoError(t, h.SerializeMany(context.Background(), hooks.OperationGetAll, &models))
+	assert.Empty(t, calls)
+	assert.Nil(t, models[0].Entity)
+
+	models = newModels()
+	ctx := context.WithValue(context.Background(), expandEntityKey, true)
+	assert.NoError(t, h.SerializeMany(ctx, hooks.OperationGetAll, &models))
+
+	assert.Equal(t, map[string][]uuid.UUID{"post": {post}, "page": {page}}, calls)
+	assert.JSONEq(t, `{"title":"Hello"}`, string(models[0].Entity))
+	assert.JSONEq(t, `{"title":"Hello"}`, string(models[1].Entity))
+	assert.Nil(t, models[2].Entity)
+}
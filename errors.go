@@ -0,0 +1,393 @@
+package translatable
+
+import (
+	"context"
+	"errors"
+	"maps"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/gofiber/fiber/v3"
+	"github.com/nicolasbonnici/gorest/crud"
+)
+
+// Stable error codes reported in problem documents. Errors without a specific
+// code use the snake_cased HTTP status text, such as not_found.
+const (
+	CodeInvalidBody     = "invalid_body"
+	CodeInvalidType     = "invalid_type"
+	CodeInvalidLocale   = "invalid_locale"
+	CodeContentEmpty    = "content_empty"
+	CodeContentTooLong  = "content_too_long"
+	CodeContentEncoding = "content_encoding"
+	CodeInvalidField    = "invalid_field"
+	CodeVersionConflict = "version_conflict"
+	CodeRateLimited     = "rate_limited"
+	CodeInvalidStatus   = "invalid_status"
+	// CodeInvalidTransition reports a status change the current status of
+	// the translation does not allow.
+	CodeInvalidTransition = "invalid_transition"
+	// CodeTranslationExists reports a write colliding with the translation
+	// the entity already has in that locale.
+	CodeTranslationExists = "translation_exists"
+	// CodeValidationFailed reports a request body with several invalid fields,
+	// listed under errors.
+	CodeValidationFailed = "validation_failed"
+
+	// ProblemTypePrefix prefixes the code to form the type of a problem document.
+	ProblemTypePrefix = "urn:gorest-translatable:problem:"
+	MIMEProblemJSON   = "application/problem+json"
+)
+
+// ProblemError is a client error carrying its stable code. It unwraps to the
+// equivalent fiber.Error, so layers unaware of codes still get the status.
+type ProblemError struct {
+	Status  int
+	Code    string
+	Message string
+	// Params fills the placeholders of the localized message, e.g. {locale}.
+	Params map[string]string
+
+	// key is the catalog key of the message when it is not Code alone.
+	key string
+	// field is the field of a multi-field content the error is about.
+	field string
+}
+
+func newProblemError(status int, code, message string) *ProblemError {
+	return &ProblemError{Status: status, Code: code, Message: message}
+}
+
+func (e *ProblemError) Error() string {
+	return e.Message
+}
+
+func (e *ProblemError) Unwrap() error {
+	return fiber.NewError(e.Status, e.Message)
+}
+
+// AllowedValuesError is a validation failure for a field restricted to a fixed
+// set of values. The set is returned to the client alongside the message.
+type AllowedValuesError struct {
+	Message string
+	Code    string
+	Allowed []string
+	// Params fills the placeholders of the localized message, e.g. {field}.
+	Params map[string]string
+}
+
+func (e *AllowedValuesError) Error() string {
+	return e.Message
+}
+
+// ValidationError reports every invalid field of a request body at once,
+// keyed by JSON field name. A lone field error is unwrapped, so it is handled
+// exactly as if it had been returned alone.
+type ValidationError struct {
+	Fields map[string]error
+}
+
+// add records err against field, keeping the first error of each field.
+func (e *ValidationError) add(field string, err error) {
+	if e.Fields == nil {
+		e.Fields = make(map[string]error)
+	}
+	if _, ok := e.Fields[field]; !ok {
+		e.Fields[field] = err
+	}
+}
+
+// has reports whether field already failed.
+func (e *ValidationError) has(field string) bool {
+	_, ok := e.Fields[field]
+	return ok
+}
+
+// err returns e, or nil when no field failed.
+func (e *ValidationError) err() error {
+	if len(e.Fields) == 0 {
+		return nil
+	}
+	return e
+}
+
+// Error joins the field messages in field order, e.g.
+// "content: content cannot be empty; locale: locale is not supported".
+func (e *ValidationError) Error() string {
+	fields := slices.Sorted(maps.Keys(e.Fields))
+	messages := make([]string, len(fields))
+	for i, field := range fields {
+		messages[i] = field + ": " + e.Fields[field].Error()
+	}
+	return strings.Join(messages, "; ")
+}
+
+func (e *ValidationError) Unwrap() error {
+	if len(e.Fields) != 1 {
+		return nil
+	}
+	for _, err := range e.Fields {
+		return err
+	}
+	return nil
+}
+
+// Messages returns the message of each invalid field.
+func (e *ValidationError) Messages() map[string]string {
+	messages := make(map[string]string, len(e.Fields))
+	for field, err := range e.Fields {
+		messages[field] = err.Error()
+	}
+	return messages
+}
+
+func errTypeNotAllowed(config *Config) *AllowedValuesError {
+	return &AllowedValuesError{Message: "translatable type is not allowed", Code: CodeInvalidType, Allowed: config.AllowedTypes}
+}
+
+func errLocaleNotSupported(config *Config) *AllowedValuesError {
+	return &AllowedValuesError{Message: "locale is not supported", Code: CodeInvalidLocale, Allowed: config.SupportedLocales}
+}
+
+func errTranslationExists(locale string) *ProblemError {
+	err := newProblemError(fiber.StatusConflict, CodeTranslationExists, "a translation already exists for locale "+locale)
+	err.Params = map[string]string{"locale": locale}
+	return err
+}
+
+// isUniqueViolation reports whether err is the database rejecting a duplicate
+// key: SQLSTATE 23505 on Postgres, error 1062 on MySQL, or SQLite's UNIQUE
+// constraint error. Drivers are matched by interface and message, so none of
+// them is a dependency.
+func isUniqueViolation(err error) bool {
+	if err == nil {
+		return false
+	}
+	var state interface{ SQLState() string }
+	if errors.As(err, &state) && state.SQLState() == "23505" {
+		return true
+	}
+	msg := err.Error()
+	return strings.Contains(msg, "SQLSTATE 23505") ||
+		strings.Contains(msg, "Error 1062") ||
+		strings.Contains(msg, "UNIQUE constraint failed")
+}
+
+var errMalformedLocale = &ProblemError{
+	Status:  fiber.StatusBadRequest,
+	Code:    CodeInvalidLocale,
+	Message: "locale is not a well-formed BCP 47 tag",
+	key:     messageKeyMalformedLocale,
+}
+
+// errInvalidLocale rejects a locale outside SupportedLocales. With
+// StrictLocales, malformed tags such as en_US are reported as such.
+func errInvalidLocale(config *Config, locale string) error {
+	if config.strictLocales() && !isWellFormedLocale(locale) {
+		return errMalformedLocale
+	}
+	return errLocaleNotSupported(config)
+}
+
+// translatableErrorHandler renders processor errors with the same status
+// mapping as the gorest default handler, adding the request id to the body and
+// the accepted values for AllowedValuesError.
+type translatableErrorHandler struct{}
+
+func (h *translatableErrorHandler) HandleError(c fiber.Ctx, err error, operation string) error {
+	if operation == "parse" || operation == "parseFilters" || operation == "parseOrdering" {
+		return sendCodedError(c, fiber.StatusBadRequest, CodeInvalidBody, "Invalid request body")
+	}
+
+	var validationErr *ValidationError
+	if errors.As(err, &validationErr) {
+		return sendValidationError(c, validationErr)
+	}
+
+	var allowedErr *AllowedValuesError
+	if errors.As(err, &allowedErr) {
+		return sendAllowedValuesError(c, allowedErr)
+	}
+
+	var conflictErr *VersionConflictError
+	if errors.As(err, &conflictErr) {
+		return sendVersionConflict(c, conflictErr)
+	}
+
+	var problemErr *ProblemError
+	if errors.As(err, &problemErr) {
+		return sendProblemError(c, problemErr)
+	}
+
+	var fiberErr *fiber.Error
+	if errors.As(err, &fiberErr) {
+		msg := fiberErr.Message
+		if fiberErr.Code >= fiber.StatusInternalServerError {
+			msg = "Internal server error"
+		}
+		return sendError(c, fiberErr.Code, msg)
+	}
+
+	if crud.IsInvalidIDError(err) {
+		return sendError(c, fiber.StatusBadRequest, err.Error())
+	}
+
+	if crud.IsNotFoundError(err) {
+		return sendError(c, fiber.StatusNotFound, "Not found")
+	}
+
+	if operation == "validate" {
+		return sendError(c, fiber.StatusBadRequest, err.Error())
+	}
+
+	if errors.Is(err, context.DeadlineExceeded) {
+		return sendError(c, errQueryTimeout.Code, errQueryTimeout.Message)
+	}
+
+	return sendError(c, fiber.StatusInternalServerError, err.Error())
+}
+
+// ProblemDetails is the RFC 7807 body of every error response, whichever layer
+// produced the error: the processor, a route handler or the request-id
+// middleware.
+type ProblemDetails struct {
+	Type   string `json:"type"`
+	Title  string `json:"title"`
+	Status int    `json:"status"`
+	Detail string `json:"detail"`
+	Code   string `json:"code"`
+	// Allowed lists the accepted values of the field that failed validation.
+	Allowed []string `json:"allowed,omitempty"`
+	// Errors holds the message of each invalid field of the request body.
+	Errors map[string]string `json:"errors,omitempty"`
+	// Current is the stored translation an update conflicted with.
+	Current   *TranslatableResponseDTO `json:"current,omitempty"`
+	RequestID string                   `json:"request_id,omitempty"`
+}
+
+// ErrorResponse is the legacy error body, served instead of ProblemDetails
+// with Config.LegacyErrors.
+type ErrorResponse struct {
+	Error     string                   `json:"error"`
+	Code      string                   `json:"-"`
+	Allowed   []string                 `json:"allowed,omitempty"`
+	Errors    map[string]string        `json:"errors,omitempty"`
+	Current   *TranslatableResponseDTO `json:"current,omitempty"`
+	RequestID string                   `json:"request_id,omitempty"`
+}
+
+func sendError(c fiber.Ctx, status int, message string) error {
+	return sendErrorResponse(c, status, ErrorResponse{Error: message})
+}
+
+// sendCodedError renders an error with a fixed message, localized by its code.
+func sendCodedError(c fiber.Ctx, status int, code, message string) error {
+	message = messagesFromContext(c.Context()).format(code, nil, message)
+	return sendErrorResponse(c, status, ErrorResponse{Error: message, Code: code})
+}
+
+func sendProblemError(c fiber.Ctx, err *ProblemError) error {
+	return sendErrorResponse(c, err.Status, ErrorResponse{Error: localizedMessage(c, err), Code: err.Code})
+}
+
+// sendFiberError renders an error returned by a route handler, keeping the
+// code of a ProblemError.
+func sendFiberError(c fiber.Ctx, err *fiber.Error, source error) error {
+	var problemErr *ProblemError
+	if errors.As(source, &problemErr) {
+		return sendProblemError(c, problemErr)
+	}
+	return sendError(c, err.Code, err.Message)
+}
+
+func sendAllowedValuesError(c fiber.Ctx, err *AllowedValuesError) error {
+	return sendErrorResponse(c, fiber.StatusBadRequest, ErrorResponse{Error: localizedMessage(c, err), Code: err.Code, Allowed: err.Allowed})
+}
+
+// sendValidationError renders a ValidationError with the message of each field
+// under errors. A lone field error is rendered as it would be on its own, so
+// single-field responses are unchanged.
+func sendValidationError(c fiber.Ctx, err *ValidationError) error {
+	messages := make(map[string]string, len(err.Fields))
+	joined := make([]string, 0, len(err.Fields))
+	for _, field := range slices.Sorted(maps.Keys(err.Fields)) {
+		messages[field] = localizedMessage(c, err.Fields[field])
+		joined = append(joined, field+": "+messages[field])
+	}
+
+	status := fiber.StatusBadRequest
+	body := ErrorResponse{Error: strings.Join(joined, "; "), Code: CodeValidationFailed, Errors: messages}
+	if lone := err.Unwrap(); lone != nil {
+		body = ErrorResponse{Error: localizedMessage(c, lone)}
+		var allowedErr *AllowedValuesError
+		var problemErr *ProblemError
+		var fiberErr *fiber.Error
+		switch {
+		case errors.As(lone, &allowedErr):
+			body.Code, body.Allowed = allowedErr.Code, allowedErr.Allowed
+		case errors.As(lone, &problemErr):
+			status, body.Code = problemErr.Status, problemErr.Code
+		case errors.As(lone, &fiberErr):
+			status = fiberErr.Code
+		}
+	}
+	return sendErrorResponse(c, status, body)
+}
+
+// sendInvalidLocale renders errInvalidLocale from a route handler.
+func sendInvalidLocale(c fiber.Ctx, config *Config, locale string) error {
+	var allowedErr *AllowedValuesError
+	if err := errInvalidLocale(config, locale); !errors.As(err, &allowedErr) {
+		return err
+	}
+	return sendAllowedValuesError(c, allowedErr)
+}
+
+func sendVersionConflict(c fiber.Ctx, err *VersionConflictError) error {
+	current := (&TranslatableConverter{}).ModelToResponseDTO(*err.Current)
+	return sendErrorResponse(c, fiber.StatusConflict, ErrorResponse{Error: localizedMessage(c, err), Code: CodeVersionConflict, Current: &current})
+}
+
+func sendErrorResponse(c fiber.Ctx, status int, body ErrorResponse) error {
+	body.RequestID = requestIDFromContext(c.Context())
+	if legacyErrorsFromContext(c.Context()) {
+		return c.Status(status).JSON(body)
+	}
+
+	code := body.Code
+	if code == "" {
+		code = statusCode(status)
+	}
+	return c.Status(status).JSON(ProblemDetails{
+		Type:      ProblemTypePrefix + code,
+		Title:     http.StatusText(status),
+		Status:    status,
+		Detail:    body.Error,
+		Code:      code,
+		Allowed:   body.Allowed,
+		Errors:    body.Errors,
+		Current:   body.Current,
+		RequestID: body.RequestID,
+	}, MIMEProblemJSON)
+}
+
+// statusCode derives the code of errors without a specific one from their
+// status, e.g. 404 gives not_found.
+func statusCode(status int) string {
+	return strings.ReplaceAll(strings.ToLower(http.StatusText(status)), " ", "_")
+}
+
+const legacyErrorsKey contextKey = "translatable_legacy_errors"
+
+// legacyErrorsMiddleware serves errors in the ErrorResponse shape for
+// Config.LegacyErrors.
+func legacyErrorsMiddleware(c fiber.Ctx) error {
+	c.SetContext(context.WithValue(c.Context(), legacyErrorsKey, true))
+	return c.Next()
+}
+
+func legacyErrorsFromContext(ctx context.Context) bool {
+	legacy, _ := ctx.Value(legacyErrorsKey).(bool)
+	return legacy
+}
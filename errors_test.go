@@ -0,0 +1,342 @@
+package translatable
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gofiber/fiber/v3"
+	"github.com/google/uuid"
+	"github.com/nicolasbonnici/gorest-translatable/mocks"
+	"github.com/nicolasbonnici/gorest/database"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreate_ReturnsAllowedValues(t *testing.T) {
+	config := DefaultConfig()
+	config.AllowedTypes = []string{"posts", "products"}
+	app, resource := setupTestApp(&mocks.MockDatabase{}, &config)
+	app.Post("/translations", resource.Create)
+
+	tests := []struct {
+		name     string
+		body     string
+		message  string
+		code     string
+		expected []string
+	}{
+		{
+			name:     "unknown type",
+			body:     `{"translatableId":"550e8400-e29b-41d4-a716-446655440000","translatable":"users","locale":"en","content":"Hello"}`,
+			message:  "translatable type is not allowed",
+			code:     CodeInvalidType,
+			expected: []string{"posts", "products"},
+		},
+		{
+			name:     "unsupported locale",
+			body:     `{"translatableId":"550e8400-e29b-41d4-a716-446655440000","translatable":"posts","locale":"it","content":"Ciao"}`,
+			message:  "locale is not supported",
+			code:     CodeInvalidLocale,
+			expected: []string{"en", "fr", "es"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(fiber.MethodPost, "/translations", strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			resp, err := app.Test(req)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var body ProblemDetails
+			assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+			assert.Equal(t, MIMEProblemJSON, resp.Header.Get(fiber.HeaderContentType))
+			assert.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+			assert.Equal(t, tt.message, body.Detail)
+			assert.Equal(t, tt.code, body.Code)
+			assert.Equal(t, ProblemTypePrefix+tt.code, body.Type)
+			assert.Equal(t, "Bad Request", body.Title)
+			assert.Equal(t, fiber.StatusBadRequest, body.Status)
+			assert.Equal(t, tt.expected, body.Allowed)
+		})
+	}
+}
+
+func TestErrorResponse_IdenticalAcrossLayers(t *testing.T) {
+	config := DefaultConfig()
+	app, resource := setupTestApp(&mocks.MockDatabase{}, &config)
+	app.Use(requestIDMiddleware)
+	app.Get("/translations/resolve", resource.Resolve)
+	app.Post("/translations", resource.Create)
+
+	send := func(req *http.Request) (int, []byte) {
+		req.Header.Set(HeaderRequestID, "req-1")
+		resp, err := app.Test(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		body, _ := io.ReadAll(resp.Body)
+		return resp.StatusCode, body
+	}
+
+	// The route handler error goes through the middleware, the create error
+	// through the processor error handler.
+	handlerStatus, handlerBody := send(httptest.NewRequest(fiber.MethodGet, "/translations/resolve?translatable_id=nope", nil))
+	createReq := httptest.NewRequest(fiber.MethodPost, "/translations", strings.NewReader(`{"translatableId":"nope","translatable":"post","locale":"en","content":"Hello"}`))
+	createReq.Header.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+	processorStatus, processorBody := send(createReq)
+
+	assert.Equal(t, fiber.StatusBadRequest, handlerStatus)
+	assert.Equal(t, handlerStatus, processorStatus)
+	assert.Equal(t, `{"type":"urn:gorest-translatable:problem:bad_request","title":"Bad Request","status":400,"detail":"translatable_id must be a valid UUID","code":"bad_request","request_id":"req-1"}`, string(handlerBody))
+	assert.Equal(t, handlerBody, processorBody)
+}
+
+func TestCreate_AllowedTablesAlias(t *testing.T) {
+	for _, useTables := range []bool{false, true} {
+		config := DefaultConfig()
+		config.AllowedTypes = nil
+		if useTables {
+			config.AllowedTables = []string{"posts"}
+		} else {
+			config.AllowedTypes = []string{"posts"}
+		}
+		assert.NoError(t, config.Validate())
+
+		app, resource := setupTestApp(&mocks.MockDatabase{}, &config)
+		app.Post("/translations", resource.Create)
+
+		req := httptest.NewRequest(fiber.MethodPost, "/translations", strings.NewReader(`{"translatableId":"550e8400-e29b-41d4-a716-446655440000","translatable":"users","locale":"en","content":"Hello"}`))
+		req.Header.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+		resp, err := app.Test(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var body ProblemDetails
+		assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+		assert.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+		assert.Equal(t, []string{"posts"}, body.Allowed)
+	}
+}
+
+func TestProblemDetails(t *testing.T) {
+	tests := []struct {
+		name   string
+		legacy bool
+		path   string
+		status int
+		body   string
+	}{
+		{
+			name:   "content too long",
+			path:   "/translations",
+			status: fiber.StatusBadRequest,
+			body:   `{"type":"urn:gorest-translatable:problem:content_too_long","title":"Bad Request","status":400,"detail":"content exceeds maximum length of 5 characters","code":"content_too_long","request_id":"req-1"}`,
+		},
+		{
+			name:   "not found",
+			path:   "/translations/missing",
+			status: fiber.StatusNotFound,
+			body:   `{"type":"urn:gorest-translatable:problem:not_found","title":"Not Found","status":404,"detail":"Translation not found","code":"not_found","request_id":"req-1"}`,
+		},
+		{
+			name:   "legacy validation error",
+			legacy: true,
+			path:   "/translations",
+			status: fiber.StatusBadRequest,
+			body:   `{"error":"content exceeds maximum length of 5 characters","request_id":"req-1"}`,
+		},
+		{
+			name:   "legacy not found",
+			legacy: true,
+			path:   "/translations/missing",
+			status: fiber.StatusNotFound,
+			body:   `{"error":"Translation not found","request_id":"req-1"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := DefaultConfig()
+			config.MaxContentLength = 5
+			app, resource := setupTestApp(&mocks.MockDatabase{}, &config)
+			if tt.legacy {
+				app.Use(legacyErrorsMiddleware)
+			}
+			app.Use(requestIDMiddleware)
+			app.Post("/translations", resource.Create)
+			app.Get("/translations/missing", func(c fiber.Ctx) error {
+				return fiber.NewError(fiber.StatusNotFound, "Translation not found")
+			})
+
+			req := httptest.NewRequest(fiber.MethodGet, tt.path, nil)
+			if tt.path == "/translations" {
+				req = httptest.NewRequest(fiber.MethodPost, tt.path, strings.NewReader(`{"translatableId":"550e8400-e29b-41d4-a716-446655440000","translatable":"post","locale":"en","content":"Hello world"}`))
+				req.Header.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+			}
+			req.Header.Set(HeaderRequestID, "req-1")
+			resp, err := app.Test(req)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			body, _ := io.ReadAll(resp.Body)
+			assert.Equal(t, tt.status, resp.StatusCode)
+			assert.Equal(t, tt.body, string(body))
+			if tt.legacy {
+				assert.Equal(t, fiber.MIMEApplicationJSONCharsetUTF8, resp.Header.Get(fiber.HeaderContentType))
+			} else {
+				assert.Equal(t, MIMEProblemJSON, resp.Header.Get(fiber.HeaderContentType))
+			}
+		})
+	}
+}
+
+func TestCreate_UniqueViolation(t *testing.T) {
+	tests := []struct {
+		name   string
+		err    error
+		status int
+		code   string
+	}{
+		{name: "postgres", err: errors.New(`ERROR: duplicate key value violates unique constraint "translations_unique" (SQLSTATE 23505)`), status: fiber.StatusConflict, code: CodeTranslationExists},
+		{name: "mysql", err: errors.New("Error 1062 (23000): Duplicate entry 'post-fr' for key 'translations_unique'"), status: fiber.StatusConflict, code: CodeTranslationExists},
+		{name: "sqlite", err: errors.New("UNIQUE constraint failed: translations.translatable_id, translations.translatable, translations.locale"), status: fiber.StatusConflict, code: CodeTranslationExists},
+		{name: "other error", err: errors.New("connection reset by peer"), status: fiber.StatusInternalServerError, code: "internal_server_error"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := &mocks.MockDatabase{
+				QueryRowFunc: func(ctx context.Context, query string, args ...interface{}) database.Row {
+					return &mocks.MockRow{ScanFunc: func(dest ...interface{}) error {
+						if strings.HasPrefix(query, "INSERT") {
+							return tt.err
+						}
+						return sql.ErrNoRows
+					}}
+				},
+				ExecFunc: func(ctx context.Context, query string, args ...interface{}) (database.Result, error) {
+					return nil, tt.err
+				},
+			}
+			config := DefaultConfig()
+			app, resource := setupTestApp(db, &config)
+			app.Post("/translations", resource.Create)
+
+			req := httptest.NewRequest(fiber.MethodPost, "/translations", strings.NewReader(`{"translatableId":"550e8400-e29b-41d4-a716-446655440000","translatable":"post","locale":"fr","content":"Bonjour"}`))
+			req.Header.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+			resp, err := app.Test(req)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			assert.Equal(t, tt.status, resp.StatusCode)
+			var problem ProblemDetails
+			assert.NoError(t, json.NewDecoder(resp.Body).Decode(&problem))
+			assert.Equal(t, tt.code, problem.Code)
+			if tt.status == fiber.StatusConflict {
+				assert.Equal(t, "a translation already exists for locale fr", problem.Detail)
+			}
+		})
+	}
+}
+
+func TestValidationError_MultipleFields(t *testing.T) {
+	id := uuid.New()
+	tests := []struct {
+		name   string
+		method string
+		path   string
+		body   string
+		errors map[string]string
+	}{
+		{
+			name: "create", method: fiber.MethodPost, path: "/translations",
+			body: `{"translatableId":"nope","translatable":"post","locale":"it","content":"Bonjour tout le monde"}`,
+			errors: map[string]string{
+				"translatableId": "translatable_id must be a valid UUID",
+				"locale":         "locale is not supported",
+				"content":        "content exceeds maximum length of 5 characters",
+			},
+		},
+		{
+			name: "create with unknown type", method: fiber.MethodPost, path: "/translations",
+			body: `{"translatableId":"` + id.String() + `","translatable":"page","locale":"it","content":"Bonjour tout le monde"}`,
+			errors: map[string]string{
+				"translatable": "translatable type is not allowed",
+				"locale":       "locale is not supported",
+			},
+		},
+		{
+			name: "update", method: fiber.MethodPut, path: "/translations/" + id.String(),
+			body: `{"locale":"it","content":" "}`,
+			errors: map[string]string{
+				"locale":  "locale is not supported",
+				"content": "content cannot be empty",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := &mocks.MockDatabase{
+				QueryRowFunc: func(ctx context.Context, query string, args ...interface{}) database.Row {
+					return &mocks.MockRow{ScanFunc: func(dest ...interface{}) error {
+						if len(dest) == 1 {
+							return sql.ErrNoRows
+						}
+						*dest[0].(*uuid.UUID) = id
+						*dest[3].(*string) = "post"
+						*dest[4].(*string) = "fr"
+						return nil
+					}}
+				},
+			}
+			config := DefaultConfig()
+			config.MaxContentLength = 5
+			app, resource := setupTestApp(db, &config)
+			app.Post("/translations", resource.Create)
+			app.Put("/translations/:id", resource.Update)
+
+			req := httptest.NewRequest(tt.method, tt.path, strings.NewReader(tt.body))
+			req.Header.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+			resp, err := app.Test(req)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+			var problem ProblemDetails
+			assert.NoError(t, json.NewDecoder(resp.Body).Decode(&problem))
+			assert.Equal(t, CodeValidationFailed, problem.Code)
+			assert.Equal(t, tt.errors, problem.Errors)
+		})
+	}
+}
+
+func TestValidationError_Error(t *testing.T) {
+	invalid := &ValidationError{}
+	assert.NoError(t, invalid.err())
+
+	invalid.add("locale", errors.New("locale is not supported"))
+	invalid.add("content", errors.New("content cannot be empty"))
+	invalid.add("content", errors.New("ignored"))
+
+	assert.EqualError(t, invalid.err(), "content: content cannot be empty; locale: locale is not supported")
+	assert.Nil(t, invalid.Unwrap())
+
+	lone := &ValidationError{}
+	lone.add("locale", errLocaleNotSupported(&Config{}))
+	var allowedErr *AllowedValuesError
+	assert.ErrorAs(t, lone, &allowedErr)
+}
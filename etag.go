@@ -0,0 +1,104 @@
+package translatable
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v3"
+)
+
+const etagKey contextKey = "translatable_etag"
+
+// translationETag is the strong entity tag of a stored translation, derived
+// from its content and last write time. Writes are checked against it.
+func translationETag(t *Translatable) string {
+	return servedETag(t, false, false)
+}
+
+// servedETag is the strong entity tag of t as a read serves it: of its
+// published content under ?state=published, of its raw content under
+// ?raw=true, each tagged apart from the stored draft.
+func servedETag(t *Translatable, published, raw bool) string {
+	written := t.CreatedAt
+	if t.UpdatedAt != nil {
+		written = *t.UpdatedAt
+	}
+	served, variant := *t, ""
+	switch {
+	case published:
+		servePublished(&served)
+		variant = "\x00" + ReadStatePublished
+		if t.PublishedAt != nil {
+			variant += "\x00" + t.PublishedAt.UTC().Format(time.RFC3339Nano)
+		}
+	case raw:
+		serveRaw(&served)
+		variant = "\x00raw"
+	}
+	sum := sha256.Sum256([]byte(served.Content + "\x00" + written.UTC().Format(time.RFC3339Nano) + variant))
+	return `"` + hex.EncodeToString(sum[:16]) + `"`
+}
+
+// etagRecorder receives the entity tag of the translation a read loads, from
+// the CRUD hooks, for the route handler to send, along with the translation
+// as served, for the read cache.
+type etagRecorder struct {
+	etag   string
+	served *Translatable
+}
+
+func withETagRecorder(ctx context.Context) (context.Context, *etagRecorder) {
+	recorder := &etagRecorder{}
+	return context.WithValue(ctx, etagKey, recorder), recorder
+}
+
+// recordETag is called with the stored row, before any published or raw
+// content is swapped in, and tags the representation the read serves.
+func recordETag(ctx context.Context, t *Translatable) {
+	if recorder, ok := ctx.Value(etagKey).(*etagRecorder); ok {
+		recorder.etag = servedETag(t, readStateFromContext(ctx) == ReadStatePublished, rawContentFromContext(ctx))
+		recorder.served = t
+	}
+}
+
+// sendETag sets the ETag of a successful read and turns it into a 304 when the
+// client's If-None-Match already lists it.
+func sendETag(c fiber.Ctx, etag string) {
+	if etag == "" || c.Response().StatusCode() != fiber.StatusOK {
+		return
+	}
+	c.Set(fiber.HeaderETag, etag)
+	if etagListed(c.Get(fiber.HeaderIfNoneMatch), etag, false) {
+		c.Response().ResetBody()
+		c.Status(fiber.StatusNotModified)
+	}
+}
+
+// checkIfMatch rejects a write with 412 when the request carries an If-Match
+// header that does not list the current entity tag of existing. Weak tags
+// never match, as If-Match compares strongly.
+func checkIfMatch(c fiber.Ctx, existing *Translatable) error {
+	header := c.Get(fiber.HeaderIfMatch)
+	if header == "" || etagListed(header, translationETag(existing), true) {
+		return nil
+	}
+	return fiber.NewError(fiber.StatusPreconditionFailed, "translation was modified since it was read")
+}
+
+// etagListed reports whether an If-Match or If-None-Match header lists etag,
+// or is "*". A strong comparison ignores the weak tags of the header.
+func etagListed(header, etag string, strong bool) bool {
+	for _, candidate := range strings.Split(header, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if !strong {
+			candidate = strings.TrimPrefix(candidate, "W/")
+		}
+		if candidate == "*" || candidate == etag {
+			return true
+		}
+	}
+	return false
+}
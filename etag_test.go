@@ -0,0 +1,165 @@
+package translatable
+
+import (
+	"context"
+	"database/sql"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v3"
+	"github.com/google/uuid"
+	"github.com/nicolasbonnici/gorest-translatable/mocks"
+	"github.com/nicolasbonnici/gorest/database"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTranslationETag(t *testing.T) {
+	written := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	later := written.Add(time.Millisecond)
+	base := Translatable{Content: "Bonjour", CreatedAt: written}
+
+	etag := translationETag(&base)
+	assert.Regexp(t, `^"[0-9a-f]{32}"$`, etag)
+
+	edited := base
+	edited.Content = "Salut"
+	touched := base
+	touched.UpdatedAt = &later
+	assert.NotEqual(t, etag, translationETag(&edited))
+	assert.NotEqual(t, etag, translationETag(&touched))
+}
+
+func TestEtagListed(t *testing.T) {
+	assert.True(t, etagListed(`"a", "b"`, `"b"`, false))
+	assert.True(t, etagListed(`*`, `"b"`, false))
+	assert.True(t, etagListed(`W/"b"`, `"b"`, false))
+	assert.False(t, etagListed(`"a"`, `"b"`, false))
+	assert.False(t, etagListed(``, `"b"`, false))
+
+	assert.True(t, etagListed(`"a", "b"`, `"b"`, true))
+	assert.True(t, etagListed(`*`, `"b"`, true))
+	assert.False(t, etagListed(`W/"b"`, `"b"`, true), "If-Match compares strongly")
+}
+
+// etagTestDatabase serves one translation, updated at updatedAt, to every
+// single-row read and records writes.
+func etagTestDatabase(id uuid.UUID, updatedAt time.Time, writes *[]string) *mocks.MockDatabase {
+	return &mocks.MockDatabase{
+		QueryRowFunc: func(ctx context.Context, query string, args ...interface{}) database.Row {
+			return &mocks.MockRow{ScanFunc: func(dest ...interface{}) error {
+				if len(dest) == 1 {
+					return sql.ErrNoRows
+				}
+				*dest[0].(*uuid.UUID) = id
+				*dest[3].(*string) = "post"
+				*dest[4].(*string) = "fr"
+				*dest[5].(*string) = "Bonjour"
+				*dest[12].(**time.Time) = &updatedAt
+				return nil
+			}}
+		},
+		ExecFunc: func(ctx context.Context, query string, args ...interface{}) (database.Result, error) {
+			*writes = append(*writes, query)
+			return mocks.NewMockResult(1), nil
+		},
+	}
+}
+
+func TestTranslatableResource_GetByID_ETag(t *testing.T) {
+	id := uuid.New()
+	updatedAt := time.Now().UTC()
+	config := DefaultConfig()
+	app, resource := setupTestApp(etagTestDatabase(id, updatedAt, new([]string)), &config)
+	app.Get("/translations/:id", resource.GetByID)
+	expected := translationETag(&Translatable{Content: "Bonjour", UpdatedAt: &updatedAt})
+
+	resp, err := app.Test(httptest.NewRequest(fiber.MethodGet, "/translations/"+id.String(), nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+	assert.Equal(t, expected, resp.Header.Get(fiber.HeaderETag))
+
+	req := httptest.NewRequest(fiber.MethodGet, "/translations/"+id.String(), nil)
+	req.Header.Set(fiber.HeaderIfNoneMatch, expected)
+	resp, err = app.Test(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, fiber.StatusNotModified, resp.StatusCode)
+	assert.Equal(t, expected, resp.Header.Get(fiber.HeaderETag))
+
+	req = httptest.NewRequest(fiber.MethodGet, "/translations/"+id.String(), nil)
+	req.Header.Set(fiber.HeaderIfNoneMatch, `"stale"`)
+	resp, err = app.Test(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+}
+
+func TestTranslatableResource_IfMatch(t *testing.T) {
+	id := uuid.New()
+	updatedAt := time.Now().UTC()
+	current := translationETag(&Translatable{Content: "Bonjour", UpdatedAt: &updatedAt})
+
+	tests := []struct {
+		name    string
+		method  string
+		ifMatch string
+		status  int
+	}{
+		{name: "update without precondition", method: fiber.MethodPut, status: fiber.StatusOK},
+		{name: "update with current tag", method: fiber.MethodPut, ifMatch: current, status: fiber.StatusOK},
+		{name: "update with stale tag", method: fiber.MethodPut, ifMatch: `"stale"`, status: fiber.StatusPreconditionFailed},
+		{name: "update with weak current tag", method: fiber.MethodPut, ifMatch: "W/" + current, status: fiber.StatusPreconditionFailed},
+		{name: "delete with current tag", method: fiber.MethodDelete, ifMatch: current, status: fiber.StatusNoContent},
+		{name: "delete with stale tag", method: fiber.MethodDelete, ifMatch: `"stale"`, status: fiber.StatusPreconditionFailed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var writes []string
+			config := DefaultConfig()
+			app, resource := setupTestApp(etagTestDatabase(id, updatedAt, &writes), &config)
+			app.Put("/translations/:id", resource.Update)
+			app.Delete("/translations/:id", resource.Delete)
+
+			req := httptest.NewRequest(tt.method, "/translations/"+id.String(), strings.NewReader(`{"locale":"fr","content":"Salut"}`))
+			req.Header.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+			if tt.ifMatch != "" {
+				req.Header.Set(fiber.HeaderIfMatch, tt.ifMatch)
+			}
+			resp, err := app.Test(req)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			assert.Equal(t, tt.status, resp.StatusCode)
+			if tt.status == fiber.StatusPreconditionFailed {
+				assert.Empty(t, writes)
+			} else {
+				assert.Len(t, writes, 1)
+			}
+		})
+	}
+}
+
+func TestServedETag(t *testing.T) {
+	written := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	published, raw := "Bonjour", "<b>Bonjour</b>"
+	stored := Translatable{Content: "Salut", PublishedContent: &published, PublishedAt: &written, ContentRaw: &raw, CreatedAt: written}
+
+	draft := servedETag(&stored, false, false)
+	assert.Equal(t, translationETag(&stored), draft)
+	assert.NotEqual(t, draft, servedETag(&stored, true, false))
+	assert.NotEqual(t, draft, servedETag(&stored, false, true))
+	assert.NotEqual(t, servedETag(&stored, true, false), servedETag(&stored, false, true))
+
+	republished := stored
+	later := written.Add(time.Second)
+	republished.PublishedAt = &later
+	assert.NotEqual(t, servedETag(&stored, true, false), servedETag(&republished, true, false))
+}
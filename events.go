@@ -0,0 +1,145 @@
+package translatable
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	EventCreated  = "translation.created"
+	EventUpdated  = "translation.updated"
+	EventDeleted  = "translation.deleted"
+	EventRestored = "translation.restored"
+)
+
+// TranslationEvent describes a committed change to a translation. Updates carry
+// the content hash before and after the change so subscribers can skip no-op
+// updates; creations only have a new hash and deletions only an old one.
+type TranslationEvent struct {
+	Type           string    `json:"type"`
+	ID             uuid.UUID `json:"id"`
+	TranslatableID uuid.UUID `json:"translatable_id"`
+	Translatable   string    `json:"translatable"`
+	Locale         string    `json:"locale"`
+	OldContentHash string    `json:"old_content_hash,omitempty"`
+	NewContentHash string    `json:"new_content_hash,omitempty"`
+	Timestamp      time.Time `json:"timestamp"`
+}
+
+// EventHandler receives translation events synchronously, after the write has
+// succeeded. Handlers doing slow work should hand the event off.
+type EventHandler func(ctx context.Context, event TranslationEvent)
+
+// EventSink is an in-process listener receiving the translation itself rather
+// than an event, for work such as cache invalidation or search reindexing. It
+// is called once per committed mutation; restores are reported as creations.
+// Each call receives its own copy of the stored translation, safe to keep.
+type EventSink interface {
+	OnCreate(ctx context.Context, t *Translatable)
+	OnUpdate(ctx context.Context, t *Translatable)
+	OnDelete(ctx context.Context, t *Translatable)
+}
+
+// NoopEventSink is an EventSink that ignores every call. Embed it to implement
+// only the callbacks a listener cares about.
+type NoopEventSink struct{}
+
+func (NoopEventSink) OnCreate(ctx context.Context, t *Translatable) {}
+func (NoopEventSink) OnUpdate(ctx context.Context, t *Translatable) {}
+func (NoopEventSink) OnDelete(ctx context.Context, t *Translatable) {}
+
+// MultiEventSink forwards every call to each of its sinks, in order.
+type MultiEventSink []EventSink
+
+func (m MultiEventSink) OnCreate(ctx context.Context, t *Translatable) {
+	for _, sink := range m {
+		sink.OnCreate(ctx, t)
+	}
+}
+
+func (m MultiEventSink) OnUpdate(ctx context.Context, t *Translatable) {
+	for _, sink := range m {
+		sink.OnUpdate(ctx, t)
+	}
+}
+
+func (m MultiEventSink) OnDelete(ctx context.Context, t *Translatable) {
+	for _, sink := range m {
+		sink.OnDelete(ctx, t)
+	}
+}
+
+func newTranslationEvent(eventType string, t *Translatable) TranslationEvent {
+	return TranslationEvent{
+		Type:           eventType,
+		ID:             t.ID,
+		TranslatableID: t.TranslatableID,
+		Translatable:   t.Translatable,
+		Locale:         t.Locale,
+		Timestamp:      time.Now(),
+	}
+}
+
+func emitCreated(ctx context.Context, config *Config, t *Translatable) {
+	config.invalidateReads(ctx, t)
+	event := newTranslationEvent(EventCreated, t)
+	event.NewContentHash = ContentChecksum(t.Content)
+	emitEvent(ctx, config, event)
+	if config.EventSink != nil {
+		config.EventSink.OnCreate(ctx, sinkCopy(t))
+	}
+	runAfterHook(ctx, "AfterCreate", config.Hooks.AfterCreate, t)
+}
+
+func emitUpdated(ctx context.Context, config *Config, previous, t *Translatable) {
+	config.invalidateReads(ctx, previous, t)
+	event := newTranslationEvent(EventUpdated, t)
+	event.NewContentHash = ContentChecksum(t.Content)
+	if previous != nil {
+		event.OldContentHash = ContentChecksum(previous.Content)
+	}
+	emitEvent(ctx, config, event)
+	if config.EventSink != nil {
+		config.EventSink.OnUpdate(ctx, sinkCopy(t))
+	}
+	runAfterHook(ctx, "AfterUpdate", config.Hooks.AfterUpdate, t)
+}
+
+func emitDeleted(ctx context.Context, config *Config, previous *Translatable) {
+	config.invalidateReads(ctx, previous)
+	event := newTranslationEvent(EventDeleted, previous)
+	event.OldContentHash = ContentChecksum(previous.Content)
+	emitEvent(ctx, config, event)
+	if config.EventSink != nil {
+		config.EventSink.OnDelete(ctx, sinkCopy(previous))
+	}
+	runAfterHook(ctx, "AfterDelete", config.Hooks.AfterDelete, previous)
+}
+
+func emitRestored(ctx context.Context, config *Config, t *Translatable) {
+	config.invalidateReads(ctx, t)
+	event := newTranslationEvent(EventRestored, t)
+	event.NewContentHash = ContentChecksum(t.Content)
+	emitEvent(ctx, config, event)
+	if config.EventSink != nil {
+		config.EventSink.OnCreate(ctx, sinkCopy(t))
+	}
+}
+
+// sinkCopy is the translation handed to Config.EventSink: sinks may keep it,
+// while the caller goes on to serialize t for the response in place.
+func sinkCopy(t *Translatable) *Translatable {
+	stored := *t
+	return &stored
+}
+
+func emitEvent(ctx context.Context, config *Config, event TranslationEvent) {
+	if config.EventHandler != nil {
+		config.EventHandler(ctx, event)
+	}
+	if config.webhooks != nil {
+		config.webhooks.dispatch(ctx, event)
+	}
+}
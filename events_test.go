@@ -0,0 +1,190 @@
+package translatable
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gofiber/fiber/v3"
+	"github.com/google/uuid"
+	"github.com/nicolasbonnici/gorest-translatable/mocks"
+	"github.com/nicolasbonnici/gorest/database"
+	"github.com/nicolasbonnici/gorest/hooks"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEvents_CreateAndDelete(t *testing.T) {
+	var events []TranslationEvent
+	config := &Config{EventHandler: func(ctx context.Context, event TranslationEvent) {
+		events = append(events, event)
+	}}
+	h := newTranslatableCRUDHooks(config)
+	model := &Translatable{ID: uuid.New(), Translatable: "post", Locale: "fr", Content: "Bonjour"}
+
+	assert.NoError(t, h.SerializeOne(context.Background(), hooks.OperationCreate, model))
+	ctx := withPreviousVersion(context.Background(), model)
+	assert.NoError(t, h.AfterQuery(ctx, hooks.OperationDelete, "", nil, nil, errors.New("db down")))
+	assert.NoError(t, h.AfterQuery(ctx, hooks.OperationDelete, "", nil, nil, nil))
+
+	assert.Len(t, events, 2)
+	assert.Equal(t, EventCreated, events[0].Type)
+	assert.Equal(t, ContentChecksum("Bonjour"), events[0].NewContentHash)
+	assert.Empty(t, events[0].OldContentHash)
+	assert.Equal(t, EventDeleted, events[1].Type)
+	assert.Equal(t, model.ID, events[1].ID)
+	assert.Equal(t, ContentChecksum("Bonjour"), events[1].OldContentHash)
+	assert.Empty(t, events[1].NewContentHash)
+}
+
+func TestEvents_UpdateCarriesContentHashes(t *testing.T) {
+	id := uuid.New()
+	tests := []struct {
+		name    string
+		content string
+		noop    bool
+	}{
+		{name: "unchanged content", content: "Hello", noop: true},
+		{name: "changed content", content: "Hello world", noop: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := &mocks.MockDatabase{
+				QueryRowFunc: func(ctx context.Context, query string, args ...interface{}) database.Row {
+					return &mocks.MockRow{ScanFunc: func(dest ...interface{}) error {
+						if len(dest) == 1 {
+							return sql.ErrNoRows
+						}
+						*dest[0].(*uuid.UUID) = id
+						*dest[3].(*string) = "post"
+						*dest[4].(*string) = "en"
+						*dest[5].(*string) = "Hello"
+						return nil
+					}}
+				},
+			}
+			var events []TranslationEvent
+			config := DefaultConfig()
+			config.EventHandler = func(ctx context.Context, event TranslationEvent) {
+				events = append(events, event)
+			}
+			app, resource := setupTestApp(db, &config)
+			app.Put("/translations/:id", resource.Update)
+
+			req := httptest.NewRequest(fiber.MethodPut, "/translations/"+id.String(), strings.NewReader(`{"locale":"en","content":"`+tt.content+`"}`))
+			req.Header.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+			resp, err := app.Test(req)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+			assert.Len(t, events, 1)
+			assert.Equal(t, EventUpdated, events[0].Type)
+			assert.Equal(t, ContentChecksum("Hello"), events[0].OldContentHash)
+			assert.Equal(t, ContentChecksum(tt.content), events[0].NewContentHash)
+			assert.Equal(t, tt.noop, events[0].OldContentHash == events[0].NewContentHash)
+		})
+	}
+}
+
+type recordingSink struct {
+	created, updated, deleted []*Translatable
+}
+
+func (s *recordingSink) OnCreate(ctx context.Context, t *Translatable) {
+	s.created = append(s.created, t)
+}
+func (s *recordingSink) OnUpdate(ctx context.Context, t *Translatable) {
+	s.updated = append(s.updated, t)
+}
+func (s *recordingSink) OnDelete(ctx context.Context, t *Translatable) {
+	s.deleted = append(s.deleted, t)
+}
+
+func TestEventSink_CalledOncePerSuccessfulCreate(t *testing.T) {
+	tests := []struct {
+		name    string
+		locale  string
+		execErr error
+		status  int
+		calls   int
+	}{
+		{name: "created", locale: "fr", status: fiber.StatusCreated, calls: 1},
+		{name: "validation error", locale: "it", status: fiber.StatusBadRequest},
+		{name: "database error", locale: "fr", execErr: errors.New("db down"), status: fiber.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := &mocks.MockDatabase{
+				QueryRowFunc: func(ctx context.Context, query string, args ...interface{}) database.Row {
+					return &mocks.MockRow{ScanFunc: func(dest ...interface{}) error { return nil }}
+				},
+				ExecFunc: func(ctx context.Context, query string, args ...interface{}) (database.Result, error) {
+					if tt.execErr != nil {
+						return nil, tt.execErr
+					}
+					return mocks.NewMockResult(1), nil
+				},
+			}
+			first, second := &recordingSink{}, &recordingSink{}
+			config := DefaultConfig()
+			config.EventSink = MultiEventSink{first, second}
+			app, resource := setupTestApp(db, &config)
+			app.Post("/translations", resource.Create)
+
+			body := `{"translatableId":"550e8400-e29b-41d4-a716-446655440000","translatable":"post","locale":"` + tt.locale + `","content":"Bonjour"}`
+			req := httptest.NewRequest(fiber.MethodPost, "/translations", strings.NewReader(body))
+			req.Header.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+			resp, err := app.Test(req)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			assert.Equal(t, tt.status, resp.StatusCode)
+			for _, sink := range []*recordingSink{first, second} {
+				assert.Len(t, sink.created, tt.calls)
+				assert.Empty(t, sink.updated)
+			}
+			if tt.calls == 1 {
+				assert.Equal(t, "Bonjour", first.created[0].Content)
+			}
+		})
+	}
+}
+
+func TestEventSink_DeleteOnlyAfterSuccess(t *testing.T) {
+	sink := &recordingSink{}
+	config := &Config{EventSink: MultiEventSink{NoopEventSink{}, sink}}
+	h := newTranslatableCRUDHooks(config)
+	model := &Translatable{ID: uuid.New(), Content: "Bonjour"}
+	ctx := withPreviousVersion(context.Background(), model)
+
+	assert.NoError(t, h.AfterQuery(ctx, hooks.OperationDelete, "", nil, nil, errors.New("db down")))
+	assert.Empty(t, sink.deleted)
+	assert.NoError(t, h.AfterQuery(ctx, hooks.OperationDelete, "", nil, nil, nil))
+	assert.Equal(t, []*Translatable{model}, sink.deleted)
+}
+
+func TestEventSink_KeepsTheStoredTranslation(t *testing.T) {
+	sink := &recordingSink{}
+	config := &Config{EventSink: sink, FieldKeys: map[string][]string{"post": {"title"}}}
+	h := newTranslatableCRUDHooks(config)
+
+	for _, operation := range []hooks.Operation{hooks.OperationCreate, hooks.OperationUpdate} {
+		model := &Translatable{ID: uuid.New(), Translatable: "post", Content: `{"title":"Bonjour"}`}
+		assert.NoError(t, h.SerializeOne(context.Background(), operation, model))
+		assert.NotEmpty(t, model.Fields, "the response is serialized in place")
+	}
+
+	for _, received := range append(sink.created, sink.updated...) {
+		assert.Empty(t, received.Fields)
+		assert.Equal(t, `{"title":"Bonjour"}`, received.Content)
+	}
+	assert.Len(t, sink.created, 1)
+	assert.Len(t, sink.updated, 1)
+}
@@ -0,0 +1,43 @@
+package translatable
+
+import (
+	"context"
+	"encoding/csv"
+	"io"
+
+	"github.com/nicolasbonnici/gorest/database"
+	"github.com/nicolasbonnici/gorest/hooks"
+)
+
+const ExportFormatCSV = "csv"
+
+var exportCSVHeader = []string{"translatable_id", "translatable", "locale", "content"}
+
+// writeTranslationsCSV streams rows to w as CSV, one record per row as it is
+// scanned, so exports never hold the whole result set in memory. Each row goes
+// through the CRUD serializer to honor ?state= and ?raw= like the JSON reads.
+func writeTranslationsCSV(ctx context.Context, w io.Writer, rows database.Rows, serializer *translatableCRUDHooks) error {
+	out := csv.NewWriter(w)
+	if err := out.Write(exportCSVHeader); err != nil {
+		return err
+	}
+
+	for rows.Next() {
+		var t Translatable
+		if err := rows.Scan(t.scanFields()...); err != nil {
+			return err
+		}
+		if err := serializer.SerializeOne(ctx, hooks.OperationGetByID, &t); err != nil {
+			return err
+		}
+		if err := out.Write([]string{t.TranslatableID.String(), t.Translatable, t.Locale, t.Content}); err != nil {
+			return err
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	out.Flush()
+	return out.Error()
+}
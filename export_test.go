@@ -0,0 +1,94 @@
+package translatable
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gofiber/fiber/v3"
+	"github.com/google/uuid"
+	"github.com/nicolasbonnici/gorest-translatable/mocks"
+	"github.com/nicolasbonnici/gorest/database"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWriteTranslationsCSV(t *testing.T) {
+	entityID := uuid.MustParse("550e8400-e29b-41d4-a716-446655440000")
+	rows := mocks.NewMockRows(2)
+	rows.ScanFunc = func(row int, dest ...interface{}) error {
+		*dest[2].(*uuid.UUID) = entityID
+		*dest[3].(*string) = "posts"
+		*dest[4].(*string) = []string{"fr", "de"}[row]
+		*dest[5].(*string) = []string{"Bonjour, \"monde\"", "Hallo\nWelt"}[row]
+		return nil
+	}
+
+	var out bytes.Buffer
+	err := writeTranslationsCSV(context.Background(), &out, rows, newTranslatableCRUDHooks(&Config{}))
+
+	assert.NoError(t, err)
+	assert.Equal(t, "translatable_id,translatable,locale,content\n"+
+		"550e8400-e29b-41d4-a716-446655440000,posts,fr,\"Bonjour, \"\"monde\"\"\"\n"+
+		"550e8400-e29b-41d4-a716-446655440000,posts,de,\"Hallo\nWelt\"\n", out.String())
+}
+
+func TestWriteTranslationsCSV_ScanError(t *testing.T) {
+	rows := mocks.NewMockRows(2)
+	rows.ScanFunc = func(row int, dest ...interface{}) error {
+		if row == 1 {
+			return errors.New("connection reset")
+		}
+		return nil
+	}
+
+	err := writeTranslationsCSV(context.Background(), io.Discard, rows, newTranslatableCRUDHooks(&Config{}))
+
+	assert.EqualError(t, err, "connection reset")
+}
+
+func TestTranslatableResource_Export(t *testing.T) {
+	var exportSQL string
+	var exportArgs []interface{}
+	db := &mocks.MockDatabase{
+		QueryFunc: func(ctx context.Context, query string, args ...interface{}) (database.Rows, error) {
+			exportSQL = query
+			exportArgs = args
+			rows := mocks.NewMockRows(1)
+			rows.ScanFunc = func(row int, dest ...interface{}) error {
+				*dest[3].(*string) = "posts"
+				*dest[4].(*string) = "fr"
+				*dest[5].(*string) = "Bonjour"
+				return nil
+			}
+			return rows, nil
+		},
+	}
+	config := DefaultConfig()
+	app, resource := setupTestApp(db, &config)
+	app.Get("/translations/export", resource.Export)
+
+	resp, err := app.Test(httptest.NewRequest(fiber.MethodGet, "/translations/export?format=csv&locale=fr&translatable=posts", nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	body, _ := io.ReadAll(resp.Body)
+
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+	assert.Equal(t, "text/csv; charset=utf-8", resp.Header.Get(fiber.HeaderContentType))
+	assert.Equal(t, `attachment; filename="translations.csv"`, resp.Header.Get(fiber.HeaderContentDisposition))
+	assert.True(t, strings.HasSuffix(string(body), ",posts,fr,Bonjour\n"))
+	assert.Contains(t, exportSQL, "ORDER BY id ASC")
+	assert.NotContains(t, exportSQL, "LIMIT")
+	assert.Contains(t, exportArgs, "fr")
+	assert.Contains(t, exportArgs, "posts")
+
+	resp, err = app.Test(httptest.NewRequest(fiber.MethodGet, "/translations/export?format=xlsx", nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+}
@@ -0,0 +1,53 @@
+package translatable
+
+import "strings"
+
+// HeaderLocaleFallback is set on locale-aware reads that served another
+// locale than the one requested.
+const HeaderLocaleFallback = "X-Locale-Fallback"
+
+const (
+	// FallbackChainThenDefault tries the requested locale chain in order, then
+	// the configured default locale.
+	FallbackChainThenDefault = "chain_then_default"
+	// FallbackChainOnly only serves a locale from the requested chain.
+	FallbackChainOnly = "chain_only"
+)
+
+// parseLocaleChain splits a comma-separated list of locales, in order of
+// preference, dropping blanks.
+func parseLocaleChain(raw string) []string {
+	var chain []string
+	for _, locale := range strings.Split(raw, ",") {
+		if locale = strings.TrimSpace(locale); locale != "" {
+			chain = append(chain, locale)
+		}
+	}
+	return chain
+}
+
+// fallbackLocales returns the locales to try, in order, for a requested chain:
+// each requested locale followed by its configured FallbackChain, then the
+// default locale unless FallbackStrategy is chain_only.
+func (c *Config) fallbackLocales(chain []string) []string {
+	seen := make(map[string]bool, len(chain)+1)
+	candidates := make([]string, 0, len(chain)+1)
+	add := func(locale string) {
+		if !seen[locale] {
+			seen[locale] = true
+			candidates = append(candidates, locale)
+		}
+	}
+
+	for _, locale := range chain {
+		add(locale)
+		for _, fallback := range c.FallbackChain[locale] {
+			add(fallback)
+		}
+	}
+
+	if c.FallbackStrategy != FallbackChainOnly && c.DefaultLocale != "" {
+		add(c.DefaultLocale)
+	}
+	return candidates
+}
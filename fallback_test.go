@@ -0,0 +1,49 @@
+package translatable
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseLocaleChain(t *testing.T) {
+	assert.Equal(t, []string{"fr-CA", "fr"}, parseLocaleChain(" fr-CA, ,fr "))
+	assert.Empty(t, parseLocaleChain(""))
+}
+
+func TestConfig_FallbackLocales(t *testing.T) {
+	tests := []struct {
+		name     string
+		strategy string
+		chain    []string
+		expected []string
+	}{
+		{name: "chain then default", strategy: FallbackChainThenDefault, chain: []string{"fr-CA", "fr"}, expected: []string{"fr-CA", "fr", "en"}},
+		{name: "default already in chain", strategy: FallbackChainThenDefault, chain: []string{"en", "fr"}, expected: []string{"en", "fr"}},
+		{name: "empty chain falls back to default", strategy: FallbackChainThenDefault, chain: nil, expected: []string{"en"}},
+		{name: "chain only", strategy: FallbackChainOnly, chain: []string{"fr-CA", "fr"}, expected: []string{"fr-CA", "fr"}},
+		{name: "chain only with empty chain", strategy: FallbackChainOnly, chain: nil, expected: []string{}},
+		{name: "duplicates removed", strategy: FallbackChainOnly, chain: []string{"fr", "fr"}, expected: []string{"fr"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := &Config{DefaultLocale: "en", FallbackStrategy: tt.strategy}
+			assert.Equal(t, tt.expected, config.fallbackLocales(tt.chain))
+		})
+	}
+}
+
+func TestConfig_FallbackLocales_Chain(t *testing.T) {
+	config := &Config{
+		DefaultLocale:    "en",
+		FallbackStrategy: FallbackChainThenDefault,
+		FallbackChain:    map[string][]string{"fr-CA": {"fr"}, "pt-BR": {"pt", "es"}},
+	}
+
+	assert.Equal(t, []string{"fr-CA", "fr", "en"}, config.fallbackLocales([]string{"fr-CA"}))
+	assert.Equal(t, []string{"pt-BR", "pt", "es", "fr", "en"}, config.fallbackLocales([]string{"pt-BR", "fr"}))
+
+	config.FallbackStrategy = FallbackChainOnly
+	assert.Equal(t, []string{"fr-CA", "fr"}, config.fallbackLocales([]string{"fr-CA"}))
+}
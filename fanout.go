@@ -0,0 +1,134 @@
+package translatable
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/gofiber/fiber/v3"
+	"github.com/google/uuid"
+)
+
+// FanOutResult reports, per target locale, what FanOut did.
+type FanOutResult struct {
+	Created []string        `json:"created"`
+	Updated []string        `json:"updated"`
+	Skipped []string        `json:"skipped"`
+	Failed  []FanOutFailure `json:"failed"`
+}
+
+// FanOutFailure is a locale FanOut could not translate, and why.
+type FanOutFailure struct {
+	Locale string `json:"locale"`
+	Error  string `json:"error"`
+}
+
+// FanOut machine-translates the sourceLocale translation of an entity into
+// every other supported locale. Locales the entity already serves are skipped
+// unless overwrite is set, in which case their content is replaced, as long as
+// they belong to userID when it is set. Each locale is translated and stored on
+// its own: a provider or validation failure is reported in the result and the
+// remaining locales are still translated. It returns ErrTranslationNotFound when
+// the entity has no sourceLocale translation.
+func (s *TranslatableService) FanOut(ctx context.Context, translator TextTranslator, translatable string, translatableID uuid.UUID, sourceLocale string, overwrite bool, userID *uuid.UUID) (*FanOutResult, error) {
+	rows, err := s.entityRows(ctx, s.db, translatable, translatableID)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	source, ok := rows[sourceLocale]
+	if !ok || !source.isLive(now) {
+		return nil, ErrTranslationNotFound
+	}
+
+	result := &FanOutResult{Created: []string{}, Updated: []string{}, Skipped: []string{}, Failed: []FanOutFailure{}}
+	for _, locale := range s.config.SupportedLocales {
+		if locale == sourceLocale {
+			continue
+		}
+		existing, exists := rows[locale]
+		live := exists && existing.isLive(now)
+		if live && !overwrite {
+			result.Skipped = append(result.Skipped, locale)
+			continue
+		}
+		if live {
+			if err := s.config.checkOwnership(ctx, userID, existing.UserID); err != nil {
+				result.Failed = append(result.Failed, FanOutFailure{Locale: locale, Error: err.Error()})
+				continue
+			}
+		}
+
+		t, err := s.fanOutLocale(ctx, translator, &source, locale, userID)
+		if err != nil {
+			result.Failed = append(result.Failed, FanOutFailure{Locale: locale, Error: fanOutError(err)})
+			continue
+		}
+
+		if !exists {
+			if err := s.insertTranslatable(ctx, s.db, t); err != nil {
+				requestLogger(ctx).Error("fan-out write failed", "translatable_id", translatableID, "locale", locale, "error", err)
+				result.Failed = append(result.Failed, FanOutFailure{Locale: locale, Error: fanOutWriteError(err)})
+				continue
+			}
+		} else if err := s.overwriteTranslatable(ctx, s.db, &existing, t, now); err != nil {
+			requestLogger(ctx).Error("fan-out write failed", "translatable_id", translatableID, "locale", locale, "error", err)
+			result.Failed = append(result.Failed, FanOutFailure{Locale: locale, Error: fanOutWriteError(err)})
+			continue
+		}
+
+		mirrorUpsert(ctx, s.config.SecondaryWriter, t)
+		if live {
+			emitUpdated(ctx, s.config, &existing, t)
+			result.Updated = append(result.Updated, locale)
+		} else {
+			emitCreated(ctx, s.config, t)
+			result.Created = append(result.Created, locale)
+		}
+	}
+	return result, nil
+}
+
+// fanOutLocale asks translator for the locale version of source within
+// Config.TranslatorTimeout and validates the result.
+func (s *TranslatableService) fanOutLocale(ctx context.Context, translator TextTranslator, source *Translatable, locale string, userID *uuid.UUID) (*Translatable, error) {
+	providerCtx, cancel := context.WithTimeout(ctx, s.config.TranslatorTimeout)
+	defer cancel()
+
+	translated, err := translator.Translate(providerCtx, s.config.providerText(source), source.Locale, locale)
+	if err != nil {
+		// Provider errors may echo the submitted content, so only log metadata.
+		timedOut := errors.Is(err, context.DeadlineExceeded)
+		requestLogger(ctx).Error("machine translation failed", "id", source.ID, "target", locale, "timeout", timedOut)
+		if timedOut {
+			return nil, errProviderTimedOut
+		}
+		return nil, errProviderFailed
+	}
+	return s.machineTranslation(ctx, source, locale, translated, userID)
+}
+
+var (
+	errProviderTimedOut = errors.New("translation provider timed out")
+	errProviderFailed   = errors.New("translation provider request failed")
+)
+
+// fanOutError is the message reported for a locale that failed validation or
+// translation.
+func fanOutError(err error) string {
+	var fiberErr *fiber.Error
+	if errors.As(err, &fiberErr) {
+		return fiberErr.Message
+	}
+	return err.Error()
+}
+
+// fanOutWriteError is the message reported for a locale that could not be
+// stored: the rejection of a lifecycle hook, database errors staying private.
+func fanOutWriteError(err error) string {
+	var rejected *hookRejectedError
+	if errors.As(err, &rejected) {
+		return rejected.err.Message
+	}
+	return "failed to store translation"
+}
@@ -0,0 +1,156 @@
+package translatable
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gofiber/fiber/v3"
+	"github.com/google/uuid"
+	"github.com/nicolasbonnici/gorest-translatable/mocks"
+	"github.com/nicolasbonnici/gorest/database"
+	"github.com/stretchr/testify/assert"
+)
+
+func fanOutTestDatabase(entityID uuid.UUID, locales map[string]string, statements *[]string) *mocks.MockDatabase {
+	return &mocks.MockDatabase{
+		QueryFunc: func(ctx context.Context, query string, args ...interface{}) (database.Rows, error) {
+			var stored [][2]string
+			for locale, content := range locales {
+				stored = append(stored, [2]string{locale, content})
+			}
+			rows := mocks.NewMockRows(len(stored))
+			rows.ScanFunc = func(row int, dest ...interface{}) error {
+				*dest[0].(*uuid.UUID) = uuid.New()
+				*dest[2].(*uuid.UUID) = entityID
+				*dest[3].(*string) = "post"
+				*dest[4].(*string) = stored[row][0]
+				*dest[5].(*string) = stored[row][1]
+				return nil
+			}
+			return rows, nil
+		},
+		QueryRowFunc: func(ctx context.Context, query string, args ...interface{}) database.Row {
+			return &mocks.MockRow{ScanFunc: func(dest ...interface{}) error { return sql.ErrNoRows }}
+		},
+		ExecFunc: func(ctx context.Context, query string, args ...interface{}) (database.Result, error) {
+			statement, _, _ := strings.Cut(query, " ")
+			*statements = append(*statements, statement)
+			return mocks.NewMockResult(1), nil
+		},
+	}
+}
+
+func TestTranslatableResource_FanOut(t *testing.T) {
+	entityID := uuid.New()
+	translator := textTranslatorFunc(func(_ context.Context, text, source, target string) (string, error) {
+		switch target {
+		case "de":
+			return "", ErrProviderUnavailable
+		case "es":
+			return strings.Repeat("Hola ", 10), nil
+		}
+		return text + " (" + target + ")", nil
+	})
+
+	tests := []struct {
+		name       string
+		query      string
+		translator TextTranslator
+		configure  func(*Config)
+		status     int
+		expected   FanOutResult
+		statements []string
+	}{
+		{
+			name:       "skips existing locales",
+			query:      "?translatable=post",
+			translator: translator,
+			status:     fiber.StatusOK,
+			expected: FanOutResult{
+				Created: []string{"it"},
+				Updated: []string{},
+				Skipped: []string{"fr"},
+				Failed: []FanOutFailure{
+					{Locale: "de", Error: "translation provider request failed"},
+					{Locale: "es", Error: "content exceeds maximum length of 20 characters"},
+				},
+			},
+			statements: []string{"INSERT"},
+		},
+		{
+			name:       "overwrites existing locales",
+			query:      "?translatable=post&overwrite=true",
+			translator: translator,
+			status:     fiber.StatusOK,
+			expected: FanOutResult{
+				Created: []string{"it"},
+				Updated: []string{"fr"},
+				Skipped: []string{},
+				Failed: []FanOutFailure{
+					{Locale: "de", Error: "translation provider request failed"},
+					{Locale: "es", Error: "content exceeds maximum length of 20 characters"},
+				},
+			},
+			statements: []string{"UPDATE", "INSERT"},
+		},
+		{name: "other source locale", query: "?translatable=post&source=fr&overwrite=true", translator: translator, status: fiber.StatusOK,
+			expected: FanOutResult{
+				Created: []string{"it"},
+				Updated: []string{"en"},
+				Skipped: []string{},
+				Failed: []FanOutFailure{
+					{Locale: "de", Error: "translation provider request failed"},
+					{Locale: "es", Error: "content exceeds maximum length of 20 characters"},
+				},
+			},
+			statements: []string{"UPDATE", "INSERT"},
+		},
+		{name: "source without translation", query: "?translatable=post&source=it", translator: translator, status: fiber.StatusNotFound},
+		{name: "unsupported source", query: "?translatable=post&source=pt", translator: translator, status: fiber.StatusBadRequest},
+		{name: "type not allowed", query: "?translatable=page", translator: translator, status: fiber.StatusBadRequest},
+		{name: "not configured", query: "?translatable=post", status: fiber.StatusServiceUnavailable},
+		{
+			name: "json type", query: "?translatable=post", translator: textTranslatorFunc(nil), status: fiber.StatusUnprocessableEntity,
+			configure: func(config *Config) { config.TypeValidators = map[string]string{"post": ValidatorJSON} },
+		},
+		{
+			name: "multi-field type", query: "?translatable=post", translator: textTranslatorFunc(nil), status: fiber.StatusUnprocessableEntity,
+			configure: func(config *Config) { config.FieldKeys = map[string][]string{"post": {"title"}} },
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var statements []string
+			db := fanOutTestDatabase(entityID, map[string]string{"en": "Hello", "fr": "Bonjour"}, &statements)
+			config := DefaultConfig()
+			config.AllowedTypes = []string{"post"}
+			config.SupportedLocales = []string{"en", "fr", "de", "es", "it"}
+			config.MaxContentLength = 20
+			config.TextTranslator = tt.translator
+			if tt.configure != nil {
+				tt.configure(&config)
+			}
+			app, resource := setupTestApp(db, &config)
+			app.Post("/translations/:translatable_id/fan-out", resource.FanOut)
+
+			resp, err := app.Test(httptest.NewRequest(fiber.MethodPost, "/translations/"+entityID.String()+"/fan-out"+tt.query, nil))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			assert.Equal(t, tt.status, resp.StatusCode)
+			assert.Equal(t, tt.statements, statements)
+			if tt.status != fiber.StatusOK {
+				return
+			}
+			var result FanOutResult
+			assert.NoError(t, json.NewDecoder(resp.Body).Decode(&result))
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
@@ -0,0 +1,157 @@
+package translatable
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/gofiber/fiber/v3"
+)
+
+// FieldValue is the field plain string content maps to on a multi-field type,
+// so clients sending "content" keep working once a type gains fields.
+const FieldValue = "value"
+
+// IsMultiField reports whether typeName stores its content as named fields,
+// i.e. it is listed in FieldKeys.
+func (c *Config) IsMultiField(typeName string) bool {
+	_, ok := c.FieldKeys[typeName]
+	return ok
+}
+
+// isAllowedField reports whether key is a field of the multi-field type
+// typeName. FieldValue always is.
+func (c *Config) isAllowedField(typeName, key string) bool {
+	return key == FieldValue || slices.Contains(c.FieldKeys[typeName], key)
+}
+
+func (c *Config) validateFieldKeys() error {
+	if len(c.FieldKeys) > 0 && c.ContentFormat == ContentFormatJSON {
+		return errors.New("field_keys requires content_format text")
+	}
+	for typeName, keys := range c.FieldKeys {
+		if !c.IsAllowedType(typeName) {
+			return fmt.Errorf("field_keys references unknown type: %s", typeName)
+		}
+		if c.contentFormat(typeName) == ContentFormatJSON {
+			return fmt.Errorf("field_keys for %s requires a text type", typeName)
+		}
+		for _, key := range keys {
+			if strings.TrimSpace(key) == "" {
+				return fmt.Errorf("field_keys for %s contains an empty key", typeName)
+			}
+		}
+	}
+	return nil
+}
+
+// encodeFields serializes fields into the JSON object stored as content.
+// Keys are written in sorted order, so equal fields give equal checksums.
+func encodeFields(fields map[string]string) string {
+	encoded, _ := json.Marshal(fields)
+	return string(encoded)
+}
+
+// decodeFields parses content stored by encodeFields. Content that is not a
+// JSON object of strings, e.g. written before its type gained fields, is
+// returned as the FieldValue field.
+func decodeFields(content string) map[string]string {
+	var fields map[string]string
+	if err := json.Unmarshal([]byte(content), &fields); err != nil || fields == nil {
+		return map[string]string{FieldValue: content}
+	}
+	return fields
+}
+
+// prepareFields validates the fields of a multi-field write, or the plain
+// content standing for the FieldValue field, and returns the content and raw
+// content to persist. Each field is checked like single content is.
+func (h *TranslatableHooks) prepareFields(typeName, content string, fields map[string]string) (string, *string, error) {
+	if len(fields) == 0 {
+		if !h.config.IsMultiField(typeName) {
+			prepared, err := h.prepareContent(typeName, content)
+			return prepared, h.rawContent(typeName, content), err
+		}
+		fields = map[string]string{FieldValue: content}
+	} else if content != "" {
+		return "", nil, fiber.NewError(400, "content and fields cannot both be set")
+	} else if !h.config.IsMultiField(typeName) {
+		return "", nil, fiber.NewError(400, "fields are not enabled for type "+typeName)
+	}
+
+	prepared := make(map[string]string, len(fields))
+	raw := make(map[string]string, len(fields))
+	for key, value := range fields {
+		content, rawContent, err := h.prepareField(typeName, key, value)
+		if err != nil {
+			return "", nil, err
+		}
+		prepared[key] = content
+		if rawContent != nil {
+			raw[key] = *rawContent
+		}
+	}
+
+	var rawContent *string
+	if h.config.StoreRawContent {
+		encoded := encodeFields(raw)
+		rawContent = &encoded
+	}
+	return encodeFields(prepared), rawContent, nil
+}
+
+// prepareField validates one field of the multi-field type typeName and
+// returns its value and raw value to persist.
+func (h *TranslatableHooks) prepareField(typeName, key, value string) (string, *string, error) {
+	if !h.config.isAllowedField(typeName, key) {
+		return "", nil, &AllowedValuesError{
+			Message: "unknown field: " + key,
+			Code:    CodeInvalidField,
+			Allowed: append([]string{FieldValue}, h.config.FieldKeys[typeName]...),
+			Params:  map[string]string{"field": key},
+		}
+	}
+	content, err := h.prepareContent(typeName, value)
+	if err != nil {
+		return "", nil, fieldError(key, err)
+	}
+	return content, h.rawContent(typeName, value), nil
+}
+
+// contentField names the request field a content error is reported against:
+// fields when only fields were sent, content otherwise.
+func contentField(content string, fields map[string]string) string {
+	if len(fields) > 0 && content == "" {
+		return "fields"
+	}
+	return "content"
+}
+
+// fieldError names the field a content validation error is about.
+func fieldError(key string, err error) error {
+	var problem *ProblemError
+	if errors.As(err, &problem) {
+		return &ProblemError{
+			Status:  problem.Status,
+			Code:    problem.Code,
+			Message: "field " + key + ": " + problem.Message,
+			Params:  problem.Params,
+			key:     problem.key,
+			field:   key,
+		}
+	}
+	var fiberErr *fiber.Error
+	if errors.As(err, &fiberErr) {
+		return fiber.NewError(fiberErr.Code, "field "+key+": "+fiberErr.Message)
+	}
+	return err
+}
+
+// serveFields fills model.Fields from its content when its type is multi-field.
+func (c *Config) serveFields(model *Translatable) {
+	if c.IsMultiField(model.Translatable) {
+		model.Fields = decodeFields(model.Content)
+	}
+}
@@ -0,0 +1,130 @@
+package translatable
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gofiber/fiber/v3"
+	"github.com/google/uuid"
+	"github.com/nicolasbonnici/gorest-translatable/mocks"
+	"github.com/nicolasbonnici/gorest/database"
+	"github.com/stretchr/testify/assert"
+)
+
+func fieldsConfig() Config {
+	config := DefaultConfig()
+	config.AllowedTypes = []string{"post", "comment"}
+	config.FieldKeys = map[string][]string{"post": {"title", "body", "excerpt"}}
+	config.MaxContentLength = 20
+	return config
+}
+
+func TestTranslatableResource_Create_Fields(t *testing.T) {
+	tests := []struct {
+		name         string
+		translatable string
+		payload      string
+		status       int
+		content      string
+		code         string
+	}{
+		{name: "multiple fields", translatable: "post", payload: `"fields":{"title":"Bonjour","body":"Le corps"}`, status: fiber.StatusCreated, content: `{"body":"Le corps","title":"Bonjour"}`},
+		{name: "plain content maps to value", translatable: "post", payload: `"content":"Bonjour"`, status: fiber.StatusCreated, content: `{"value":"Bonjour"}`},
+		{name: "plain content on single field type", translatable: "comment", payload: `"content":"Bonjour"`, status: fiber.StatusCreated, content: "Bonjour"},
+		{name: "unknown field", translatable: "post", payload: `"fields":{"subtitle":"Bonjour"}`, status: fiber.StatusBadRequest, code: CodeInvalidField},
+		{name: "field too long", translatable: "post", payload: `"fields":{"title":"Bonjour","body":"` + strings.Repeat("a", 21) + `"}`, status: fiber.StatusBadRequest, code: CodeContentTooLong},
+		{name: "empty field", translatable: "post", payload: `"fields":{"title":" "}`, status: fiber.StatusBadRequest, code: CodeContentEmpty},
+		{name: "content and fields", translatable: "post", payload: `"content":"Bonjour","fields":{"title":"Bonjour"}`, status: fiber.StatusBadRequest},
+		{name: "fields on single field type", translatable: "comment", payload: `"fields":{"title":"Bonjour"}`, status: fiber.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var stored string
+			db := &mocks.MockDatabase{
+				QueryRowFunc: func(ctx context.Context, query string, args ...interface{}) database.Row {
+					return &mocks.MockRow{ScanFunc: func(dest ...interface{}) error { return sql.ErrNoRows }}
+				},
+				ExecFunc: func(ctx context.Context, query string, args ...interface{}) (database.Result, error) {
+					stored = args[5].(string)
+					return mocks.NewMockResult(1), nil
+				},
+			}
+			config := fieldsConfig()
+			app, resource := setupTestApp(db, &config)
+			app.Post("/translations", resource.Create)
+
+			body := `{"translatableId":"` + uuid.NewString() + `","translatable":"` + tt.translatable + `","locale":"fr",` + tt.payload + `}`
+			req := httptest.NewRequest(fiber.MethodPost, "/translations", strings.NewReader(body))
+			req.Header.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+			resp, err := app.Test(req)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			assert.Equal(t, tt.status, resp.StatusCode)
+			assert.Equal(t, tt.content, stored)
+			if tt.code != "" {
+				var problem ProblemDetails
+				assert.NoError(t, json.NewDecoder(resp.Body).Decode(&problem))
+				assert.Equal(t, tt.code, problem.Code)
+			}
+		})
+	}
+}
+
+func TestTranslatableResource_GetByID_Fields(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		fields  map[string]string
+	}{
+		{name: "stored fields", content: `{"body":"Le corps","title":"Bonjour"}`, fields: map[string]string{"title": "Bonjour", "body": "Le corps"}},
+		{name: "stored before fields", content: "Bonjour", fields: map[string]string{FieldValue: "Bonjour"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := &mocks.MockDatabase{
+				QueryRowFunc: func(ctx context.Context, query string, args ...interface{}) database.Row {
+					return &mocks.MockRow{ScanFunc: func(dest ...interface{}) error {
+						*dest[3].(*string) = "post"
+						*dest[4].(*string) = "fr"
+						*dest[5].(*string) = tt.content
+						return nil
+					}}
+				},
+			}
+			config := fieldsConfig()
+			app, resource := setupTestApp(db, &config)
+			app.Get("/translations/:id", resource.GetByID)
+
+			resp, err := app.Test(httptest.NewRequest(fiber.MethodGet, "/translations/"+uuid.NewString(), nil))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+			var body Translatable
+			assert.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+			assert.Equal(t, tt.fields, body.Fields)
+		})
+	}
+}
+
+func TestConfig_ValidateFieldKeys(t *testing.T) {
+	config := fieldsConfig()
+	assert.NoError(t, config.Validate())
+
+	config = fieldsConfig()
+	config.FieldKeys = map[string][]string{"page": {"title"}}
+	assert.EqualError(t, config.Validate(), "field_keys references unknown type: page")
+
+	config = fieldsConfig()
+	config.ContentFormat = ContentFormatJSON
+	assert.EqualError(t, config.Validate(), "field_keys requires content_format text")
+}
@@ -2,9 +2,13 @@ package translatable
 
 import (
 	"context"
+	"database/sql"
 	"errors"
-	"html"
+	"fmt"
+	"strconv"
 	"strings"
+	"time"
+	"unicode/utf8"
 
 	"github.com/gofiber/fiber/v3"
 	"github.com/google/uuid"
@@ -14,6 +18,14 @@ import (
 	"github.com/nicolasbonnici/gorest/query"
 )
 
+// HeaderTranslationWarning is set to TranslationWarningUntranslated on writes
+// whose content is identical to the source-locale content, under
+// Config.WarnOnUntranslated.
+const (
+	HeaderTranslationWarning       = "X-Translation-Warning"
+	TranslationWarningUntranslated = "untranslated"
+)
+
 type TranslatableHooks struct {
 	db     database.Database
 	config *Config
@@ -27,116 +39,536 @@ func NewTranslatableHooks(db database.Database, config *Config) *TranslatableHoo
 }
 
 func (h *TranslatableHooks) CreateHook(c fiber.Ctx, dto TranslatableCreateDTO, model *Translatable) error {
-	if _, err := uuid.Parse(dto.TranslatableID); err != nil {
-		return fiber.NewError(400, "translatable_id must be a valid UUID")
+	if err := h.prepareCreate(c, dto, model); err != nil {
+		return err
 	}
-
-	if !h.config.IsAllowedType(dto.Translatable) {
-		return fiber.NewError(400, "translatable type is not allowed")
+	if err := runBeforeHook(auth.Context(c), h.config.Hooks.BeforeCreate, model); err != nil {
+		return err
 	}
+	c.SetContext(withWriteLocale(c.Context(), model.Locale))
 
-	if !h.config.IsSupportedLocale(dto.Locale) {
-		return fiber.NewError(400, "locale is not supported")
-	}
+	return nil
+}
 
-	content := strings.TrimSpace(dto.Content)
-	if content == "" {
-		return fiber.NewError(400, "content cannot be empty")
+// prepareCreate validates a create and builds the translation to store, as
+// CreateHook does, leaving the lifecycle hook to the caller: an upsert only
+// knows whether it creates or updates once it has read the stored row.
+func (h *TranslatableHooks) prepareCreate(c fiber.Ctx, dto TranslatableCreateDTO, model *Translatable) error {
+	if err := h.validateCreate(c, dto, model).err(); err != nil {
+		return err
 	}
 
-	if len(content) > h.config.MaxContentLength {
-		return fiber.NewError(400, "content exceeds maximum length")
+	ctx := auth.Context(c)
+	if err := h.checkDefaultLocaleFirst(c, model); err != nil {
+		return err
+	}
+	checksum, err := h.sourceChecksum(ctx, model)
+	if err != nil {
+		return err
+	}
+	model.SourceChecksum = checksum
+	h.warnUntranslated(c, model)
+	h.config.serveFields(model)
+	model.ReceivedAt = h.trackReceivedAt(ctx)
+	model.Version = 1
+	model.TenantID = h.config.tenantID(ctx)
+	model.Status = h.config.DefaultStatus
+
+	if ttl, ok := h.config.TypeTTLs[dto.Translatable]; ok {
+		expiresAt := time.Now().Add(ttl)
+		model.ExpiresAt = &expiresAt
 	}
-
-	model.Content = html.EscapeString(content)
 
 	userID := getUserIDFromFiberContext(c)
 	if userID != nil {
 		model.UserID = userID
 	}
-
 	return nil
 }
 
-func (h *TranslatableHooks) UpdateHook(c fiber.Ctx, dto TranslatableUpdateDTO, model *Translatable) error {
-	if !h.config.IsSupportedLocale(dto.Locale) {
-		return fiber.NewError(400, "locale is not supported")
+// validateCreate checks the fields of a create that need no database, and
+// sets the locale and the content, sanitized, the translation would be stored
+// with.
+func (h *TranslatableHooks) validateCreate(c fiber.Ctx, dto TranslatableCreateDTO, model *Translatable) *ValidationError {
+	invalid := &ValidationError{}
+	if _, err := uuid.Parse(dto.TranslatableID); err != nil {
+		invalid.add("translatableId", fiber.NewError(400, "translatable_id must be a valid UUID"))
+	}
+
+	if !h.config.IsAllowedType(dto.Translatable) {
+		invalid.add("translatable", errTypeNotAllowed(h.config))
 	}
 
-	content := strings.TrimSpace(dto.Content)
-	if content == "" {
-		return fiber.NewError(400, "content cannot be empty")
+	model.Locale = h.config.normalizeLocale(dto.Locale)
+	if err := h.checkLocale(c, model.Locale); err != nil {
+		invalid.add("locale", err)
 	}
 
-	if len(content) > h.config.MaxContentLength {
-		return fiber.NewError(400, "content exceeds maximum length")
+	// Content is validated against the rules of its type, so an unknown type
+	// leaves nothing to check it with.
+	if !invalid.has("translatable") {
+		content, raw, err := h.prepareFields(dto.Translatable, dto.Content, dto.Fields)
+		if err != nil {
+			invalid.add(contentField(dto.Content, dto.Fields), err)
+		}
+		model.Content = content
+		model.ContentRaw = raw
 	}
+	return invalid
+}
 
-	model.Content = html.EscapeString(content)
+func (h *TranslatableHooks) UpdateHook(c fiber.Ctx, dto TranslatableUpdateDTO, model *Translatable) error {
+	patch := patchFromContext(c.Context())
+	invalid := &ValidationError{}
+	if patch != nil {
+		dto.Version = patch.Version
+	}
+	if patch == nil || patch.Locale != nil {
+		if patch != nil {
+			dto.Locale = *patch.Locale
+		}
+		model.Locale = h.config.normalizeLocale(dto.Locale)
+		if err := h.checkLocale(c, model.Locale); err != nil {
+			invalid.add("locale", err)
+		}
+	}
 
 	id := c.Params("id")
 	ctx := auth.Context(c)
 	userID := getUserIDFromFiberContext(c)
 
 	existing, err := h.getTranslatable(ctx, id)
-	if err != nil {
+	if errors.Is(err, ErrTranslationNotFound) {
+		if err := invalid.err(); err != nil {
+			return err
+		}
 		return fiber.NewError(404, "Translation not found")
 	}
+	if err != nil {
+		return errDatabase(err, "failed to update translation")
+	}
 
-	if userID != nil && existing.UserID != nil && *existing.UserID != *userID {
-		return fiber.NewError(403, "You can only update your own translations")
+	if patch != nil {
+		if patch.Locale == nil {
+			model.Locale = existing.Locale
+		}
+		if err := h.applyPatch(patch, existing, model); err != nil {
+			var content string
+			if patch.Content != nil {
+				content = *patch.Content
+			}
+			invalid.add(contentField(content, patch.Fields), err)
+		}
+	} else {
+		content, raw, err := h.prepareFields(existing.Translatable, dto.Content, dto.Fields)
+		if err != nil {
+			invalid.add(contentField(dto.Content, dto.Fields), err)
+		}
+		model.Content = content
+		model.ContentRaw = raw
+	}
+	if err := invalid.err(); err != nil {
+		return err
+	}
+
+	if err := errOwnership(h.config.checkOwnership(ctx, userID, existing.UserID), "update"); err != nil {
+		return err
+	}
+	if err := checkIfMatch(c, existing); err != nil {
+		return err
+	}
+	if dto.Version != nil && *dto.Version != existing.Version {
+		return &VersionConflictError{Current: existing}
+	}
+
+	now := time.Now()
+	model.ID = existing.ID
+	model.UserID = existing.UserID
+	model.TranslatableID = existing.TranslatableID
+	model.Translatable = existing.Translatable
+	model.PublishedContent = existing.PublishedContent
+	model.PublishedAt = existing.PublishedAt
+	model.ExpiresAt = existing.ExpiresAt
+	model.CreatedAt = existing.CreatedAt
+	model.ReceivedAt = existing.ReceivedAt
+	model.UpdatedAt = &now
+	model.Version = existing.Version + 1
+	model.TenantID = existing.TenantID
+	model.Status = existing.Status
+	model.ReviewedBy = existing.ReviewedBy
+	model.ReviewedAt = existing.ReviewedAt
+	if model.SourceChecksum, err = h.sourceChecksum(ctx, model); err != nil {
+		return err
+	}
+	h.warnUntranslated(c, model)
+	if err := runBeforeHook(ctx, h.config.Hooks.BeforeUpdate, model); err != nil {
+		return err
 	}
+	h.config.serveFields(model)
+
+	guard := &versionGuard{version: existing.Version, reload: func() (*Translatable, error) {
+		return h.getTranslatable(ctx, id)
+	}}
+	c.SetContext(withWriteLocale(withVersionGuard(withPreviousVersion(c.Context(), existing), guard), model.Locale))
 
 	return nil
 }
 
+// prepareEntityLocales validates the per-locale content of a bulk entity write
+// and builds the translations to store, as CreateHook does for a single one.
+func (h *TranslatableHooks) prepareEntityLocales(c fiber.Ctx, translatable string, translatableID uuid.UUID, contents map[string]string) ([]Translatable, error) {
+	if !h.config.IsAllowedType(translatable) {
+		return nil, errTypeNotAllowed(h.config)
+	}
+	if len(contents) == 0 {
+		return nil, fiber.NewError(400, "translations cannot be empty")
+	}
+
+	ctx := auth.Context(c)
+	userID := getUserIDFromFiberContext(c)
+	now := time.Now()
+
+	var sourceChecksum *string
+	if source, ok := contents[h.config.DefaultLocale]; ok {
+		content, err := h.prepareContent(translatable, source)
+		if err != nil {
+			return nil, err
+		}
+		checksum := ContentChecksum(content)
+		sourceChecksum = &checksum
+	}
+
+	translations := make([]Translatable, 0, len(contents))
+	seen := make(map[string]bool, len(contents))
+	for locale, raw := range contents {
+		locale = h.config.normalizeLocale(locale)
+		if err := h.checkLocale(c, locale); err != nil {
+			return nil, err
+		}
+		if seen[locale] {
+			return nil, fiber.NewError(400, "translations contain the same locale twice: "+locale)
+		}
+		seen[locale] = true
+		content, err := h.prepareContent(translatable, raw)
+		if err != nil {
+			return nil, err
+		}
+
+		t := Translatable{
+			ID:             uuid.New(),
+			UserID:         userID,
+			TranslatableID: translatableID,
+			Translatable:   translatable,
+			Locale:         locale,
+			Content:        content,
+			ContentRaw:     h.rawContent(translatable, raw),
+			SourceChecksum: sourceChecksum,
+			ReceivedAt:     h.trackReceivedAt(ctx),
+			CreatedAt:      now,
+		}
+		if sourceChecksum == nil {
+			if t.SourceChecksum, err = h.sourceChecksum(ctx, &t); err != nil {
+				return nil, err
+			}
+		}
+		if ttl, ok := h.config.TypeTTLs[translatable]; ok {
+			expiresAt := now.Add(ttl)
+			t.ExpiresAt = &expiresAt
+		}
+		translations = append(translations, t)
+	}
+	return translations, nil
+}
+
+// prepareImportRecord validates one row of a CSV import like CreateHook
+// validates a create request, and builds the translation to store.
+func (h *TranslatableHooks) prepareImportRecord(c fiber.Ctx, record importRecord) (Translatable, error) {
+	translatableID, err := uuid.Parse(record.Fields[0])
+	if err != nil {
+		return Translatable{}, fiber.NewError(400, "translatable_id must be a valid UUID")
+	}
+	translatable, locale, raw := record.Fields[1], h.config.normalizeLocale(record.Fields[2]), record.Fields[3]
+	if !h.config.IsAllowedType(translatable) {
+		return Translatable{}, errTypeNotAllowed(h.config)
+	}
+	if err := h.checkLocale(c, locale); err != nil {
+		return Translatable{}, err
+	}
+	content, err := h.prepareContent(translatable, raw)
+	if err != nil {
+		return Translatable{}, err
+	}
+
+	ctx := auth.Context(c)
+	now := time.Now()
+	t := Translatable{
+		ID:             uuid.New(),
+		UserID:         getUserIDFromFiberContext(c),
+		TranslatableID: translatableID,
+		Translatable:   translatable,
+		Locale:         locale,
+		Content:        content,
+		ContentRaw:     h.rawContent(translatable, raw),
+		ReceivedAt:     h.trackReceivedAt(ctx),
+		CreatedAt:      now,
+	}
+	if t.SourceChecksum, err = h.sourceChecksum(ctx, &t); err != nil {
+		return Translatable{}, err
+	}
+	if ttl, ok := h.config.TypeTTLs[translatable]; ok {
+		expiresAt := now.Add(ttl)
+		t.ExpiresAt = &expiresAt
+	}
+	return t, nil
+}
+
+// checkLocale requires locale to be one of SupportedLocales. Admins can pass
+// ?skip_locale_validation=true to write a locale outside that list, e.g. one
+// being retired, as long as it is a well-formed tag; others have it ignored.
+func (h *TranslatableHooks) checkLocale(c fiber.Ctx, locale string) error {
+	if h.config.IsSupportedLocale(locale) {
+		return nil
+	}
+	if isWellFormedLocale(locale) && c.Query("skip_locale_validation") == "true" && h.config.IsAdmin(auth.Context(c)) {
+		return nil
+	}
+	return errInvalidLocale(h.config, locale)
+}
+
+// prepareContent validates raw content of a type against the configured format
+// and limits and returns the value to persist, sanitized for text content.
+func (h *TranslatableHooks) prepareContent(typeName, raw string) (string, error) {
+	if strings.TrimSpace(raw) == "" {
+		return "", newProblemError(400, CodeContentEmpty, "content cannot be empty")
+	}
+
+	if !utf8.ValidString(raw) {
+		return "", newProblemError(400, CodeContentEncoding, "content must be valid UTF-8")
+	}
+
+	content := h.config.normalizeContent(typeName, raw)
+	if h.config.contentLength(content) > h.config.MaxContentLength {
+		unit := h.config.contentLengthUnit()
+		return "", &ProblemError{
+			Status:  400,
+			Code:    CodeContentTooLong,
+			Message: fmt.Sprintf("content exceeds maximum length of %d %s", h.config.MaxContentLength, unit),
+			Params:  map[string]string{"max": strconv.Itoa(h.config.MaxContentLength)},
+			key:     CodeContentTooLong + "." + unit,
+		}
+	}
+
+	if h.config.contentFormat(typeName) == ContentFormatJSON {
+		if err := validateJSONContent(content, h.config.MaxJSONDepth, h.config.MaxJSONKeys); err != nil {
+			if errors.Is(err, errInvalidJSON) {
+				return "", fiber.NewError(400, err.Error())
+			}
+			return "", fiber.NewError(422, err.Error())
+		}
+		return content, nil
+	}
+
+	return h.config.sanitizer(typeName).Sanitize(content), nil
+}
+
 func (h *TranslatableHooks) DeleteHook(c fiber.Ctx, id any) error {
 	ctx := auth.Context(c)
 	userID := getUserIDFromFiberContext(c)
 
 	existing, err := h.getTranslatable(ctx, id)
-	if err != nil {
+	if errors.Is(err, ErrTranslationNotFound) {
 		return fiber.NewError(404, "Translation not found")
 	}
+	if err != nil {
+		return errDatabase(err, "failed to delete translation")
+	}
 
-	if userID != nil && existing.UserID != nil && *existing.UserID != *userID {
-		return fiber.NewError(403, "You can only delete your own translations")
+	if err := errOwnership(h.config.checkOwnership(ctx, userID, existing.UserID), "delete"); err != nil {
+		return err
+	}
+	if err := checkIfMatch(c, existing); err != nil {
+		return err
+	}
+	if err := runBeforeHook(ctx, h.config.Hooks.BeforeDelete, existing); err != nil {
+		return err
 	}
 
+	c.SetContext(withPreviousVersion(withDeletedBy(withPendingDelete(c.Context(), existing.ID), userID), existing))
 	return nil
 }
 
 func (h *TranslatableHooks) GetByIDHook(c fiber.Ctx, id any) error {
-	return nil
+	applyCacheControl(c)
+	applyInclude(c)
+	return applyReadState(c)
 }
 
 func (h *TranslatableHooks) GetAllHook(c fiber.Ctx, conditions *[]query.Condition, orderBy *[]crud.OrderByClause) error {
+	applyCacheControl(c)
+	applyExpand(c)
+	applyInclude(c)
+
+	params := queryParams(c)
+	ranges, err := dateRangeConditions(params)
+	if err != nil {
+		return fiber.NewError(400, err.Error())
+	}
+	*conditions = append(*conditions, ranges...)
+
+	sort, err := h.config.sortClause(params)
+	if err != nil {
+		return err
+	}
+	if sort != nil {
+		*orderBy = append([]crud.OrderByClause{*sort}, *orderBy...)
+	} else if len(*orderBy) == 0 {
+		*orderBy = append(*orderBy, defaultSort)
+	}
+	return applyReadState(c)
+}
+
+// applyCacheControl lets a single read opt out of the read cache, e.g. for
+// editor previews, via "Cache-Control: no-cache" or ?no_cache=true.
+func applyCacheControl(c fiber.Ctx) {
+	noCache := strings.Contains(strings.ToLower(c.Get(fiber.HeaderCacheControl)), "no-cache")
+	if noCache || c.Query("no_cache") == "true" {
+		c.SetContext(withCacheBypass(c.Context()))
+	}
+}
+
+// applyReadState records the requested ?state= and ?raw= on the request context
+// so the CRUD hooks can serve published snapshots or unescaped content instead
+// of the escaped working content.
+func applyReadState(c fiber.Ctx) error {
+	if c.Query("raw") == "true" {
+		c.SetContext(withRawContent(c.Context()))
+	}
+
+	switch state := c.Query("state"); state {
+	case "", ReadStateDraft:
+		return nil
+	case ReadStatePublished:
+		c.SetContext(withReadState(c.Context(), state))
+		return nil
+	default:
+		return fiber.NewError(400, "state must be draft or published")
+	}
+}
+
+// rawContent returns the submitted content of a type to keep next to its
+// escaped form when Config.StoreRawContent is set. JSON content is never
+// escaped, so there is nothing to keep.
+func (h *TranslatableHooks) rawContent(typeName, raw string) *string {
+	if !h.config.StoreRawContent || h.config.contentFormat(typeName) == ContentFormatJSON {
+		return nil
+	}
+	raw = h.config.normalizeContent(typeName, raw)
+	return &raw
+}
+
+// checkDefaultLocaleFirst enforces Config.DefaultLocaleFirst on creation: a
+// non-default locale needs an existing default-locale translation unless an
+// admin forces it.
+func (h *TranslatableHooks) checkDefaultLocaleFirst(c fiber.Ctx, model *Translatable) error {
+	if !h.config.DefaultLocaleFirst || model.Locale == h.config.DefaultLocale {
+		return nil
+	}
+
+	ctx := auth.Context(c)
+	if c.Query("force") == "true" {
+		if !h.config.IsAdmin(ctx) {
+			return fiber.NewError(403, "force requires the admin role")
+		}
+		return nil
+	}
+
+	_, ok, err := h.defaultLocaleContent(ctx, model)
+	if err != nil {
+		return errDatabase(err, "failed to read the "+h.config.DefaultLocale+" translation")
+	}
+	if !ok {
+		return fiber.NewError(409, "a "+h.config.DefaultLocale+" translation must be created first")
+	}
 	return nil
 }
 
+// sourceChecksum returns the checksum of the source-locale content model is
+// translated from: its own content in the default locale, otherwise that of its
+// default-locale sibling, or nil while there is none. A failed lookup is
+// reported as errDatabase rather than stored as a missing source.
+func (h *TranslatableHooks) sourceChecksum(ctx context.Context, model *Translatable) (*string, error) {
+	if model.Locale == h.config.DefaultLocale {
+		checksum := ContentChecksum(model.Content)
+		return &checksum, nil
+	}
+
+	source, ok, err := h.defaultLocaleContent(ctx, model)
+	if err != nil {
+		return nil, errDatabase(err, "failed to read the "+h.config.DefaultLocale+" translation")
+	}
+	if !ok {
+		return nil, nil
+	}
+	checksum := ContentChecksum(source)
+	return &checksum, nil
+}
+
+// warnUntranslated sets HeaderTranslationWarning, under
+// Config.WarnOnUntranslated, when model is written outside the default locale
+// with the same content as its source, i.e. was likely never translated.
+func (h *TranslatableHooks) warnUntranslated(c fiber.Ctx, model *Translatable) {
+	if !h.config.WarnOnUntranslated || model.Locale == h.config.DefaultLocale || model.SourceChecksum == nil {
+		return
+	}
+	if ContentChecksum(model.Content) == *model.SourceChecksum {
+		c.Set(HeaderTranslationWarning, TranslationWarningUntranslated)
+	}
+}
+
+// defaultLocaleContent returns the content of the default-locale translation of
+// the entity model belongs to, and false when there is none. Other errors of
+// the lookup are returned as is.
+func (h *TranslatableHooks) defaultLocaleContent(ctx context.Context, model *Translatable) (string, bool, error) {
+	d := h.db.Dialect()
+	tenant, args := h.config.tenantCondition(ctx, d, "tenant_id", []any{model.Translatable, model.TranslatableID, h.config.DefaultLocale, time.Now()})
+	statement := "SELECT content FROM " + h.config.table() + " WHERE translatable = " + d.Placeholder(1) +
+		" AND translatable_id = " + d.Placeholder(2) +
+		" AND locale = " + d.Placeholder(3) +
+		" AND deleted_at IS NULL AND (expires_at IS NULL OR expires_at > " + d.Placeholder(4) + ")" + tenant
+	var content string
+	err := h.db.QueryRow(ctx, statement, args...).Scan(&content)
+	if errors.Is(err, sql.ErrNoRows) {
+		return "", false, nil
+	}
+	if err != nil {
+		return "", false, err
+	}
+	return content, true, nil
+}
+
+// getTranslatable reads the live translation id names. It returns
+// ErrTranslationNotFound when there is none, id not being a UUID included, and
+// the database error otherwise.
 func (h *TranslatableHooks) getTranslatable(ctx context.Context, id any) (*Translatable, error) {
 	var t Translatable
 	idStr, ok := id.(string)
 	if !ok {
-		return nil, errors.New("invalid ID type")
+		return nil, ErrTranslationNotFound
 	}
 
 	idUUID, err := uuid.Parse(idStr)
 	if err != nil {
-		return nil, err
+		return nil, ErrTranslationNotFound
 	}
 
-	sql := "SELECT * FROM translations WHERE id = " + h.db.Dialect().Placeholder(1)
-	err = h.db.QueryRow(ctx, sql, idUUID).Scan(
-		&t.ID,
-		&t.UserID,
-		&t.TranslatableID,
-		&t.Translatable,
-		&t.Locale,
-		&t.Content,
-		&t.UpdatedAt,
-		&t.CreatedAt,
-	)
+	d := h.db.Dialect()
+	tenant, args := h.config.tenantCondition(ctx, d, "tenant_id", []any{idUUID, time.Now()})
+	statement := "SELECT " + translatableColumns + " FROM " + h.config.table() + " WHERE id = " + d.Placeholder(1) +
+		" AND deleted_at IS NULL AND (expires_at IS NULL OR expires_at > " + d.Placeholder(2) + ")" + tenant
+	err = h.db.QueryRow(ctx, statement, args...).Scan(t.scanFields()...)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrTranslationNotFound
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,577 @@
+package translatable
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v3"
+	"github.com/google/uuid"
+	"github.com/nicolasbonnici/gorest-translatable/mocks"
+	"github.com/nicolasbonnici/gorest/database"
+	"github.com/nicolasbonnici/gorest/rbac"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTranslatableHooks_PrepareContent_Trim(t *testing.T) {
+	tests := []struct {
+		name     string
+		trim     *bool
+		raw      string
+		expected string
+		wantErr  bool
+	}{
+		{name: "trims by default", raw: "  Hello  ", expected: "Hello"},
+		{name: "trims when enabled", trim: new(true), raw: "  Hello  ", expected: "Hello"},
+		{name: "preserves whitespace when disabled", trim: new(false), raw: "Hello, ", expected: "Hello, "},
+		{name: "rejects blank content when trimming", raw: "   ", wantErr: true},
+		{name: "rejects blank content when not trimming", trim: new(false), raw: " \t\n", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := Config{MaxContentLength: 100, TrimContent: tt.trim}
+			h := NewTranslatableHooks(nil, &config)
+
+			content, err := h.prepareContent("post", tt.raw)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, content)
+		})
+	}
+}
+
+func TestTranslatableHooks_PrepareContent_Length(t *testing.T) {
+	tests := []struct {
+		name       string
+		countRunes *bool
+		raw        string
+		wantErr    string
+	}{
+		{name: "characters at the limit", raw: strings.Repeat("日", 5)},
+		{name: "characters over the limit", raw: strings.Repeat("é", 6), wantErr: "content exceeds maximum length of 5 characters"},
+		{name: "bytes at the limit", countRunes: new(false), raw: "café"},
+		{name: "bytes over the limit", countRunes: new(false), raw: strings.Repeat("日", 2), wantErr: "content exceeds maximum length of 5 bytes"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := Config{MaxContentLength: 5, CountRunes: tt.countRunes}
+			h := NewTranslatableHooks(nil, &config)
+
+			content, err := h.prepareContent("post", tt.raw)
+
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.raw, content)
+		})
+	}
+}
+
+func TestTranslatableHooks_PrepareContent_Encoding(t *testing.T) {
+	tests := []struct {
+		name      string
+		normalize bool
+		raw       string
+		expected  string
+		wantErr   string
+	}{
+		{name: "invalid byte sequence", raw: "caf\xe9", wantErr: "content must be valid UTF-8"},
+		{name: "truncated sequence", normalize: true, raw: "日本\xe8\xaa", wantErr: "content must be valid UTF-8"},
+		{name: "decomposed kept by default", raw: "cafe\u0301", expected: "cafe\u0301"},
+		{name: "decomposed normalized to NFC", normalize: true, raw: "cafe\u0301", expected: "caf\u00e9"},
+		{name: "composed unchanged", normalize: true, raw: "caf\u00e9", expected: "caf\u00e9"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := DefaultConfig()
+			config.NormalizeUnicode = tt.normalize
+			h := NewTranslatableHooks(nil, &config)
+
+			content, _, err := h.prepareFields("post", tt.raw, nil)
+
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				var problem *ProblemError
+				assert.ErrorAs(t, err, &problem)
+				assert.Equal(t, CodeContentEncoding, problem.Code)
+				assert.Equal(t, fiber.StatusBadRequest, problem.Status)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, content)
+		})
+	}
+}
+
+func TestTranslatableHooks_PrepareContent_Whitespace(t *testing.T) {
+	tests := []struct {
+		name      string
+		typeName  string
+		collapse  bool
+		trimLines bool
+		raw       string
+		expected  string
+	}{
+		{name: "kept by default", typeName: "post", raw: "a  b\t\tc", expected: "a  b\t\tc"},
+		{name: "multiple spaces", typeName: "post", collapse: true, raw: "a   b  c", expected: "a b c"},
+		{name: "tabs", typeName: "post", collapse: true, raw: "a\t\tb \t c", expected: "a b c"},
+		{name: "lines kept when collapsing", typeName: "post", collapse: true, raw: "a  b\n\n c  d", expected: "a b\n\n c d"},
+		{name: "trimmed lines", typeName: "post", trimLines: true, raw: "  a  b \r\n\t c\t\n d", expected: "a  b\nc\nd"},
+		{name: "both", typeName: "post", collapse: true, trimLines: true, raw: " Hello \t world \n  second   line ", expected: "Hello world\nsecond line"},
+		{name: "json type untouched", typeName: "product", collapse: true, trimLines: true, raw: "{\"a\":  \"b  c\"}", expected: "{\"a\":  \"b  c\"}"},
+		{name: "html type untouched", typeName: "article", collapse: true, trimLines: true, raw: "<pre>a  b\n  c</pre>", expected: "<pre>a  b\n  c</pre>"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := DefaultConfig()
+			config.AllowedTypes = []string{"post", "product", "article"}
+			config.TypeValidators = map[string]string{"product": ValidatorJSON, "article": ValidatorHTML}
+			config.CollapseWhitespace = tt.collapse
+			config.TrimLines = tt.trimLines
+			h := NewTranslatableHooks(nil, &config)
+
+			content, _, err := h.prepareFields(tt.typeName, tt.raw, nil)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, content)
+		})
+	}
+}
+
+func TestTranslatableHooks_PrepareContent_TypeValidators(t *testing.T) {
+	tests := []struct {
+		name     string
+		typeName string
+		raw      string
+		expected string
+		wantErr  string
+	}{
+		{name: "json type rejects invalid JSON", typeName: "product", raw: `{bad`, wantErr: "content must be valid JSON"},
+		{name: "json type accepts a document", typeName: "product", raw: `{"a":1}`, expected: `{"a":1}`},
+		{name: "json type is not escaped", typeName: "product", raw: `{"a":"<b>"}`, expected: `{"a":"<b>"}`},
+		{name: "html type keeps allowed markup", typeName: "article", raw: `<b>Hi</b><script>x</script>`, expected: `<b>Hi</b>`},
+		{name: "other types are escaped", typeName: "post", raw: `{bad`, expected: `{bad`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := DefaultConfig()
+			config.AllowedTypes = []string{"post", "product", "article"}
+			config.TypeValidators = map[string]string{"product": ValidatorJSON, "article": ValidatorHTML}
+			if err := config.Validate(); err != nil {
+				t.Fatal(err)
+			}
+			h := NewTranslatableHooks(nil, &config)
+
+			content, _, err := h.prepareFields(tt.typeName, tt.raw, nil)
+
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, content)
+		})
+	}
+}
+
+func TestConfig_Validate_TypeValidators(t *testing.T) {
+	config := DefaultConfig()
+	config.TypeValidators = map[string]string{"post": "xml"}
+	assert.EqualError(t, config.Validate(), "type_validators for post must be one of text, json, html")
+
+	config.TypeValidators = map[string]string{"page": ValidatorJSON}
+	assert.EqualError(t, config.Validate(), "type_validators references unknown type: page")
+}
+
+func TestTranslatableHooks_NormalizeUnicode_Checksum(t *testing.T) {
+	config := DefaultConfig()
+	config.NormalizeUnicode = true
+	h := NewTranslatableHooks(nil, &config)
+
+	composed, err := h.prepareContent("post", "Cr\u00e8me br\u00fbl\u00e9e")
+	assert.NoError(t, err)
+	decomposed, err := h.prepareContent("post", "Cre\u0300me bru\u0302le\u0301e")
+	assert.NoError(t, err)
+
+	assert.Equal(t, composed, decomposed)
+	assert.Equal(t, ContentChecksum(composed), ContentChecksum(decomposed))
+}
+
+func TestApplyCacheControl(t *testing.T) {
+	tests := []struct {
+		name     string
+		target   string
+		header   string
+		expected bool
+	}{
+		{name: "cached by default", target: "/translations", expected: false},
+		{name: "no-cache header", target: "/translations", header: "no-cache", expected: true},
+		{name: "no-cache among directives", target: "/translations", header: "max-age=0, No-Cache", expected: true},
+		{name: "query parameter", target: "/translations?no_cache=true", expected: true},
+		{name: "query parameter false", target: "/translations?no_cache=false", expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := fiber.New()
+			var bypass bool
+			app.Get("/translations", func(c fiber.Ctx) error {
+				applyCacheControl(c)
+				bypass = cacheBypassFromContext(c.Context())
+				return nil
+			})
+
+			req := httptest.NewRequest(fiber.MethodGet, tt.target, nil)
+			if tt.header != "" {
+				req.Header.Set(fiber.HeaderCacheControl, tt.header)
+			}
+			if _, err := app.Test(req); err != nil {
+				t.Fatal(err)
+			}
+			assert.Equal(t, tt.expected, bypass)
+		})
+	}
+}
+
+func TestTranslatableHooks_SourceChecksum(t *testing.T) {
+	entityID := uuid.New()
+	var lookupArgs []interface{}
+	db := &mocks.MockDatabase{
+		QueryRowFunc: func(ctx context.Context, query string, args ...interface{}) database.Row {
+			lookupArgs = args
+			return &mocks.MockRow{ScanFunc: func(dest ...interface{}) error {
+				*dest[0].(*string) = "Hello world\n"
+				return nil
+			}}
+		},
+	}
+	config := DefaultConfig()
+	h := NewTranslatableHooks(db, &config)
+
+	source, err := h.sourceChecksum(context.Background(), &Translatable{Locale: "en", Content: "Hello world"})
+	assert.NoError(t, err)
+	assert.Equal(t, ContentChecksum("Hello world"), *source)
+	assert.Nil(t, lookupArgs)
+
+	translated, err := h.sourceChecksum(context.Background(), &Translatable{Translatable: "post", TranslatableID: entityID, Locale: "fr", Content: "Bonjour"})
+	assert.NoError(t, err)
+	assert.Equal(t, source, translated)
+	assert.Equal(t, []interface{}{"post", entityID, "en"}, lookupArgs[:3])
+	assert.IsType(t, time.Time{}, lookupArgs[3], "expired translations are no source")
+
+	orphan, err := NewTranslatableHooks(&mocks.MockDatabase{}, &config).sourceChecksum(context.Background(), &Translatable{Locale: "fr"})
+	assert.NoError(t, err)
+	assert.Nil(t, orphan)
+
+	failing := &mocks.MockDatabase{
+		QueryRowFunc: func(ctx context.Context, query string, args ...interface{}) database.Row {
+			return &mocks.MockRow{ScanFunc: func(dest ...interface{}) error { return errors.New("connection refused") }}
+		},
+	}
+	unknown, err := NewTranslatableHooks(failing, &config).sourceChecksum(context.Background(), &Translatable{Locale: "fr"})
+	assert.Nil(t, unknown)
+	var fiberErr *fiber.Error
+	if assert.ErrorAs(t, err, &fiberErr) {
+		assert.Equal(t, fiber.StatusInternalServerError, fiberErr.Code)
+	}
+}
+
+func TestTranslatableHooks_ExistingLookupErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		id      string
+		scanErr error
+		status  int
+	}{
+		{name: "missing", id: uuid.NewString(), scanErr: sql.ErrNoRows, status: fiber.StatusNotFound},
+		{name: "malformed id", id: "not-a-uuid", status: fiber.StatusNotFound},
+		{name: "database failure", id: uuid.NewString(), scanErr: errors.New("connection refused"), status: fiber.StatusInternalServerError},
+		{name: "deadline", id: uuid.NewString(), scanErr: context.DeadlineExceeded, status: fiber.StatusGatewayTimeout},
+	}
+
+	for _, tt := range tests {
+		for _, method := range []string{fiber.MethodPut, fiber.MethodDelete} {
+			t.Run(tt.name+" "+method, func(t *testing.T) {
+				executed := false
+				db := &mocks.MockDatabase{
+					QueryRowFunc: func(ctx context.Context, query string, args ...interface{}) database.Row {
+						return &mocks.MockRow{ScanFunc: func(dest ...interface{}) error { return tt.scanErr }}
+					},
+					ExecFunc: func(ctx context.Context, query string, args ...interface{}) (database.Result, error) {
+						executed = true
+						return mocks.NewMockResult(1), nil
+					},
+				}
+				config := DefaultConfig()
+				app, resource := setupTestApp(db, &config)
+				app.Put("/translations/:id", resource.Update)
+				app.Delete("/translations/:id", resource.Delete)
+
+				req := httptest.NewRequest(method, "/translations/"+tt.id, strings.NewReader(`{"locale":"en","content":"Bonjour"}`))
+				req.Header.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+				resp, err := app.Test(req)
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				assert.Equal(t, tt.status, resp.StatusCode)
+				assert.False(t, executed)
+			})
+		}
+	}
+}
+
+func TestTranslatableHooks_WarnOnUntranslated(t *testing.T) {
+	tests := []struct {
+		name    string
+		warn    bool
+		locale  string
+		content string
+		flagged bool
+	}{
+		{name: "same content as the source", warn: true, locale: "fr", content: "Hello", flagged: true},
+		{name: "same content but surrounding whitespace", warn: true, locale: "fr", content: " Hello\n", flagged: true},
+		{name: "translated content", warn: true, locale: "fr", content: "Bonjour"},
+		{name: "source locale", warn: true, locale: "en", content: "Hello"},
+		{name: "disabled", locale: "fr", content: "Hello"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := &mocks.MockDatabase{
+				QueryRowFunc: func(ctx context.Context, query string, args ...interface{}) database.Row {
+					return &mocks.MockRow{ScanFunc: func(dest ...interface{}) error {
+						if strings.HasPrefix(query, "SELECT content ") {
+							*dest[0].(*string) = "Hello"
+						}
+						return nil
+					}}
+				},
+			}
+			config := DefaultConfig()
+			config.WarnOnUntranslated = tt.warn
+			app, resource := setupTestApp(db, &config)
+			app.Post("/translations", resource.Create)
+
+			body := `{"translatableId":"550e8400-e29b-41d4-a716-446655440000","translatable":"post","locale":"` + tt.locale + `","content":` + strconv.Quote(tt.content) + `}`
+			req := httptest.NewRequest(fiber.MethodPost, "/translations", strings.NewReader(body))
+			req.Header.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+			resp, err := app.Test(req)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			assert.Equal(t, fiber.StatusCreated, resp.StatusCode)
+			assert.Equal(t, tt.flagged, resp.Header.Get(HeaderTranslationWarning) == TranslationWarningUntranslated)
+		})
+	}
+}
+
+func TestTranslatableHooks_DefaultLocaleFirst(t *testing.T) {
+	tests := []struct {
+		name       string
+		locale     string
+		query      string
+		hasDefault bool
+		lookupErr  error
+		roles      []string
+		status     int
+	}{
+		{name: "default locale is always accepted", locale: "en", status: fiber.StatusCreated},
+		{name: "missing default locale", locale: "fr", status: fiber.StatusConflict},
+		{name: "default locale lookup fails", locale: "fr", lookupErr: errors.New("connection refused"), status: fiber.StatusInternalServerError},
+		{name: "default locale lookup times out", locale: "fr", lookupErr: context.DeadlineExceeded, status: fiber.StatusGatewayTimeout},
+		{name: "default locale exists", locale: "fr", hasDefault: true, status: fiber.StatusCreated},
+		{name: "force requires admin", locale: "fr", query: "?force=true", roles: []string{"editor"}, status: fiber.StatusForbidden},
+		{name: "admin force", locale: "fr", query: "?force=true", roles: []string{"admin"}, status: fiber.StatusCreated},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := &mocks.MockDatabase{
+				QueryRowFunc: func(ctx context.Context, query string, args ...interface{}) database.Row {
+					if !strings.HasPrefix(query, "SELECT content ") {
+						return &mocks.MockRow{ScanFunc: func(dest ...interface{}) error { return nil }}
+					}
+					if tt.lookupErr != nil {
+						return &mocks.MockRow{ScanFunc: func(dest ...interface{}) error { return tt.lookupErr }}
+					}
+					if !tt.hasDefault {
+						return &mocks.MockRow{}
+					}
+					return &mocks.MockRow{ScanFunc: func(dest ...interface{}) error {
+						*dest[0].(*string) = "Hello"
+						return nil
+					}}
+				},
+			}
+			config := DefaultConfig()
+			config.DefaultLocaleFirst = true
+			app, resource := setupTestApp(db, &config)
+			app.Use(func(c fiber.Ctx) error {
+				c.SetContext(rbac.WithRoles(c.Context(), tt.roles))
+				return c.Next()
+			})
+			app.Post("/translations", resource.Create)
+
+			body := `{"translatableId":"550e8400-e29b-41d4-a716-446655440000","translatable":"post","locale":"` + tt.locale + `","content":"Hello"}`
+			req := httptest.NewRequest(fiber.MethodPost, "/translations"+tt.query, strings.NewReader(body))
+			req.Header.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+			resp, err := app.Test(req)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			assert.Equal(t, tt.status, resp.StatusCode)
+		})
+	}
+}
+
+func TestTranslatableHooks_SkipLocaleValidation(t *testing.T) {
+	tests := []struct {
+		name    string
+		locale  string
+		query   string
+		roles   []string
+		status  int
+		allowed []string
+	}{
+		{name: "unsupported locale", locale: "nl", status: fiber.StatusBadRequest, allowed: []string{"en", "fr", "es"}},
+		{name: "flag ignored for non-admin", locale: "nl", query: "?skip_locale_validation=true", roles: []string{"editor"}, status: fiber.StatusBadRequest, allowed: []string{"en", "fr", "es"}},
+		{name: "admin bypass", locale: "nl", query: "?skip_locale_validation=true", roles: []string{"admin"}, status: fiber.StatusCreated},
+		{name: "admin bypass still rejects malformed tags", locale: "not a locale", query: "?skip_locale_validation=true", roles: []string{"admin"}, status: fiber.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := &mocks.MockDatabase{
+				QueryRowFunc: func(ctx context.Context, query string, args ...interface{}) database.Row {
+					return &mocks.MockRow{ScanFunc: func(dest ...interface{}) error { return nil }}
+				},
+			}
+			config := DefaultConfig()
+			app, resource := setupTestApp(db, &config)
+			app.Use(func(c fiber.Ctx) error {
+				c.SetContext(rbac.WithRoles(c.Context(), tt.roles))
+				return c.Next()
+			})
+			app.Post("/translations", resource.Create)
+
+			body := `{"translatableId":"550e8400-e29b-41d4-a716-446655440000","translatable":"post","locale":"` + tt.locale + `","content":"Hallo"}`
+			req := httptest.NewRequest(fiber.MethodPost, "/translations"+tt.query, strings.NewReader(body))
+			req.Header.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+			resp, err := app.Test(req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			assert.Equal(t, tt.status, resp.StatusCode)
+			if tt.status == fiber.StatusBadRequest {
+				var body ProblemDetails
+				assert.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+				assert.Equal(t, CodeInvalidLocale, body.Code)
+				assert.Equal(t, tt.allowed, body.Allowed)
+			}
+		})
+	}
+}
+
+func TestTranslatableHooks_StrictLocales(t *testing.T) {
+	tests := []struct {
+		name    string
+		strict  *bool
+		locale  string
+		message string
+	}{
+		{name: "malformed tag", locale: "frr-nonsense", message: "locale is not a well-formed BCP 47 tag"},
+		{name: "well-formed unsupported tag", locale: "nl-BE", message: "locale is not supported"},
+		{name: "malformed tag without strict locales", strict: new(false), locale: "frr-nonsense", message: "locale is not supported"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := DefaultConfig()
+			config.StrictLocales = tt.strict
+			app, resource := setupTestApp(&mocks.MockDatabase{}, &config)
+			app.Post("/translations", resource.Create)
+
+			body := `{"translatableId":"550e8400-e29b-41d4-a716-446655440000","translatable":"post","locale":"` + tt.locale + `","content":"Hallo"}`
+			req := httptest.NewRequest(fiber.MethodPost, "/translations", strings.NewReader(body))
+			req.Header.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+			resp, err := app.Test(req)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+			var errBody ProblemDetails
+			assert.NoError(t, json.NewDecoder(resp.Body).Decode(&errBody))
+			assert.Equal(t, tt.message, errBody.Detail)
+			assert.Equal(t, CodeInvalidLocale, errBody.Code)
+		})
+	}
+}
+
+func TestTranslatableHooks_NormalizesLocale(t *testing.T) {
+	for _, locale := range []string{"fr_FR", "fr-fr", "fr-FR"} {
+		t.Run(locale, func(t *testing.T) {
+			var stored []interface{}
+			db := &mocks.MockDatabase{
+				QueryRowFunc: func(ctx context.Context, query string, args ...interface{}) database.Row {
+					return &mocks.MockRow{ScanFunc: func(dest ...interface{}) error { return nil }}
+				},
+				ExecFunc: func(ctx context.Context, query string, args ...interface{}) (database.Result, error) {
+					stored = args
+					return mocks.NewMockResult(1), nil
+				},
+			}
+			config := DefaultConfig()
+			config.SupportedLocales = []string{"en", "fr-FR"}
+			config.CanonicalLocales = true
+			app, resource := setupTestApp(db, &config)
+			app.Post("/translations", resource.Create)
+
+			body := `{"translatableId":"550e8400-e29b-41d4-a716-446655440000","translatable":"post","locale":"` + locale + `","content":"Bonjour"}`
+			req := httptest.NewRequest(fiber.MethodPost, "/translations", strings.NewReader(body))
+			req.Header.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+			resp, err := app.Test(req)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			assert.Equal(t, fiber.StatusCreated, resp.StatusCode)
+			assert.Contains(t, stored, "fr-FR")
+			if locale != "fr-FR" {
+				assert.NotContains(t, stored, locale)
+			}
+		})
+	}
+}
+
+func TestTranslatableHooks_RawContent(t *testing.T) {
+	config := DefaultConfig()
+	h := NewTranslatableHooks(nil, &config)
+	assert.Nil(t, h.rawContent("post", "<b>Hi</b>"))
+
+	config.StoreRawContent = true
+	assert.Equal(t, "<b>Hi</b>", *h.rawContent("post", "  <b>Hi</b> "))
+
+	config.ContentFormat = ContentFormatJSON
+	assert.Nil(t, h.rawContent("post", `{"title":"<b>Hi</b>"}`))
+}
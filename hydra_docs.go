@@ -0,0 +1,86 @@
+package translatable
+
+import (
+	"reflect"
+	"strings"
+
+	"github.com/gofiber/fiber/v3"
+)
+
+const hydraContext = "http://www.w3.org/ns/hydra/context.jsonld"
+
+// wantsHydraDocs reports whether a collection request asks for the API
+// documentation instead of a page of results: JSON-LD requested without any
+// query parameter.
+func wantsHydraDocs(c fiber.Ctx) bool {
+	return strings.Contains(c.Get(fiber.HeaderAccept), "application/ld+json") &&
+		len(c.Request().URI().QueryString()) == 0
+}
+
+// hydraAPIDocumentation describes the translation resource as a Hydra
+// ApiDocumentation so generic Hydra clients can discover its operations.
+func hydraAPIDocumentation(basePath string) fiber.Map {
+	return fiber.Map{
+		"@context":          hydraContext,
+		"@id":               basePath,
+		"@type":             "hydra:ApiDocumentation",
+		"hydra:title":       "Translations API",
+		"hydra:description": "Multi-language content translations",
+		"hydra:entrypoint":  basePath,
+		"hydra:supportedClass": []fiber.Map{
+			{
+				"@id":                     "#Translation",
+				"@type":                   "hydra:Class",
+				"hydra:title":             "Translation",
+				"hydra:supportedProperty": hydraProperties(TranslatableResponseDTO{}),
+				"hydra:supportedOperation": []fiber.Map{
+					hydraOperation(fiber.MethodGet, "Retrieve a translation", "#Translation"),
+					hydraOperation(fiber.MethodPut, "Replace a translation", "#Translation"),
+					hydraOperation(fiber.MethodDelete, "Delete a translation", ""),
+				},
+			},
+			{
+				"@id":         "#TranslationCollection",
+				"@type":       "hydra:Class",
+				"hydra:title": "TranslationCollection",
+				"hydra:supportedOperation": []fiber.Map{
+					hydraOperation(fiber.MethodGet, "List translations", "hydra:Collection"),
+					hydraOperation(fiber.MethodPost, "Create a translation", "#Translation"),
+				},
+			},
+		},
+	}
+}
+
+func hydraOperation(method, title, returns string) fiber.Map {
+	operation := fiber.Map{
+		"@type":        "hydra:Operation",
+		"hydra:method": method,
+		"hydra:title":  title,
+	}
+	if returns != "" {
+		operation["hydra:returns"] = returns
+	}
+	return operation
+}
+
+// hydraProperties lists the JSON fields of a response DTO as supported properties.
+func hydraProperties(dto any) []fiber.Map {
+	t := reflect.TypeOf(dto)
+	properties := make([]fiber.Map, 0, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name, opts, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "" || name == "-" {
+			continue
+		}
+		properties = append(properties, fiber.Map{
+			"@type":           "hydra:SupportedProperty",
+			"hydra:property":  name,
+			"hydra:required":  !strings.Contains(opts, "omitempty") && field.Type.Kind() != reflect.Ptr,
+			"hydra:readable":  true,
+			"hydra:writeable": name != "id" && name != "user_id" && name != "created_at" && name != "updated_at",
+		})
+	}
+	return properties
+}
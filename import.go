@@ -0,0 +1,80 @@
+package translatable
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+)
+
+const (
+	ImportSkipExisting   = "skip-existing"
+	ImportOverwrite      = "overwrite"
+	ImportFailOnConflict = "fail-on-conflict"
+)
+
+var importStrategies = []string{ImportSkipExisting, ImportOverwrite, ImportFailOnConflict}
+
+// ImportReport summarizes a CSV import. Errors lists the rejected rows by their
+// line in the file; when it is not empty nothing was imported.
+type ImportReport struct {
+	Created int              `json:"created"`
+	Updated int              `json:"updated"`
+	Skipped int              `json:"skipped"`
+	Errors  []ImportRowError `json:"errors"`
+}
+
+type ImportRowError struct {
+	Line  int    `json:"line"`
+	Error string `json:"error"`
+}
+
+// ImportFormatError reports a file that is not a CSV in the export layout.
+type ImportFormatError struct {
+	Line    int
+	Message string
+}
+
+func (e *ImportFormatError) Error() string {
+	return fmt.Sprintf("line %d: %s", e.Line, e.Message)
+}
+
+// importRecord is one data row of an import file with its line number.
+type importRecord struct {
+	Line   int
+	Fields []string
+}
+
+// readImportCSV parses an import file laid out like the CSV export. It stops at
+// the first malformed row and reports its line.
+func readImportCSV(r io.Reader) ([]importRecord, error) {
+	in := csv.NewReader(r)
+	in.FieldsPerRecord = len(exportCSVHeader)
+
+	header, err := in.Read()
+	if errors.Is(err, io.EOF) {
+		return nil, &ImportFormatError{Line: 1, Message: "file is empty"}
+	}
+	if err != nil || !slices.Equal(header, exportCSVHeader) {
+		return nil, &ImportFormatError{Line: 1, Message: "header must be " + strings.Join(exportCSVHeader, ",")}
+	}
+
+	var records []importRecord
+	for {
+		fields, err := in.Read()
+		if errors.Is(err, io.EOF) {
+			return records, nil
+		}
+		var parseErr *csv.ParseError
+		if errors.As(err, &parseErr) {
+			return nil, &ImportFormatError{Line: parseErr.StartLine, Message: parseErr.Err.Error()}
+		}
+		if err != nil {
+			return nil, err
+		}
+		line, _ := in.FieldPos(0)
+		records = append(records, importRecord{Line: line, Fields: fields})
+	}
+}
@@ -0,0 +1,183 @@
+package translatable
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"mime/multipart"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v3"
+	"github.com/google/uuid"
+	"github.com/nicolasbonnici/gorest-translatable/mocks"
+	authcontext "github.com/nicolasbonnici/gorest/auth/context"
+	"github.com/nicolasbonnici/gorest/database"
+	"github.com/stretchr/testify/assert"
+)
+
+const importHeader = "translatable_id,translatable,locale,content\n"
+
+func TestReadImportCSV(t *testing.T) {
+	tests := []struct {
+		name    string
+		file    string
+		lines   []int
+		wantErr string
+	}{
+		{
+			name:  "valid file",
+			file:  importHeader + "550e8400-e29b-41d4-a716-446655440000,post,fr,\"Bonjour\nmonde\"\n550e8400-e29b-41d4-a716-446655440000,post,es,Hola\n",
+			lines: []int{2, 4},
+		},
+		{name: "empty file", file: "", wantErr: "line 1: file is empty"},
+		{name: "wrong header", file: "id,locale,content\n", wantErr: "line 1: header must be translatable_id,translatable,locale,content"},
+		{
+			name:    "malformed row",
+			file:    importHeader + "550e8400-e29b-41d4-a716-446655440000,post,fr,Bonjour\n550e8400-e29b-41d4-a716-446655440000,post,es\n",
+			wantErr: "line 3: wrong number of fields",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			records, err := readImportCSV(strings.NewReader(tt.file))
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			lines := make([]int, len(records))
+			for i, record := range records {
+				lines[i] = record.Line
+			}
+			assert.Equal(t, tt.lines, lines)
+		})
+	}
+}
+
+func TestTranslatableResource_Import(t *testing.T) {
+	file := importHeader +
+		"550e8400-e29b-41d4-a716-446655440000,post,fr,Bonjour\n" +
+		"550e8400-e29b-41d4-a716-446655440000,post,es,Hola\n"
+
+	tests := []struct {
+		name       string
+		strategy   string
+		file       string
+		status     int
+		report     ImportReport
+		statements []string
+		committed  bool
+		deleted    bool
+		owned      bool
+	}{
+		{
+			name: "skip existing", strategy: ImportSkipExisting, file: file, status: fiber.StatusOK,
+			report:     ImportReport{Created: 1, Skipped: 1, Errors: []ImportRowError{}},
+			statements: []string{"INSERT"}, committed: true,
+		},
+		{
+			name: "overwrite", strategy: ImportOverwrite, file: file, status: fiber.StatusOK,
+			report:     ImportReport{Created: 1, Updated: 1, Errors: []ImportRowError{}},
+			statements: []string{"UPDATE", "INSERT"}, committed: true, owned: true,
+		},
+		{
+			name: "overwrite another user's translation", strategy: ImportOverwrite, file: file, status: fiber.StatusConflict,
+			report:     ImportReport{Errors: []ImportRowError{{Line: 2, Error: ErrForbidden.Error()}}},
+			statements: []string{"INSERT"},
+		},
+		{
+			name: "fail on conflict", strategy: ImportFailOnConflict, file: file, status: fiber.StatusConflict,
+			report:     ImportReport{Errors: []ImportRowError{{Line: 2, Error: ErrTranslationExists.Error()}}},
+			statements: []string{"INSERT"},
+		},
+		{
+			name: "deleted translation of the importer", strategy: ImportFailOnConflict, file: file, status: fiber.StatusOK,
+			report:     ImportReport{Created: 2, Errors: []ImportRowError{}},
+			statements: []string{"UPDATE", "INSERT"}, committed: true, deleted: true, owned: true,
+		},
+		{
+			name: "deleted translation of another user", strategy: ImportOverwrite, file: file, status: fiber.StatusConflict,
+			report:     ImportReport{Errors: []ImportRowError{{Line: 2, Error: ErrForbidden.Error()}}},
+			statements: []string{"INSERT"}, deleted: true,
+		},
+		{
+			name: "invalid rows", strategy: ImportOverwrite, status: fiber.StatusUnprocessableEntity,
+			file: importHeader + "not-a-uuid,post,fr,Bonjour\n550e8400-e29b-41d4-a716-446655440000,post,xx,Hola\n",
+			report: ImportReport{Errors: []ImportRowError{
+				{Line: 2, Error: "translatable_id must be a valid UUID"},
+				{Line: 3, Error: "locale is not a well-formed BCP 47 tag"},
+			}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var statements []string
+			importer, owner := uuid.New(), uuid.New()
+			if tt.owned {
+				owner = importer
+			}
+			deletedAt := time.Now().Add(-time.Hour)
+			tx := &mocks.MockTx{
+				QueryFunc: func(ctx context.Context, query string, args ...interface{}) (database.Rows, error) {
+					if args[2] != "fr" {
+						return mocks.NewMockRows(0), nil
+					}
+					rows := mocks.NewMockRows(1)
+					rows.ScanFunc = func(row int, dest ...interface{}) error {
+						*dest[0].(*uuid.UUID) = uuid.New()
+						*dest[1].(**uuid.UUID) = &owner
+						*dest[4].(*string) = "fr"
+						if tt.deleted {
+							*dest[14].(**time.Time) = &deletedAt
+						}
+						return nil
+					}
+					return rows, nil
+				},
+				ExecFunc: func(ctx context.Context, query string, args ...interface{}) (database.Result, error) {
+					statement, _, _ := strings.Cut(query, " ")
+					statements = append(statements, statement)
+					return mocks.NewMockResult(1), nil
+				},
+			}
+			db := &mocks.MockDatabase{
+				BeginFunc: func(ctx context.Context) (database.Tx, error) { return tx, nil },
+			}
+			config := DefaultConfig()
+			app, resource := setupTestApp(db, &config)
+			app.Use(func(c fiber.Ctx) error {
+				authcontext.SetUserID(c, importer.String())
+				return c.Next()
+			})
+			app.Post("/translations/import", resource.Import)
+
+			var body bytes.Buffer
+			form := multipart.NewWriter(&body)
+			_ = form.WriteField("strategy", tt.strategy)
+			part, _ := form.CreateFormFile("file", "translations.csv")
+			_, _ = part.Write([]byte(tt.file))
+			_ = form.Close()
+
+			req := httptest.NewRequest(fiber.MethodPost, "/translations/import", &body)
+			req.Header.Set(fiber.HeaderContentType, form.FormDataContentType())
+			resp, err := app.Test(req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			raw, _ := io.ReadAll(resp.Body)
+
+			var report ImportReport
+			assert.Equal(t, tt.status, resp.StatusCode)
+			assert.NoError(t, json.Unmarshal(raw, &report))
+			assert.Equal(t, tt.report, report)
+			assert.Equal(t, tt.statements, statements)
+			assert.Equal(t, tt.committed, tx.Committed)
+		})
+	}
+}
@@ -0,0 +1,63 @@
+package translatable
+
+import (
+	"context"
+	"errors"
+
+	"github.com/gofiber/fiber/v3"
+)
+
+// LifecycleHook is called with a translation around a write.
+type LifecycleHook func(ctx context.Context, t *Translatable) error
+
+// LifecycleHooks are integrator callbacks run around every write of a
+// translation, whichever route makes it. Before hooks see the row about to be
+// written, and may still change it; an error aborts the write with 422, or
+// with the status of a 4xx *fiber.Error. After hooks see the stored row once
+// the write is committed; their errors are only logged.
+type LifecycleHooks struct {
+	BeforeCreate LifecycleHook
+	AfterCreate  LifecycleHook
+	BeforeUpdate LifecycleHook
+	AfterUpdate  LifecycleHook
+	BeforeDelete LifecycleHook
+	AfterDelete  LifecycleHook
+}
+
+// hookRejectedError is the error a before hook aborted a write with. It is
+// answered with its own status rather than as a database failure.
+type hookRejectedError struct {
+	err *fiber.Error
+}
+
+func (e *hookRejectedError) Error() string { return e.err.Error() }
+
+func (e *hookRejectedError) Unwrap() error { return e.err }
+
+// runBeforeHook runs hook, if set, on t and turns its error into one the
+// client is answered with.
+func runBeforeHook(ctx context.Context, hook LifecycleHook, t *Translatable) error {
+	if hook == nil {
+		return nil
+	}
+	err := hook(ctx, t)
+	if err == nil {
+		return nil
+	}
+	var fiberErr *fiber.Error
+	if errors.As(err, &fiberErr) && fiberErr.Code >= 400 && fiberErr.Code < 500 {
+		return &hookRejectedError{err: fiberErr}
+	}
+	return &hookRejectedError{err: fiber.NewError(fiber.StatusUnprocessableEntity, err.Error())}
+}
+
+// runAfterHook runs hook, if set, on t. The write is already done, so errors
+// are logged.
+func runAfterHook(ctx context.Context, name string, hook LifecycleHook, t *Translatable) {
+	if hook == nil {
+		return
+	}
+	if err := hook(ctx, t); err != nil {
+		requestLogger(ctx).Warn("lifecycle hook failed", "hook", name, "id", t.ID, "error", err)
+	}
+}
@@ -0,0 +1,164 @@
+package translatable
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gofiber/fiber/v3"
+	"github.com/google/uuid"
+	"github.com/nicolasbonnici/gorest-translatable/mocks"
+	"github.com/nicolasbonnici/gorest/database"
+	"github.com/stretchr/testify/assert"
+)
+
+// lifecycleDatabase holds no translation and counts the statements written,
+// in or out of a transaction. Once written, the translation reads back from
+// the natural key.
+func lifecycleDatabase(writes *int) *mocks.MockDatabase {
+	db := &mocks.MockDatabase{
+		QueryRowFunc: func(ctx context.Context, query string, args ...interface{}) database.Row {
+			return &mocks.MockRow{ScanFunc: func(dest ...interface{}) error { return sql.ErrNoRows }}
+		},
+		QueryFunc: func(ctx context.Context, query string, args ...interface{}) (database.Rows, error) {
+			if *writes == 0 {
+				return mocks.NewMockRows(0), nil
+			}
+			rows := mocks.NewMockRows(1)
+			rows.ScanFunc = func(row int, dest ...interface{}) error {
+				*dest[0].(*uuid.UUID) = uuid.New()
+				*dest[3].(*string) = "post"
+				*dest[4].(*string) = "fr"
+				*dest[5].(*string) = "Bonjour"
+				return nil
+			}
+			return rows, nil
+		},
+		ExecFunc: func(ctx context.Context, query string, args ...interface{}) (database.Result, error) {
+			*writes++
+			return mocks.NewMockResult(1), nil
+		},
+	}
+	db.BeginFunc = func(ctx context.Context) (database.Tx, error) {
+		return &mocks.MockTx{ExecFunc: db.ExecFunc, QueryFunc: db.QueryFunc, QueryRowFunc: db.QueryRowFunc}, nil
+	}
+	return db
+}
+
+func TestLifecycleHooks_BeforeCreate(t *testing.T) {
+	tests := []struct {
+		name   string
+		method string
+		err    error
+		code   int
+	}{
+		{name: "create rejected", method: fiber.MethodPost, err: errors.New("title is reserved"), code: fiber.StatusUnprocessableEntity},
+		{name: "create rejected with a status", method: fiber.MethodPost, err: fiber.NewError(fiber.StatusBadRequest, "title is reserved"), code: fiber.StatusBadRequest},
+		{name: "server errors become 422", method: fiber.MethodPost, err: fiber.NewError(fiber.StatusInternalServerError, "title is reserved"), code: fiber.StatusUnprocessableEntity},
+		{name: "upsert rejected", method: fiber.MethodPut, err: errors.New("title is reserved"), code: fiber.StatusUnprocessableEntity},
+		{name: "create accepted", method: fiber.MethodPost, code: fiber.StatusCreated},
+		{name: "upsert accepted", method: fiber.MethodPut, code: fiber.StatusCreated},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			writes, calls := 0, 0
+			var seen *Translatable
+			config := DefaultConfig()
+			config.Hooks.BeforeCreate = func(ctx context.Context, t *Translatable) error {
+				seen = t
+				calls++
+				return tt.err
+			}
+			app := fiber.New()
+			RegisterTranslatableRoutes(app, lifecycleDatabase(&writes), &config, nil, nil)
+
+			body := `{"translatableId":"` + uuid.NewString() + `","translatable":"post","locale":"fr","content":"Bonjour"}`
+			req := httptest.NewRequest(tt.method, "/translations", strings.NewReader(body))
+			req.Header.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+			resp, err := app.Test(req)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			assert.Equal(t, tt.code, resp.StatusCode)
+			assert.Equal(t, "Bonjour", seen.Content)
+			assert.Equal(t, 1, calls, "the hook runs once per write")
+			if tt.err == nil {
+				assert.Equal(t, 1, writes)
+				return
+			}
+			assert.Zero(t, writes, "a rejected create is not written")
+			var problem ProblemDetails
+			assert.NoError(t, json.NewDecoder(resp.Body).Decode(&problem))
+			assert.Equal(t, "title is reserved", problem.Detail)
+		})
+	}
+}
+
+func TestLifecycleHooks_AfterCreate(t *testing.T) {
+	writes := 0
+	var persisted []*Translatable
+	config := DefaultConfig()
+	config.Hooks.AfterCreate = func(ctx context.Context, t *Translatable) error {
+		persisted = append(persisted, t)
+		return errors.New("search index unavailable")
+	}
+	app := fiber.New()
+	RegisterTranslatableRoutes(app, lifecycleDatabase(&writes), &config, nil, nil)
+
+	translatableID := uuid.New()
+	body := `{"translatableId":"` + translatableID.String() + `","translatable":"post","locale":"fr","content":"Bonjour"}`
+	req := httptest.NewRequest(fiber.MethodPost, "/translations", strings.NewReader(body))
+	req.Header.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+	req.Header.Set(fiber.HeaderAccept, fiber.MIMEApplicationJSON)
+	resp, err := app.Test(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, fiber.StatusCreated, resp.StatusCode, "after hook errors do not fail the write")
+	var created TranslatableResponseDTO
+	assert.NoError(t, json.NewDecoder(resp.Body).Decode(&created))
+	assert.Equal(t, 1, writes)
+	if assert.Len(t, persisted, 1) {
+		assert.Equal(t, created.ID, persisted[0].ID)
+		assert.Equal(t, translatableID, persisted[0].TranslatableID)
+		assert.Equal(t, "Bonjour", persisted[0].Content)
+		assert.Equal(t, 1, persisted[0].Version)
+	}
+}
+
+func TestLifecycleHooks_BeforeDelete(t *testing.T) {
+	id := uuid.New()
+	writes := 0
+	db := lifecycleDatabase(&writes)
+	db.QueryRowFunc = func(ctx context.Context, query string, args ...interface{}) database.Row {
+		return &mocks.MockRow{ScanFunc: func(dest ...interface{}) error {
+			*dest[0].(*uuid.UUID) = id
+			*dest[5].(*string) = "Bonjour"
+			return nil
+		}}
+	}
+	var deleting *Translatable
+	config := DefaultConfig()
+	config.Hooks.BeforeDelete = func(ctx context.Context, t *Translatable) error {
+		deleting = t
+		return errors.New("translation is referenced")
+	}
+	app := fiber.New()
+	RegisterTranslatableRoutes(app, db, &config, nil, nil)
+
+	resp, err := app.Test(httptest.NewRequest(fiber.MethodDelete, "/translations/"+id.String(), nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, fiber.StatusUnprocessableEntity, resp.StatusCode)
+	assert.Zero(t, writes)
+	assert.Equal(t, "Bonjour", deleting.Content)
+}
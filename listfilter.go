@@ -0,0 +1,46 @@
+package translatable
+
+import (
+	"strings"
+
+	"github.com/gofiber/fiber/v3"
+)
+
+// expandListFilters rewrites comma-separated ?locale=, ?translatable= and
+// ?status= filters, e.g. locale=en,fr, into the locale[]=en&locale[]=fr form the gorest
+// filters turn into IN predicates. Every listed locale must be supported and
+// every listed type allowed.
+func expandListFilters(c fiber.Ctx, config *Config) *AllowedValuesError {
+	args := c.Request().URI().QueryArgs()
+	for _, key := range []string{"locale", "translatable", "status"} {
+		var values []string
+		for _, value := range args.PeekMulti(key) {
+			values = append(values, strings.Split(string(value), ",")...)
+		}
+		if len(values) < 2 {
+			continue
+		}
+
+		for i, value := range values {
+			value = strings.TrimSpace(value)
+			switch key {
+			case "locale":
+				value = config.normalizeLocale(value)
+				if !config.IsSupportedLocale(value) {
+					return errLocaleNotSupported(config)
+				}
+			case "translatable":
+				if !config.IsAllowedType(value) {
+					return errTypeNotAllowed(config)
+				}
+			}
+			values[i] = value
+		}
+
+		args.Del(key)
+		for _, value := range values {
+			args.Add(key+"[]", value)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,125 @@
+package translatable
+
+import (
+	"context"
+	"encoding/json"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v3"
+	"github.com/nicolasbonnici/gorest-translatable/mocks"
+	"github.com/nicolasbonnici/gorest/database"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTranslatableResource_GetAll_ListFilters(t *testing.T) {
+	var selectSQL string
+	var selectArgs []interface{}
+	db := &mocks.MockDatabase{
+		QueryFunc: func(ctx context.Context, query string, args ...interface{}) (database.Rows, error) {
+			selectSQL = query
+			selectArgs = args
+			return mocks.NewMockRows(0), nil
+		},
+		QueryRowFunc: func(ctx context.Context, query string, args ...interface{}) database.Row {
+			return &mocks.MockRow{ScanFunc: func(dest ...interface{}) error {
+				*dest[0].(*int) = 0
+				return nil
+			}}
+		},
+	}
+	config := DefaultConfig()
+	config.AllowedTypes = []string{"posts", "articles"}
+	app, resource := setupTestApp(db, &config)
+	app.Get("/translations", resource.GetAll)
+
+	resp, err := app.Test(httptest.NewRequest(fiber.MethodGet, "/translations?locale=en,fr&translatable=posts,articles", nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+	assert.Contains(t, selectSQL, "locale IN (")
+	assert.Contains(t, selectSQL, "translatable IN (")
+	assert.Equal(t, []interface{}{"en", "fr"}, subsequence(selectArgs, "en", "fr"))
+	assert.Equal(t, []interface{}{"posts", "articles"}, subsequence(selectArgs, "posts", "articles"))
+}
+
+// subsequence returns the members of want found in args, in the order args
+// holds them.
+func subsequence(args []interface{}, want ...interface{}) []interface{} {
+	var found []interface{}
+	for _, arg := range args {
+		for _, w := range want {
+			if arg == w {
+				found = append(found, arg)
+			}
+		}
+	}
+	return found
+}
+
+func TestTranslatableResource_GetAll_ListFilters_Invalid(t *testing.T) {
+	tests := []struct {
+		name  string
+		query string
+		code  string
+	}{
+		{name: "unsupported locale", query: "?locale=en,it", code: CodeInvalidLocale},
+		{name: "type not allowed", query: "?translatable=posts,users", code: CodeInvalidType},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			queried := false
+			db := &mocks.MockDatabase{
+				QueryFunc: func(ctx context.Context, query string, args ...interface{}) (database.Rows, error) {
+					queried = true
+					return mocks.NewMockRows(0), nil
+				},
+			}
+			config := DefaultConfig()
+			config.AllowedTypes = []string{"posts", "articles"}
+			app, resource := setupTestApp(db, &config)
+			app.Get("/translations", resource.GetAll)
+
+			resp, err := app.Test(httptest.NewRequest(fiber.MethodGet, "/translations"+tt.query, nil))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+			assert.False(t, queried)
+			var problem ProblemDetails
+			assert.NoError(t, json.NewDecoder(resp.Body).Decode(&problem))
+			assert.Equal(t, tt.code, problem.Code)
+		})
+	}
+}
+
+func TestTranslatableResource_Count_ListFilters(t *testing.T) {
+	var countSQL string
+	var countArgs []interface{}
+	db := &mocks.MockDatabase{
+		QueryRowFunc: func(ctx context.Context, query string, args ...interface{}) database.Row {
+			countSQL = query
+			countArgs = args
+			return &mocks.MockRow{ScanFunc: func(dest ...interface{}) error {
+				*dest[0].(*int) = 3
+				return nil
+			}}
+		},
+	}
+	config := DefaultConfig()
+	app, resource := setupTestApp(db, &config)
+	app.Get("/translations/count", resource.Count)
+
+	resp, err := app.Test(httptest.NewRequest(fiber.MethodGet, "/translations/count?locale=es,%20fr", nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+	assert.Contains(t, countSQL, "locale IN (")
+	assert.Equal(t, []interface{}{"es", "fr"}, subsequence(countArgs, "es", "fr"))
+}
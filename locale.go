@@ -0,0 +1,145 @@
+package translatable
+
+import (
+	"golang.org/x/text/language"
+	"golang.org/x/text/language/display"
+)
+
+const (
+	DirectionLTR = "ltr"
+	DirectionRTL = "rtl"
+)
+
+// rtlScripts lists the scripts written right to left, and rtlLanguages the
+// languages written in one of them by default.
+var (
+	rtlLanguages = map[string]bool{
+		"ar": true, "arc": true, "ckb": true, "dv": true, "fa": true, "he": true, "iw": true,
+		"ks": true, "ps": true, "sd": true, "ug": true, "ur": true, "yi": true,
+	}
+	rtlScripts = map[string]bool{
+		"Adlm": true, "Arab": true, "Hebr": true, "Nkoo": true, "Rohg": true, "Syrc": true, "Thaa": true,
+	}
+)
+
+// canonicalLocale returns locale as a canonical BCP 47 tag: hyphen-separated,
+// with the registered casing, so EN_us becomes en-US. Deprecated subtags are
+// kept as given.
+func canonicalLocale(locale string) (string, error) {
+	tag, err := language.Raw.Parse(locale)
+	if err != nil {
+		return "", err
+	}
+	return tag.String(), nil
+}
+
+// isWellFormedLocale reports whether locale parses as a BCP 47 language tag,
+// independently of SupportedLocales.
+func isWellFormedLocale(locale string) bool {
+	_, err := canonicalLocale(locale)
+	return err == nil
+}
+
+// strictLocales reports whether locales are held to BCP 47: unless
+// StrictLocales is false.
+func (c *Config) strictLocales() bool {
+	return c.StrictLocales == nil || *c.StrictLocales
+}
+
+// canonicalLocales reports whether locales are stored and looked up in their
+// canonical spelling.
+func (c *Config) canonicalLocales() bool {
+	return c.CanonicalLocales && c.strictLocales()
+}
+
+// normalizeLocale returns the canonical spelling of locale under
+// CanonicalLocales, so fr_FR, fr-fr and fr-FR are stored and looked up as
+// fr-FR. Malformed tags, and every locale without CanonicalLocales, are
+// returned as given.
+func (c *Config) normalizeLocale(locale string) string {
+	if !c.canonicalLocales() {
+		return locale
+	}
+	canonical, err := canonicalLocale(locale)
+	if err != nil {
+		return locale
+	}
+	return canonical
+}
+
+// normalizeFallbackChain spells the locales of FallbackChain like those of
+// SupportedLocales.
+func (c *Config) normalizeFallbackChain() {
+	if !c.canonicalLocales() || len(c.FallbackChain) == 0 {
+		return
+	}
+	chains := make(map[string][]string, len(c.FallbackChain))
+	for locale, chain := range c.FallbackChain {
+		normalized := make([]string, len(chain))
+		for i, fallback := range chain {
+			normalized[i] = c.normalizeLocale(fallback)
+		}
+		chains[c.normalizeLocale(locale)] = normalized
+	}
+	c.FallbackChain = chains
+}
+
+// localeInfo describes a supported locale for the locales endpoints.
+func (c *Config) localeInfo(locale string) LocaleInfo {
+	info := LocaleInfo{Locale: locale, IsDefault: locale == c.DefaultLocale}
+	tag, err := language.Raw.Parse(locale)
+	if err != nil {
+		return info
+	}
+	if _, confidence := tag.Base(); confidence == language.No {
+		return info
+	}
+	info.Direction = localeDirection(tag)
+	info.DisplayName = display.Self.Name(tag)
+	return info
+}
+
+// localeDirection is the writing direction of the script tag is written in,
+// given or likely, falling back to its language when the script is unknown.
+func localeDirection(tag language.Tag) string {
+	if script, confidence := tag.Script(); confidence >= language.High {
+		if rtlScripts[script.String()] {
+			return DirectionRTL
+		}
+		return DirectionLTR
+	}
+	if base, _ := tag.Base(); rtlLanguages[base.String()] {
+		return DirectionRTL
+	}
+	return DirectionLTR
+}
+
+// negotiateLocale picks the supported locale, among those available, that best
+// matches an Accept-Language header, weighing its quality values. It returns
+// DefaultLocale when the header is malformed or nothing matches.
+func (c *Config) negotiateLocale(acceptLanguage string, available map[string]bool) string {
+	preferred, _, err := language.ParseAcceptLanguage(acceptLanguage)
+	if err != nil || len(preferred) == 0 {
+		return c.DefaultLocale
+	}
+
+	var locales []string
+	var tags []language.Tag
+	for _, locale := range c.SupportedLocales {
+		tag, err := language.Parse(locale)
+		if err != nil || !available[locale] {
+			continue
+		}
+		locales = append(locales, locale)
+		tags = append(tags, tag)
+	}
+	if len(tags) == 0 {
+		return c.DefaultLocale
+	}
+
+	_, index, confidence := language.NewMatcher(tags).Match(preferred...)
+	if confidence == language.No {
+		return c.DefaultLocale
+	}
+	return locales[index]
+}
@@ -0,0 +1,37 @@
+package translatable
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCanonicalLocale(t *testing.T) {
+	tests := []struct {
+		locale   string
+		expected string
+	}{
+		{locale: "en", expected: "en"},
+		{locale: "EN-us", expected: "en-US"},
+		{locale: "en_US", expected: "en-US"},
+		{locale: "zh-hant-tw", expected: "zh-Hant-TW"},
+		{locale: "iw", expected: "iw"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.locale, func(t *testing.T) {
+			canonical, err := canonicalLocale(tt.locale)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, canonical)
+		})
+	}
+}
+
+func TestCanonicalLocale_Malformed(t *testing.T) {
+	for _, locale := range []string{"", "en-", "klingon", "frr-nonsense", "xx"} {
+		t.Run(locale, func(t *testing.T) {
+			_, err := canonicalLocale(locale)
+			assert.Error(t, err)
+			assert.False(t, isWellFormedLocale(locale))
+		})
+	}
+}
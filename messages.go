@@ -0,0 +1,147 @@
+package translatable
+
+import (
+	"context"
+	"errors"
+	"maps"
+	"strings"
+
+	"github.com/gofiber/fiber/v3"
+)
+
+// defaultMessages is the built-in catalog of error messages, per locale and
+// message key. A key is an error code, suffixed with a variant when the code
+// has several messages. Placeholders such as {max} are filled from the error.
+var defaultMessages = map[string]map[string]string{
+	"en": {
+		CodeInvalidBody:             "Invalid request body",
+		CodeInvalidType:             "translatable type is not allowed",
+		CodeInvalidLocale:           "locale is not supported",
+		messageKeyMalformedLocale:   "locale is not a well-formed BCP 47 tag",
+		CodeContentEmpty:            "content cannot be empty",
+		CodeContentEncoding:         "content must be valid UTF-8",
+		messageKeyTooManyCharacters: "content exceeds maximum length of {max} characters",
+		messageKeyTooManyBytes:      "content exceeds maximum length of {max} bytes",
+		CodeInvalidField:            "unknown field: {field}",
+		CodeVersionConflict:         "translation was updated by someone else",
+		CodeRateLimited:             "Too many requests",
+		CodeTranslationExists:       "a translation already exists for locale {locale}",
+		CodeInvalidStatus:           "status is not valid",
+		CodeInvalidTransition:       "cannot move a translation from {from} to {to}",
+		messageKeyField:             "field {field}: {message}",
+	},
+	"fr": {
+		CodeInvalidBody:             "Corps de requête invalide",
+		CodeInvalidType:             "ce type de traduction n'est pas autorisé",
+		CodeInvalidLocale:           "cette langue n'est pas prise en charge",
+		messageKeyMalformedLocale:   "la langue n'est pas une étiquette BCP 47 valide",
+		CodeContentEmpty:            "le contenu ne peut pas être vide",
+		CodeContentEncoding:         "le contenu doit être encodé en UTF-8 valide",
+		messageKeyTooManyCharacters: "le contenu dépasse la longueur maximale de {max} caractères",
+		messageKeyTooManyBytes:      "le contenu dépasse la longueur maximale de {max} octets",
+		CodeInvalidField:            "champ inconnu : {field}",
+		CodeVersionConflict:         "la traduction a été modifiée par quelqu'un d'autre",
+		CodeRateLimited:             "Trop de requêtes",
+		CodeTranslationExists:       "une traduction existe déjà pour la langue {locale}",
+		CodeInvalidStatus:           "ce statut n'est pas valide",
+		CodeInvalidTransition:       "impossible de passer une traduction de {from} à {to}",
+		messageKeyField:             "champ {field} : {message}",
+	},
+}
+
+// Message keys of the codes with several messages, and of the prefix of an
+// error about one field of a multi-field content.
+const (
+	messageKeyMalformedLocale   = CodeInvalidLocale + ".malformed"
+	messageKeyTooManyCharacters = CodeContentTooLong + "." + ContentLengthCharacters
+	messageKeyTooManyBytes      = CodeContentTooLong + "." + ContentLengthBytes
+	messageKeyField             = "field"
+)
+
+// messageCatalog returns the built-in catalog with Config.Messages laid over
+// it, message by message.
+func (c *Config) messageCatalog() map[string]map[string]string {
+	catalog := make(map[string]map[string]string, len(defaultMessages)+len(c.Messages))
+	for locale, messages := range defaultMessages {
+		catalog[locale] = maps.Clone(messages)
+	}
+	for locale, messages := range c.Messages {
+		if catalog[locale] == nil {
+			catalog[locale] = make(map[string]string, len(messages))
+		}
+		maps.Copy(catalog[locale], messages)
+	}
+	return catalog
+}
+
+// messageSet holds the error messages of one locale.
+type messageSet map[string]string
+
+// format returns the message of key with params filled in, or fallback when
+// the catalog has none.
+func (m messageSet) format(key string, params map[string]string, fallback string) string {
+	message, ok := m[key]
+	if !ok {
+		return fallback
+	}
+	for name, value := range params {
+		message = strings.ReplaceAll(message, "{"+name+"}", value)
+	}
+	return message
+}
+
+const messagesKey contextKey = "translatable_messages"
+
+// messagesMiddleware selects the language of error messages from the
+// Accept-Language header, among the supported locales the catalog covers,
+// falling back to DefaultLocale.
+func messagesMiddleware(config *Config) fiber.Handler {
+	catalog := config.messageCatalog()
+	available := make(map[string]bool, len(catalog))
+	for locale := range catalog {
+		available[locale] = true
+	}
+	return func(c fiber.Ctx) error {
+		locale := config.negotiateLocale(c.Get(fiber.HeaderAcceptLanguage), available)
+		c.SetContext(context.WithValue(c.Context(), messagesKey, messageSet(catalog[locale])))
+		return c.Next()
+	}
+}
+
+func messagesFromContext(ctx context.Context) messageSet {
+	messages, _ := ctx.Value(messagesKey).(messageSet)
+	return messages
+}
+
+// localizedMessage returns the message of err in the language of the request.
+// Errors without a catalog entry keep their own message.
+func localizedMessage(c fiber.Ctx, err error) string {
+	messages := messagesFromContext(c.Context())
+	var problemErr *ProblemError
+	var allowedErr *AllowedValuesError
+	var conflictErr *VersionConflictError
+	switch {
+	case errors.As(err, &problemErr):
+		return problemErr.localize(messages)
+	case errors.As(err, &allowedErr):
+		return messages.format(allowedErr.Code, allowedErr.Params, allowedErr.Message)
+	case errors.As(err, &conflictErr):
+		return messages.format(CodeVersionConflict, nil, conflictErr.Error())
+	}
+	return err.Error()
+}
+
+func (e *ProblemError) localize(messages messageSet) string {
+	key := e.key
+	if key == "" {
+		key = e.Code
+	}
+	if _, ok := messages[key]; !ok {
+		return e.Message
+	}
+	message := messages.format(key, e.Params, e.Message)
+	if e.field == "" {
+		return message
+	}
+	return messages.format(messageKeyField, map[string]string{"field": e.field, "message": message}, "field "+e.field+": "+message)
+}
@@ -0,0 +1,102 @@
+package translatable
+
+import (
+	"encoding/json"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gofiber/fiber/v3"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLocalizedErrors(t *testing.T) {
+	id := uuid.New().String()
+	tests := []struct {
+		name           string
+		acceptLanguage string
+		messages       map[string]map[string]string
+		body           string
+		detail         string
+		errors         map[string]string
+	}{
+		{
+			name: "english", acceptLanguage: "en-US,en;q=0.9",
+			body:   `{"translatableId":"` + id + `","translatable":"post","locale":"fr","content":"Bonjour tout le monde"}`,
+			detail: "content exceeds maximum length of 5 characters",
+		},
+		{
+			name: "french", acceptLanguage: "fr-FR,fr;q=0.9,en;q=0.5",
+			body:   `{"translatableId":"` + id + `","translatable":"post","locale":"fr","content":"Bonjour tout le monde"}`,
+			detail: "le contenu dépasse la longueur maximale de 5 caractères",
+		},
+		{
+			name:   "no header falls back to the default locale",
+			body:   `{"translatableId":"` + id + `","translatable":"post","locale":"fr","content":"Bonjour tout le monde"}`,
+			detail: "content exceeds maximum length of 5 characters",
+		},
+		{
+			name: "unknown language falls back to the default locale", acceptLanguage: "ja",
+			body:   `{"translatableId":"` + id + `","translatable":"post","locale":"fr","content":"Bonjour tout le monde"}`,
+			detail: "content exceeds maximum length of 5 characters",
+		},
+		{
+			name: "french field errors", acceptLanguage: "fr",
+			body:   `{"translatableId":"` + id + `","translatable":"post","locale":"it","content":" "}`,
+			detail: "content: le contenu ne peut pas être vide; locale: cette langue n'est pas prise en charge",
+			errors: map[string]string{
+				"content": "le contenu ne peut pas être vide",
+				"locale":  "cette langue n'est pas prise en charge",
+			},
+		},
+		{
+			name: "configured message", acceptLanguage: "es",
+			messages: map[string]map[string]string{"es": {CodeContentEmpty: "el contenido no puede estar vacío"}},
+			body:     `{"translatableId":"` + id + `","translatable":"post","locale":"fr","content":" "}`,
+			detail:   "el contenido no puede estar vacío",
+		},
+		{
+			name: "configured override", acceptLanguage: "fr",
+			messages: map[string]map[string]string{"fr": {CodeContentEmpty: "contenu vide"}},
+			body:     `{"translatableId":"` + id + `","translatable":"post","locale":"fr","content":" "}`,
+			detail:   "contenu vide",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := DefaultConfig()
+			config.MaxContentLength = 5
+			config.Messages = tt.messages
+			app, resource := setupTestApp(nil, &config)
+			app.Use(messagesMiddleware(&config))
+			app.Post("/translations", resource.Create)
+
+			req := httptest.NewRequest(fiber.MethodPost, "/translations", strings.NewReader(tt.body))
+			req.Header.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+			if tt.acceptLanguage != "" {
+				req.Header.Set(fiber.HeaderAcceptLanguage, tt.acceptLanguage)
+			}
+			resp, err := app.Test(req)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+			var body ProblemDetails
+			assert.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+			assert.Equal(t, tt.detail, body.Detail)
+			assert.Equal(t, tt.errors, body.Errors)
+		})
+	}
+}
+
+func TestLocalizedErrors_FieldPrefix(t *testing.T) {
+	err := fieldError("title", newProblemError(fiber.StatusBadRequest, CodeContentEmpty, "content cannot be empty"))
+	var problem *ProblemError
+	assert.ErrorAs(t, err, &problem)
+
+	assert.Equal(t, "field title: content cannot be empty", problem.localize(nil))
+	assert.Equal(t, "champ title : le contenu ne peut pas être vide", problem.localize(defaultMessages["fr"]))
+}
@@ -0,0 +1,202 @@
+package translatable
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/nicolasbonnici/gorest/database"
+	"github.com/nicolasbonnici/gorest/hooks"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const (
+	metricsPrefix                 = "translatable_"
+	defaultMetricsRefreshInterval = time.Minute
+)
+
+// metricOperations maps CRUD operations to the operation label they are
+// counted under; both list and single reads count as queries.
+var metricOperations = map[hooks.Operation]string{
+	hooks.OperationCreate:  "create",
+	hooks.OperationUpdate:  "update",
+	hooks.OperationDelete:  "delete",
+	hooks.OperationGetAll:  "query",
+	hooks.OperationGetByID: "query",
+}
+
+// translatableMetrics collects the plugin metrics with the Prometheus client.
+// It only exists with Config.EnableMetrics, so a disabled plugin pays nothing
+// on its request path. It is a prometheus.Collector the host registers in its
+// own registry, or serves with TranslatablePlugin.MetricsHandler behind its own
+// authentication.
+type translatableMetrics struct {
+	operations    *prometheus.CounterVec
+	queryDuration prometheus.Histogram
+	translations  *prometheus.Desc
+	registry      *prometheus.Registry
+
+	// db counts the translations per locale; RegisterTranslatableRoutes sets it.
+	db              database.Database
+	refreshInterval time.Duration
+	table           string
+	tenantScoped    bool
+	localesMu       sync.Mutex
+	localesAt       time.Time
+	locales         []localeTotal
+}
+
+// localeTotal is the number of live translations of a tenant in a locale.
+type localeTotal struct {
+	tenant string
+	locale string
+	count  int
+}
+
+func newTranslatableMetrics(config *Config) *translatableMetrics {
+	labels := []string{"locale"}
+	if config.TenantScoped {
+		labels = append(labels, "tenant")
+	}
+	m := &translatableMetrics{
+		operations: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: metricsPrefix + "operations_total",
+			Help: "Successful CRUD operations by type.",
+		}, []string{"operation"}),
+		queryDuration: prometheus.NewHistogram(prometheus.HistogramOpts{
+			Name:    metricsPrefix + "db_query_duration_seconds",
+			Help:    "Latency of database statements.",
+			Buckets: prometheus.DefBuckets,
+		}),
+		translations:    prometheus.NewDesc(metricsPrefix+"translations", "Live translations by locale.", labels, nil),
+		registry:        prometheus.NewRegistry(),
+		refreshInterval: config.MetricsRefreshInterval,
+		table:           config.table(),
+		tenantScoped:    config.TenantScoped,
+	}
+	for _, op := range metricOperations {
+		m.operations.WithLabelValues(op)
+	}
+	m.registry.MustRegister(m)
+	return m
+}
+
+func (m *translatableMetrics) Describe(ch chan<- *prometheus.Desc) {
+	m.operations.Describe(ch)
+	m.queryDuration.Describe(ch)
+	ch <- m.translations
+}
+
+func (m *translatableMetrics) Collect(ch chan<- prometheus.Metric) {
+	m.operations.Collect(ch)
+	m.queryDuration.Collect(ch)
+	for _, total := range m.localeTotals(context.Background()) {
+		labels := []string{total.locale}
+		if m.tenantScoped {
+			labels = append(labels, total.tenant)
+		}
+		ch <- prometheus.MustNewConstMetric(m.translations, prometheus.GaugeValue, float64(total.count), labels...)
+	}
+}
+
+// handler serves the metrics in the Prometheus exposition format.
+func (m *translatableMetrics) handler() http.Handler {
+	return promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{})
+}
+
+func (m *translatableMetrics) countOperation(operation hooks.Operation) {
+	if m == nil {
+		return
+	}
+	if op, ok := metricOperations[operation]; ok {
+		m.operations.WithLabelValues(op).Inc()
+	}
+}
+
+func (m *translatableMetrics) observeQuery(start time.Time) {
+	m.queryDuration.Observe(time.Since(start).Seconds())
+}
+
+// instrument returns db timing each statement into the query latency
+// histogram, or db itself when metrics are disabled.
+func (m *translatableMetrics) instrument(db database.Database) database.Database {
+	if m == nil || db == nil {
+		return db
+	}
+	return timedDatabase{Database: db, metrics: m}
+}
+
+// timedDatabase times the statements run through it. QueryRow is timed up to
+// the Scan, where drivers actually wait for the row.
+type timedDatabase struct {
+	database.Database
+	metrics *translatableMetrics
+}
+
+func (d timedDatabase) Query(ctx context.Context, query string, args ...interface{}) (database.Rows, error) {
+	defer d.metrics.observeQuery(time.Now())
+	return d.Database.Query(ctx, query, args...)
+}
+
+func (d timedDatabase) QueryRow(ctx context.Context, query string, args ...interface{}) database.Row {
+	return timedRow{Row: d.Database.QueryRow(ctx, query, args...), start: time.Now(), metrics: d.metrics}
+}
+
+func (d timedDatabase) Exec(ctx context.Context, query string, args ...interface{}) (database.Result, error) {
+	defer d.metrics.observeQuery(time.Now())
+	return d.Database.Exec(ctx, query, args...)
+}
+
+type timedRow struct {
+	database.Row
+	start   time.Time
+	metrics *translatableMetrics
+}
+
+func (r timedRow) Scan(dest ...interface{}) error {
+	defer r.metrics.observeQuery(r.start)
+	return r.Row.Scan(dest...)
+}
+
+// localeTotals returns the number of live translations per locale, and per
+// tenant when translations are tenant-scoped, counted again once the previous
+// count is older than the refresh interval.
+func (m *translatableMetrics) localeTotals(ctx context.Context) []localeTotal {
+	m.localesMu.Lock()
+	defer m.localesMu.Unlock()
+	if m.db == nil || (m.locales != nil && time.Since(m.localesAt) < m.refreshInterval) {
+		return m.locales
+	}
+
+	groups := "locale"
+	if m.tenantScoped {
+		groups = "locale, tenant_id"
+	}
+	sql := "SELECT " + groups + ", COUNT(*) FROM " + m.table +
+		" WHERE deleted_at IS NULL AND (expires_at IS NULL OR expires_at > " + m.db.Dialect().Placeholder(1) + ")" +
+		" GROUP BY " + groups
+	rows, err := m.db.Query(ctx, sql, time.Now())
+	if err != nil {
+		requestLogger(ctx).Warn("metrics locale count failed", "error", err)
+		return m.locales
+	}
+	defer func() { _ = rows.Close() }()
+
+	locales := []localeTotal{}
+	for rows.Next() {
+		var total localeTotal
+		dest := []any{&total.locale, &total.count}
+		if m.tenantScoped {
+			dest = []any{&total.locale, &total.tenant, &total.count}
+		}
+		if err := rows.Scan(dest...); err != nil {
+			requestLogger(ctx).Warn("metrics locale count failed", "error", err)
+			return m.locales
+		}
+		locales = append(locales, total)
+	}
+	m.locales, m.localesAt = locales, time.Now()
+	return locales
+}
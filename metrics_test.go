@@ -0,0 +1,124 @@
+package translatable
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gofiber/fiber/v3"
+	"github.com/nicolasbonnici/gorest-translatable/mocks"
+	"github.com/nicolasbonnici/gorest/database"
+	"github.com/stretchr/testify/assert"
+)
+
+// scrapeMetrics returns the metrics served by the handler of config.
+func scrapeMetrics(t *testing.T, config *Config) string {
+	t.Helper()
+	recorder := httptest.NewRecorder()
+	config.metrics.handler().ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	body, _ := io.ReadAll(recorder.Body)
+	return string(body)
+}
+
+func TestMetrics_CreateIncrementsCounter(t *testing.T) {
+	var countSQL string
+	db := &mocks.MockDatabase{
+		QueryRowFunc: func(ctx context.Context, query string, args ...interface{}) database.Row {
+			return &mocks.MockRow{ScanFunc: func(dest ...interface{}) error { return nil }}
+		},
+		ExecFunc: func(ctx context.Context, query string, args ...interface{}) (database.Result, error) {
+			return mocks.NewMockResult(1), nil
+		},
+		QueryFunc: func(ctx context.Context, query string, args ...interface{}) (database.Rows, error) {
+			if !strings.HasPrefix(query, "SELECT locale, COUNT(*)") {
+				return mocks.NewMockRows(0), nil
+			}
+			countSQL = query
+			rows := mocks.NewMockRows(2)
+			locales := []string{"en", "fr\"\\"}
+			rows.ScanFunc = func(row int, dest ...interface{}) error {
+				*dest[0].(*string) = locales[row]
+				*dest[1].(*int) = 3
+				return nil
+			}
+			return rows, nil
+		},
+	}
+	config := DefaultConfig()
+	config.EnableMetrics = true
+	if err := config.Validate(); err != nil {
+		t.Fatal(err)
+	}
+	app := fiber.New()
+	RegisterTranslatableRoutes(app, db, &config, nil, nil)
+
+	req := httptest.NewRequest(fiber.MethodPost, "/translations", strings.NewReader(`{"translatableId":"550e8400-e29b-41d4-a716-446655440000","translatable":"post","locale":"fr","content":"Bonjour"}`))
+	req.Header.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+	resp, err := app.Test(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, fiber.StatusCreated, resp.StatusCode)
+
+	body := scrapeMetrics(t, &config)
+	assert.Contains(t, body, `translatable_operations_total{operation="create"} 1`)
+	assert.Contains(t, body, `translatable_operations_total{operation="update"} 0`)
+	assert.Contains(t, body, `translatable_translations{locale="en"} 3`)
+	assert.Contains(t, body, `translatable_translations{locale="fr\"\\"} 3`, "label values are escaped by the client")
+	assert.NotContains(t, body, `translatable_db_query_duration_seconds_count 0`)
+	assert.Contains(t, countSQL, "expires_at > $1")
+
+	resp, err = app.Test(httptest.NewRequest(fiber.MethodGet, "/translations/metrics", nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	served, _ := io.ReadAll(resp.Body)
+	assert.NotContains(t, string(served), "translatable_operations_total", "the plugin does not mount the metrics")
+}
+
+func TestMetrics_TenantScoped(t *testing.T) {
+	var countSQL string
+	db := &mocks.MockDatabase{
+		QueryFunc: func(ctx context.Context, query string, args ...interface{}) (database.Rows, error) {
+			countSQL = query
+			rows := mocks.NewMockRows(2)
+			tenants := []string{"A", "B"}
+			rows.ScanFunc = func(row int, dest ...interface{}) error {
+				*dest[0].(*string) = "fr"
+				*dest[1].(*string) = tenants[row]
+				*dest[2].(*int) = row + 1
+				return nil
+			}
+			return rows, nil
+		},
+	}
+	config := DefaultConfig()
+	config.EnableMetrics = true
+	config.TenantScoped = true
+	if err := config.Validate(); err != nil {
+		t.Fatal(err)
+	}
+	RegisterTranslatableRoutes(fiber.New(), db, &config, nil, nil)
+
+	body := scrapeMetrics(t, &config)
+	assert.Contains(t, countSQL, "GROUP BY locale, tenant_id")
+	assert.Contains(t, body, `translatable_translations{locale="fr",tenant="A"} 1`)
+	assert.Contains(t, body, `translatable_translations{locale="fr",tenant="B"} 2`)
+}
+
+func TestMetrics_DisabledByDefault(t *testing.T) {
+	config := DefaultConfig()
+	assert.NoError(t, config.Validate())
+	assert.Nil(t, config.metrics)
+
+	db := &mocks.MockDatabase{}
+	assert.Same(t, db, config.metrics.instrument(db))
+
+	plugin := &TranslatablePlugin{config: config}
+	assert.Nil(t, plugin.MetricsCollector())
+	assert.Nil(t, plugin.MetricsHandler())
+}
@@ -2,12 +2,26 @@ package migrations
 
 import (
 	"context"
+	"fmt"
+	"strings"
 
 	"github.com/nicolasbonnici/gorest/database"
 	"github.com/nicolasbonnici/gorest/migrations"
 )
 
-func GetMigrations() migrations.MigrationSource {
+// DefaultTableName is the table GetMigrations creates when given none.
+const DefaultTableName = "translations"
+
+// GetMigrations returns the plugin migrations, creating and altering table
+// (DefaultTableName when empty); index and constraint names are derived from
+// it. Each type in partialIndexTypes
+// gets a partial index on (translatable_id, locale) restricted to that type;
+// the list is read when that migration is applied, so changing it later needs
+// the migration to be rolled back and re-applied.
+func GetMigrations(table string, partialIndexTypes ...string) migrations.MigrationSource {
+	if table == "" {
+		table = DefaultTableName
+	}
 	builder := migrations.NewMigrationBuilder("gorest-translatable")
 
 	builder.Add(
@@ -15,7 +29,7 @@ func GetMigrations() migrations.MigrationSource {
 		"create_translations_table",
 		func(ctx context.Context, db database.Database) error {
 			if err := migrations.SQL(ctx, db, migrations.DialectSQL{
-				Postgres: `CREATE TABLE IF NOT EXISTS translations (
+				Postgres: fmt.Sprintf(`CREATE TABLE IF NOT EXISTS %s (
 					id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
 					user_id UUID REFERENCES users(id) ON DELETE SET NULL,
 					translatable_id UUID NOT NULL,
@@ -25,8 +39,8 @@ func GetMigrations() migrations.MigrationSource {
 					updated_at TIMESTAMP(0) WITH TIME ZONE,
 					created_at TIMESTAMP(0) WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
 					UNIQUE(translatable_id, translatable, locale)
-				)`,
-				MySQL: `CREATE TABLE IF NOT EXISTS translations (
+				)`, table),
+				MySQL: fmt.Sprintf(`CREATE TABLE IF NOT EXISTS %s (
 					id CHAR(36) PRIMARY KEY,
 					user_id CHAR(36),
 					translatable_id CHAR(36) NOT NULL,
@@ -40,8 +54,8 @@ func GetMigrations() migrations.MigrationSource {
 					INDEX idx_translatable_lookup (translatable_id, translatable, locale),
 					INDEX idx_translatable_user (user_id),
 					INDEX idx_translatable_created (created_at DESC)
-				) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci`,
-				SQLite: `CREATE TABLE IF NOT EXISTS translations (
+				) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci`, table),
+				SQLite: fmt.Sprintf(`CREATE TABLE IF NOT EXISTS %s (
 					id TEXT PRIMARY KEY,
 					user_id TEXT REFERENCES users(id) ON DELETE SET NULL,
 					translatable_id TEXT NOT NULL,
@@ -51,7 +65,7 @@ func GetMigrations() migrations.MigrationSource {
 					updated_at TEXT,
 					created_at TEXT NOT NULL DEFAULT (datetime('now')),
 					UNIQUE(translatable_id, translatable, locale)
-				)`,
+				)`, table),
 			}); err != nil {
 				return err
 			}
@@ -59,15 +73,15 @@ func GetMigrations() migrations.MigrationSource {
 			// Create indexes for Postgres and SQLite
 			if db.DriverName() == "postgres" {
 				if err := migrations.SQL(ctx, db, migrations.DialectSQL{
-					Postgres: `CREATE INDEX IF NOT EXISTS idx_translations_lookup ON translations(translatable_id, translatable, locale)`,
+					Postgres: fmt.Sprintf(`CREATE INDEX IF NOT EXISTS idx_%[1]s_lookup ON %[1]s(translatable_id, translatable, locale)`, table),
 				}); err != nil {
 					return err
 				}
-				if err := migrations.CreateIndex(ctx, db, "idx_translations_user", "translations", "user_id"); err != nil {
+				if err := migrations.CreateIndex(ctx, db, "idx_"+table+"_user", table, "user_id"); err != nil {
 					return err
 				}
 				if err := migrations.SQL(ctx, db, migrations.DialectSQL{
-					Postgres: `CREATE INDEX IF NOT EXISTS idx_translations_created ON translations(created_at DESC)`,
+					Postgres: fmt.Sprintf(`CREATE INDEX IF NOT EXISTS idx_%[1]s_created ON %[1]s(created_at DESC)`, table),
 				}); err != nil {
 					return err
 				}
@@ -75,15 +89,15 @@ func GetMigrations() migrations.MigrationSource {
 
 			if db.DriverName() == "sqlite" {
 				if err := migrations.SQL(ctx, db, migrations.DialectSQL{
-					SQLite: `CREATE INDEX IF NOT EXISTS idx_translations_lookup ON translations(translatable_id, translatable, locale)`,
+					SQLite: fmt.Sprintf(`CREATE INDEX IF NOT EXISTS idx_%[1]s_lookup ON %[1]s(translatable_id, translatable, locale)`, table),
 				}); err != nil {
 					return err
 				}
-				if err := migrations.CreateIndex(ctx, db, "idx_translations_user", "translations", "user_id"); err != nil {
+				if err := migrations.CreateIndex(ctx, db, "idx_"+table+"_user", table, "user_id"); err != nil {
 					return err
 				}
 				if err := migrations.SQL(ctx, db, migrations.DialectSQL{
-					SQLite: `CREATE INDEX IF NOT EXISTS idx_translations_created ON translations(created_at DESC)`,
+					SQLite: fmt.Sprintf(`CREATE INDEX IF NOT EXISTS idx_%[1]s_created ON %[1]s(created_at DESC)`, table),
 				}); err != nil {
 					return err
 				}
@@ -94,14 +108,409 @@ func GetMigrations() migrations.MigrationSource {
 		func(ctx context.Context, db database.Database) error {
 			// Drop indexes first
 			if db.DriverName() == "postgres" || db.DriverName() == "sqlite" {
-				_ = migrations.DropIndex(ctx, db, "idx_translations_lookup", "translations")
-				_ = migrations.DropIndex(ctx, db, "idx_translations_user", "translations")
-				_ = migrations.DropIndex(ctx, db, "idx_translations_created", "translations")
+				_ = migrations.DropIndex(ctx, db, "idx_"+table+"_lookup", table)
+				_ = migrations.DropIndex(ctx, db, "idx_"+table+"_user", table)
+				_ = migrations.DropIndex(ctx, db, "idx_"+table+"_created", table)
+			}
+
+			return migrations.DropTableIfExists(ctx, db, table)
+		},
+	)
+
+	builder.Add(
+		"20261016000001000",
+		"add_translations_publish_columns",
+		func(ctx context.Context, db database.Database) error {
+			if db.DriverName() == "sqlite" {
+				if err := migrations.AddColumn(ctx, db, table, "published_content TEXT"); err != nil {
+					return err
+				}
+				return migrations.AddColumn(ctx, db, table, "published_at TEXT")
+			}
+
+			return migrations.SQL(ctx, db, migrations.DialectSQL{
+				Postgres: fmt.Sprintf(`ALTER TABLE %s
+					ADD COLUMN IF NOT EXISTS published_content JSONB,
+					ADD COLUMN IF NOT EXISTS published_at TIMESTAMP(0) WITH TIME ZONE`, table),
+				MySQL: fmt.Sprintf(`ALTER TABLE %s
+					ADD COLUMN published_content JSON NULL,
+					ADD COLUMN published_at TIMESTAMP NULL`, table),
+			})
+		},
+		func(ctx context.Context, db database.Database) error {
+			if err := migrations.DropColumn(ctx, db, table, "published_at"); err != nil {
+				return err
+			}
+			return migrations.DropColumn(ctx, db, table, "published_content")
+		},
+	)
+
+	builder.Add(
+		"20261016000002000",
+		"add_translations_expires_at",
+		func(ctx context.Context, db database.Database) error {
+			if err := migrations.SQL(ctx, db, migrations.DialectSQL{
+				Postgres: fmt.Sprintf(`ALTER TABLE %s ADD COLUMN IF NOT EXISTS expires_at TIMESTAMP(0) WITH TIME ZONE`, table),
+				MySQL:    fmt.Sprintf(`ALTER TABLE %s ADD COLUMN expires_at TIMESTAMP NULL`, table),
+				SQLite:   fmt.Sprintf(`ALTER TABLE %s ADD COLUMN expires_at TEXT`, table),
+			}); err != nil {
+				return err
+			}
+			return migrations.CreateIndex(ctx, db, "idx_"+table+"_expires", table, "expires_at")
+		},
+		func(ctx context.Context, db database.Database) error {
+			_ = migrations.DropIndex(ctx, db, "idx_"+table+"_expires", table)
+			return migrations.DropColumn(ctx, db, table, "expires_at")
+		},
+	)
+
+	builder.Add(
+		"20261016000003000",
+		"create_partial_type_indexes",
+		func(ctx context.Context, db database.Database) error {
+			for _, typeName := range partialIndexTypes {
+				if err := CreatePartialTypeIndex(ctx, db, table, typeName); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+		func(ctx context.Context, db database.Database) error {
+			for _, typeName := range partialIndexTypes {
+				if err := DropPartialTypeIndex(ctx, db, table, typeName); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	)
+
+	builder.Add(
+		"20261016000004000",
+		"add_translations_auto_translated",
+		func(ctx context.Context, db database.Database) error {
+			return migrations.SQL(ctx, db, migrations.DialectSQL{
+				Postgres: fmt.Sprintf(`ALTER TABLE %s ADD COLUMN IF NOT EXISTS auto_translated BOOLEAN NOT NULL DEFAULT FALSE`, table),
+				MySQL:    fmt.Sprintf(`ALTER TABLE %s ADD COLUMN auto_translated BOOLEAN NOT NULL DEFAULT FALSE`, table),
+				SQLite:   fmt.Sprintf(`ALTER TABLE %s ADD COLUMN auto_translated INTEGER NOT NULL DEFAULT 0`, table),
+			})
+		},
+		func(ctx context.Context, db database.Database) error {
+			return migrations.DropColumn(ctx, db, table, "auto_translated")
+		},
+	)
+
+	builder.Add(
+		"20261016000005000",
+		"add_translations_source_checksum",
+		func(ctx context.Context, db database.Database) error {
+			return migrations.SQL(ctx, db, migrations.DialectSQL{
+				Postgres: fmt.Sprintf(`ALTER TABLE %s ADD COLUMN IF NOT EXISTS source_checksum CHAR(64)`, table),
+				MySQL:    fmt.Sprintf(`ALTER TABLE %s ADD COLUMN source_checksum CHAR(64) NULL`, table),
+				SQLite:   fmt.Sprintf(`ALTER TABLE %s ADD COLUMN source_checksum TEXT`, table),
+			})
+		},
+		func(ctx context.Context, db database.Database) error {
+			return migrations.DropColumn(ctx, db, table, "source_checksum")
+		},
+	)
+
+	builder.Add(
+		"20261016000006000",
+		"add_translations_content_raw",
+		func(ctx context.Context, db database.Database) error {
+			return migrations.SQL(ctx, db, migrations.DialectSQL{
+				Postgres: fmt.Sprintf(`ALTER TABLE %s ADD COLUMN IF NOT EXISTS content_raw TEXT`, table),
+				MySQL:    fmt.Sprintf(`ALTER TABLE %s ADD COLUMN content_raw TEXT NULL`, table),
+				SQLite:   fmt.Sprintf(`ALTER TABLE %s ADD COLUMN content_raw TEXT`, table),
+			})
+		},
+		func(ctx context.Context, db database.Database) error {
+			return migrations.DropColumn(ctx, db, table, "content_raw")
+		},
+	)
+
+	builder.Add(
+		"20261016000007000",
+		"add_translations_deleted_at",
+		func(ctx context.Context, db database.Database) error {
+			if err := migrations.SQL(ctx, db, migrations.DialectSQL{
+				Postgres: fmt.Sprintf(`ALTER TABLE %s ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMP(0) WITH TIME ZONE`, table),
+				MySQL:    fmt.Sprintf(`ALTER TABLE %s ADD COLUMN deleted_at TIMESTAMP NULL`, table),
+				SQLite:   fmt.Sprintf(`ALTER TABLE %s ADD COLUMN deleted_at TEXT`, table),
+			}); err != nil {
+				return err
+			}
+			return migrations.CreateIndex(ctx, db, "idx_"+table+"_deleted", table, "deleted_at")
+		},
+		func(ctx context.Context, db database.Database) error {
+			_ = migrations.DropIndex(ctx, db, "idx_"+table+"_deleted", table)
+			return migrations.DropColumn(ctx, db, table, "deleted_at")
+		},
+	)
+
+	builder.Add(
+		"20261016000008000",
+		"add_translations_received_at",
+		func(ctx context.Context, db database.Database) error {
+			return migrations.SQL(ctx, db, migrations.DialectSQL{
+				Postgres: fmt.Sprintf(`ALTER TABLE %s ADD COLUMN IF NOT EXISTS received_at TIMESTAMP(3) WITH TIME ZONE`, table),
+				MySQL:    fmt.Sprintf(`ALTER TABLE %s ADD COLUMN received_at TIMESTAMP(3) NULL`, table),
+				SQLite:   fmt.Sprintf(`ALTER TABLE %s ADD COLUMN received_at TEXT`, table),
+			})
+		},
+		func(ctx context.Context, db database.Database) error {
+			return migrations.DropColumn(ctx, db, table, "received_at")
+		},
+	)
+
+	builder.Add(
+		"20261016000009000",
+		"add_translations_version",
+		func(ctx context.Context, db database.Database) error {
+			return migrations.SQL(ctx, db, migrations.DialectSQL{
+				Postgres: fmt.Sprintf(`ALTER TABLE %s ADD COLUMN IF NOT EXISTS version INTEGER NOT NULL DEFAULT 1`, table),
+				MySQL:    fmt.Sprintf(`ALTER TABLE %s ADD COLUMN version INT NOT NULL DEFAULT 1`, table),
+				SQLite:   fmt.Sprintf(`ALTER TABLE %s ADD COLUMN version INTEGER NOT NULL DEFAULT 1`, table),
+			})
+		},
+		func(ctx context.Context, db database.Database) error {
+			return migrations.DropColumn(ctx, db, table, "version")
+		},
+	)
+
+	builder.Add(
+		"20261016000010000",
+		"add_translations_tenant_id",
+		func(ctx context.Context, db database.Database) error {
+			// Translations are unique per tenant: untenanted installs store the
+			// empty tenant, as NULLs never collide in a unique index.
+			if db.DriverName() == "sqlite" {
+				return rebuildSQLiteTranslations(ctx, db, table, append(sqliteTranslationsColumns, `tenant_id TEXT NOT NULL DEFAULT ''`), "translatable_id, translatable, locale, tenant_id", partialIndexTypes)
+			}
+			if err := migrations.SQL(ctx, db, migrations.DialectSQL{
+				Postgres: fmt.Sprintf(`ALTER TABLE %[1]s
+					ADD COLUMN IF NOT EXISTS tenant_id TEXT NOT NULL DEFAULT '',
+					DROP CONSTRAINT IF EXISTS %[1]s_translatable_id_translatable_locale_key,
+					ADD CONSTRAINT %[1]s_tenant_natural_key UNIQUE (translatable_id, translatable, locale, tenant_id)`, table),
+				MySQL: fmt.Sprintf(`ALTER TABLE %s
+					ADD COLUMN tenant_id VARCHAR(255) NOT NULL DEFAULT '',
+					DROP INDEX unique_translation,
+					ADD UNIQUE KEY unique_translation (translatable_id, translatable, locale, tenant_id)`, table),
+			}); err != nil {
+				return err
+			}
+			return migrations.CreateIndex(ctx, db, "idx_"+table+"_tenant", table, "tenant_id")
+		},
+		func(ctx context.Context, db database.Database) error {
+			if db.DriverName() == "sqlite" {
+				return rebuildSQLiteTranslations(ctx, db, table, sqliteTranslationsColumns, "translatable_id, translatable, locale", partialIndexTypes)
+			}
+			_ = migrations.DropIndex(ctx, db, "idx_"+table+"_tenant", table)
+			return migrations.SQL(ctx, db, migrations.DialectSQL{
+				Postgres: fmt.Sprintf(`ALTER TABLE %[1]s
+					DROP CONSTRAINT IF EXISTS %[1]s_tenant_natural_key,
+					DROP COLUMN IF EXISTS tenant_id,
+					ADD CONSTRAINT %[1]s_translatable_id_translatable_locale_key UNIQUE (translatable_id, translatable, locale)`, table),
+				MySQL: fmt.Sprintf(`ALTER TABLE %s
+					DROP INDEX unique_translation,
+					DROP COLUMN tenant_id,
+					ADD UNIQUE KEY unique_translation (translatable_id, translatable, locale)`, table),
+			})
+		},
+	)
+
+	builder.Add(
+		"20261016000011000",
+		"add_translations_status",
+		func(ctx context.Context, db database.Database) error {
+			if err := migrations.SQL(ctx, db, migrations.DialectSQL{
+				Postgres: fmt.Sprintf(`ALTER TABLE %s ADD COLUMN IF NOT EXISTS status TEXT NOT NULL DEFAULT 'draft'`, table),
+				MySQL:    fmt.Sprintf(`ALTER TABLE %s ADD COLUMN status VARCHAR(16) NOT NULL DEFAULT 'draft'`, table),
+				SQLite:   fmt.Sprintf(`ALTER TABLE %s ADD COLUMN status TEXT NOT NULL DEFAULT 'draft'`, table),
+			}); err != nil {
+				return err
+			}
+			// Translations published before statuses existed stay published.
+			backfill := fmt.Sprintf(`UPDATE %s SET status = 'published' WHERE published_at IS NOT NULL`, table)
+			if err := migrations.SQL(ctx, db, migrations.DialectSQL{Postgres: backfill, MySQL: backfill, SQLite: backfill}); err != nil {
+				return err
 			}
+			return migrations.CreateIndex(ctx, db, "idx_"+table+"_status", table, "status")
+		},
+		func(ctx context.Context, db database.Database) error {
+			_ = migrations.DropIndex(ctx, db, "idx_"+table+"_status", table)
+			return migrations.DropColumn(ctx, db, table, "status")
+		},
+	)
 
-			return migrations.DropTableIfExists(ctx, db, "translations")
+	builder.Add(
+		"20261016000012000",
+		"add_translations_review_columns",
+		func(ctx context.Context, db database.Database) error {
+			if db.DriverName() == "sqlite" {
+				if err := migrations.AddColumn(ctx, db, table, "reviewed_by TEXT"); err != nil {
+					return err
+				}
+				return migrations.AddColumn(ctx, db, table, "reviewed_at TEXT")
+			}
+
+			return migrations.SQL(ctx, db, migrations.DialectSQL{
+				Postgres: fmt.Sprintf(`ALTER TABLE %s
+					ADD COLUMN IF NOT EXISTS reviewed_by UUID,
+					ADD COLUMN IF NOT EXISTS reviewed_at TIMESTAMP(0) WITH TIME ZONE`, table),
+				MySQL: fmt.Sprintf(`ALTER TABLE %s
+					ADD COLUMN reviewed_by CHAR(36) NULL,
+					ADD COLUMN reviewed_at TIMESTAMP NULL`, table),
+			})
+		},
+		func(ctx context.Context, db database.Database) error {
+			if err := migrations.DropColumn(ctx, db, table, "reviewed_at"); err != nil {
+				return err
+			}
+			return migrations.DropColumn(ctx, db, table, "reviewed_by")
+		},
+	)
+
+	builder.Add(
+		"20261016000013000",
+		"add_translations_updated_by",
+		func(ctx context.Context, db database.Database) error {
+			return migrations.SQL(ctx, db, migrations.DialectSQL{
+				Postgres: fmt.Sprintf(`ALTER TABLE %s ADD COLUMN IF NOT EXISTS updated_by UUID`, table),
+				MySQL:    fmt.Sprintf(`ALTER TABLE %s ADD COLUMN updated_by CHAR(36) NULL`, table),
+				SQLite:   fmt.Sprintf(`ALTER TABLE %s ADD COLUMN updated_by TEXT`, table),
+			})
+		},
+		func(ctx context.Context, db database.Database) error {
+			return migrations.DropColumn(ctx, db, table, "updated_by")
 		},
 	)
 
 	return builder.Build()
 }
+
+// sqliteTranslationsColumns are the SQLite columns of the translations table
+// as the migrations before add_translations_tenant_id leave it.
+var sqliteTranslationsColumns = []string{
+	"id TEXT PRIMARY KEY",
+	"user_id TEXT REFERENCES users(id) ON DELETE SET NULL",
+	"translatable_id TEXT NOT NULL",
+	"translatable TEXT NOT NULL",
+	"locale TEXT NOT NULL DEFAULT 'en'",
+	"content TEXT NOT NULL",
+	"updated_at TEXT",
+	"created_at TEXT NOT NULL DEFAULT (datetime('now'))",
+	"published_content TEXT",
+	"published_at TEXT",
+	"expires_at TEXT",
+	"auto_translated INTEGER NOT NULL DEFAULT 0",
+	"source_checksum TEXT",
+	"content_raw TEXT",
+	"deleted_at TEXT",
+	"received_at TEXT",
+	"version INTEGER NOT NULL DEFAULT 1",
+}
+
+// rebuildSQLiteTranslations recreates the translations table with columns and
+// a unique constraint on unique, as SQLite cannot alter a table constraint.
+// Rows are copied over, filling columns the table did not have with their
+// defaults, and the indexes of the previous migrations are recreated.
+func rebuildSQLiteTranslations(ctx context.Context, db database.Database, table string, columns []string, unique string, partialIndexTypes []string) error {
+	existing, err := sqliteColumnNames(ctx, db, table)
+	if err != nil {
+		return err
+	}
+	var copied []string
+	for _, column := range columns {
+		if name, _, _ := strings.Cut(column, " "); existing[name] {
+			copied = append(copied, name)
+		}
+	}
+	names := strings.Join(copied, ", ")
+
+	rebuild := table + "_rebuild"
+	statements := []string{
+		"CREATE TABLE " + rebuild + " (" + strings.Join(columns, ", ") + ", UNIQUE(" + unique + "))",
+		"INSERT INTO " + rebuild + " (" + names + ") SELECT " + names + " FROM " + table,
+		"DROP TABLE " + table,
+		"ALTER TABLE " + rebuild + " RENAME TO " + table,
+		fmt.Sprintf("CREATE INDEX IF NOT EXISTS idx_%[1]s_lookup ON %[1]s(translatable_id, translatable, locale)", table),
+		fmt.Sprintf("CREATE INDEX IF NOT EXISTS idx_%[1]s_user ON %[1]s(user_id)", table),
+		fmt.Sprintf("CREATE INDEX IF NOT EXISTS idx_%[1]s_created ON %[1]s(created_at DESC)", table),
+		fmt.Sprintf("CREATE INDEX IF NOT EXISTS idx_%[1]s_expires ON %[1]s(expires_at)", table),
+		fmt.Sprintf("CREATE INDEX IF NOT EXISTS idx_%[1]s_deleted ON %[1]s(deleted_at)", table),
+	}
+	if strings.Contains(unique, "tenant_id") {
+		statements = append(statements, fmt.Sprintf("CREATE INDEX IF NOT EXISTS idx_%[1]s_tenant ON %[1]s(tenant_id)", table))
+	}
+	for _, statement := range statements {
+		if err := migrations.SQL(ctx, db, migrations.DialectSQL{SQLite: statement}); err != nil {
+			return err
+		}
+	}
+	for _, typeName := range partialIndexTypes {
+		if err := CreatePartialTypeIndex(ctx, db, table, typeName); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// sqliteColumnNames returns the columns the SQLite translations table has.
+func sqliteColumnNames(ctx context.Context, db database.Database, table string) (map[string]bool, error) {
+	rows, err := db.Query(ctx, "SELECT name FROM pragma_table_info('"+table+"')")
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = rows.Close() }()
+
+	names := make(map[string]bool)
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return nil, err
+		}
+		names[name] = true
+	}
+	return names, rows.Err()
+}
+
+// CreatePartialTypeIndex creates an index on (translatable_id, locale) covering
+// only the rows of one type. MySQL has no partial indexes, so it is a no-op there.
+func CreatePartialTypeIndex(ctx context.Context, db database.Database, table, typeName string) error {
+	if db.DriverName() == "mysql" {
+		return nil
+	}
+
+	literal := "'" + strings.ReplaceAll(typeName, "'", "''") + "'"
+	sql := fmt.Sprintf("CREATE INDEX IF NOT EXISTS %s ON %s (translatable_id, locale) WHERE translatable = %s",
+		PartialTypeIndexName(table, typeName), table, literal)
+
+	return migrations.SQL(ctx, db, migrations.DialectSQL{
+		Postgres: sql,
+		SQLite:   sql,
+	})
+}
+
+func DropPartialTypeIndex(ctx context.Context, db database.Database, table, typeName string) error {
+	if db.DriverName() == "mysql" {
+		return nil
+	}
+	return migrations.DropIndex(ctx, db, PartialTypeIndexName(table, typeName), table)
+}
+
+// PartialTypeIndexName derives a safe index identifier from a table and a type
+// name.
+func PartialTypeIndexName(table, typeName string) string {
+	var b strings.Builder
+	b.WriteString("idx_" + table + "_type_")
+	for _, r := range strings.ToLower(typeName) {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') {
+			b.WriteRune(r)
+		} else {
+			b.WriteRune('_')
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,105 @@
+package migrations
+
+import (
+	"context"
+	"testing"
+
+	"github.com/nicolasbonnici/gorest-translatable/mocks"
+	"github.com/nicolasbonnici/gorest/database"
+	"github.com/stretchr/testify/assert"
+)
+
+type recordingDB struct {
+	database.Database
+	driver  string
+	columns []string
+	queries []string
+	reads   []string
+}
+
+func (d *recordingDB) Query(ctx context.Context, query string, args ...interface{}) (database.Rows, error) {
+	d.reads = append(d.reads, query)
+	rows := mocks.NewMockRows(len(d.columns))
+	rows.ScanFunc = func(row int, dest ...interface{}) error {
+		*dest[0].(*string) = d.columns[row]
+		return nil
+	}
+	return rows, nil
+}
+
+func (d *recordingDB) DriverName() string { return d.driver }
+
+func (d *recordingDB) Exec(ctx context.Context, query string, args ...interface{}) (database.Result, error) {
+	d.queries = append(d.queries, query)
+	return nil, nil
+}
+
+func TestPartialTypeIndexName(t *testing.T) {
+	assert.Equal(t, "idx_translations_type_posts", PartialTypeIndexName(DefaultTableName, "posts"))
+	assert.Equal(t, "idx_translations_type_blog_entry_", PartialTypeIndexName(DefaultTableName, "Blog-Entry'"))
+	assert.Equal(t, "idx_i18n_entries_type_posts", PartialTypeIndexName("i18n_entries", "posts"))
+}
+
+func TestCreatePartialTypeIndex(t *testing.T) {
+	db := &recordingDB{driver: "postgres"}
+
+	assert.NoError(t, CreatePartialTypeIndex(context.Background(), db, DefaultTableName, "o'reilly"))
+	assert.NoError(t, DropPartialTypeIndex(context.Background(), db, DefaultTableName, "o'reilly"))
+
+	assert.Equal(t, []string{
+		"CREATE INDEX IF NOT EXISTS idx_translations_type_o_reilly ON translations (translatable_id, locale) WHERE translatable = 'o''reilly'",
+		"DROP INDEX IF EXISTS idx_translations_type_o_reilly",
+	}, db.queries)
+}
+
+func TestCreatePartialTypeIndex_MySQLIsNoop(t *testing.T) {
+	db := &recordingDB{driver: "mysql"}
+
+	assert.NoError(t, CreatePartialTypeIndex(context.Background(), db, DefaultTableName, "posts"))
+	assert.NoError(t, DropPartialTypeIndex(context.Background(), db, DefaultTableName, "posts"))
+	assert.Empty(t, db.queries)
+}
+
+func TestRebuildSQLiteTranslations(t *testing.T) {
+	db := &recordingDB{driver: "sqlite", columns: []string{"id", "translatable_id", "translatable", "locale", "content", "version"}}
+	columns := []string{"id TEXT PRIMARY KEY", "translatable_id TEXT NOT NULL", "translatable TEXT NOT NULL", "locale TEXT NOT NULL", "content TEXT NOT NULL", "version INTEGER NOT NULL DEFAULT 1", "tenant_id TEXT NOT NULL DEFAULT ''"}
+
+	assert.NoError(t, rebuildSQLiteTranslations(context.Background(), db, DefaultTableName, columns, "translatable_id, translatable, locale, tenant_id", []string{"posts"}))
+
+	assert.Equal(t, []string{
+		"CREATE TABLE translations_rebuild (id TEXT PRIMARY KEY, translatable_id TEXT NOT NULL, translatable TEXT NOT NULL, locale TEXT NOT NULL, content TEXT NOT NULL, version INTEGER NOT NULL DEFAULT 1, tenant_id TEXT NOT NULL DEFAULT '', UNIQUE(translatable_id, translatable, locale, tenant_id))",
+		"INSERT INTO translations_rebuild (id, translatable_id, translatable, locale, content, version) SELECT id, translatable_id, translatable, locale, content, version FROM translations",
+		"DROP TABLE translations",
+		"ALTER TABLE translations_rebuild RENAME TO translations",
+		"CREATE INDEX IF NOT EXISTS idx_translations_lookup ON translations(translatable_id, translatable, locale)",
+		"CREATE INDEX IF NOT EXISTS idx_translations_user ON translations(user_id)",
+		"CREATE INDEX IF NOT EXISTS idx_translations_created ON translations(created_at DESC)",
+		"CREATE INDEX IF NOT EXISTS idx_translations_expires ON translations(expires_at)",
+		"CREATE INDEX IF NOT EXISTS idx_translations_deleted ON translations(deleted_at)",
+		"CREATE INDEX IF NOT EXISTS idx_translations_tenant ON translations(tenant_id)",
+		"CREATE INDEX IF NOT EXISTS idx_translations_type_posts ON translations (translatable_id, locale) WHERE translatable = 'posts'",
+	}, db.queries)
+}
+
+func TestGetMigrations_TableName(t *testing.T) {
+	for _, driver := range []string{"postgres", "mysql", "sqlite"} {
+		t.Run(driver, func(t *testing.T) {
+			db := &recordingDB{driver: driver}
+			source, err := GetMigrations("i18n_entries", "posts").Migrations()
+			assert.NoError(t, err)
+
+			for _, migration := range source {
+				assert.NoError(t, migration.Executor.Up(context.Background(), db), migration.Name)
+			}
+			for i := len(source) - 1; i >= 0; i-- {
+				assert.NoError(t, source[i].Executor.Down(context.Background(), db), source[i].Name)
+			}
+
+			assert.NotEmpty(t, db.queries)
+			for _, query := range append(db.queries, db.reads...) {
+				assert.NotContains(t, query, "translations")
+			}
+			assert.Contains(t, db.queries[0], "CREATE TABLE IF NOT EXISTS i18n_entries")
+		})
+	}
+}
@@ -2,7 +2,10 @@ package mocks
 
 import (
 	"context"
+	"database/sql"
 	"errors"
+	"fmt"
+	"strings"
 
 	"github.com/nicolasbonnici/gorest/database"
 )
@@ -11,6 +14,9 @@ type MockDatabase struct {
 	ExecFunc     func(ctx context.Context, query string, args ...interface{}) (database.Result, error)
 	QueryFunc    func(ctx context.Context, query string, args ...interface{}) (database.Rows, error)
 	QueryRowFunc func(ctx context.Context, query string, args ...interface{}) database.Row
+	BeginFunc    func(ctx context.Context) (database.Tx, error)
+	// SQLDialect, when set, replaces MockDialect, e.g. with a QuotingDialect.
+	SQLDialect database.Dialect
 }
 
 func (m *MockDatabase) Exec(ctx context.Context, query string, args ...interface{}) (database.Result, error) {
@@ -38,12 +44,39 @@ func (m *MockDatabase) Connect(ctx context.Context, dsn string) error { return n
 func (m *MockDatabase) Close() error                                  { return nil }
 func (m *MockDatabase) Ping(ctx context.Context) error                { return nil }
 func (m *MockDatabase) Begin(ctx context.Context) (database.Tx, error) {
+	if m.BeginFunc != nil {
+		return m.BeginFunc(ctx)
+	}
 	return nil, errors.New("not implemented")
 }
-func (m *MockDatabase) Dialect() database.Dialect                 { return nil }
+func (m *MockDatabase) Dialect() database.Dialect {
+	if m.SQLDialect != nil {
+		return m.SQLDialect
+	}
+	return &MockDialect{}
+}
 func (m *MockDatabase) DriverName() string                        { return "mock" }
 func (m *MockDatabase) Introspector() database.SchemaIntrospector { return nil }
 
+// MockDialect renders Postgres-style numbered placeholders so generated SQL can be asserted.
+type MockDialect struct {
+	database.BaseDialect
+}
+
+func (d *MockDialect) Placeholder(n int) string {
+	return fmt.Sprintf("$%d", n)
+}
+
+// QuotingDialect is a MockDialect quoting identifiers in double quotes, as the
+// Postgres and SQLite dialects do.
+type QuotingDialect struct {
+	MockDialect
+}
+
+func (d *QuotingDialect) QuoteIdentifier(name string) string {
+	return `"` + strings.ReplaceAll(name, `"`, `""`) + `"`
+}
+
 type MockResult struct {
 	rowsAffected int64
 	lastInsertId int64
@@ -70,10 +103,13 @@ func (m *MockRow) Scan(dest ...interface{}) error {
 	if m.ScanFunc != nil {
 		return m.ScanFunc(dest...)
 	}
-	return errors.New("no rows")
+	return sql.ErrNoRows
 }
 
 type MockRows struct {
+	// ScanFunc fills dest for the current row, numbered from 0.
+	ScanFunc func(row int, dest ...interface{}) error
+
 	closed    bool
 	closeErr  error
 	scanErr   error
@@ -97,6 +133,9 @@ func (m *MockRows) Scan(dest ...interface{}) error {
 	if m.scanErr != nil {
 		return m.scanErr
 	}
+	if m.ScanFunc != nil {
+		return m.ScanFunc(m.nextCount-1, dest...)
+	}
 	return nil
 }
 
@@ -108,3 +147,46 @@ func (m *MockRows) Close() error {
 func (m *MockRows) Err() error {
 	return nil
 }
+
+// MockTx delegates to its Func fields and records how the transaction ended.
+type MockTx struct {
+	ExecFunc     func(ctx context.Context, query string, args ...interface{}) (database.Result, error)
+	QueryFunc    func(ctx context.Context, query string, args ...interface{}) (database.Rows, error)
+	QueryRowFunc func(ctx context.Context, query string, args ...interface{}) database.Row
+
+	Committed  bool
+	RolledBack bool
+}
+
+func (m *MockTx) Exec(ctx context.Context, query string, args ...interface{}) (database.Result, error) {
+	if m.ExecFunc != nil {
+		return m.ExecFunc(ctx, query, args...)
+	}
+	return &MockResult{rowsAffected: 1}, nil
+}
+
+func (m *MockTx) Query(ctx context.Context, query string, args ...interface{}) (database.Rows, error) {
+	if m.QueryFunc != nil {
+		return m.QueryFunc(ctx, query, args...)
+	}
+	return &MockRows{}, nil
+}
+
+func (m *MockTx) QueryRow(ctx context.Context, query string, args ...interface{}) database.Row {
+	if m.QueryRowFunc != nil {
+		return m.QueryRowFunc(ctx, query, args...)
+	}
+	return &MockRow{}
+}
+
+func (m *MockTx) Commit(ctx context.Context) error {
+	m.Committed = true
+	return nil
+}
+
+func (m *MockTx) Rollback(ctx context.Context) error {
+	if !m.Committed {
+		m.RolledBack = true
+	}
+	return nil
+}
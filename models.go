@@ -1,6 +1,10 @@
 package translatable
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
@@ -13,20 +17,144 @@ type Translatable struct {
 	Translatable   string     `json:"translatable" db:"translatable"`
 	Locale         string     `json:"locale" db:"locale"`
 	Content        string     `json:"content" db:"content"`
-	UpdatedAt      *time.Time `json:"updated_at,omitempty" db:"updated_at"`
-	CreatedAt      time.Time  `json:"created_at" db:"created_at"`
+	// Fields holds the decoded content of types listed in Config.FieldKeys.
+	Fields           map[string]string `json:"fields,omitempty" db:"-"`
+	PublishedContent *string           `json:"-" db:"published_content"`
+	PublishedAt      *time.Time        `json:"published_at,omitempty" db:"published_at"`
+	ExpiresAt        *time.Time        `json:"expires_at,omitempty" db:"expires_at"`
+	AutoTranslated   bool              `json:"auto_translated" db:"auto_translated"`
+	SourceChecksum   *string           `json:"source_checksum,omitempty" db:"source_checksum"`
+	ContentRaw       *string           `json:"-" db:"content_raw"`
+	UpdatedAt        *time.Time        `json:"updated_at,omitempty" db:"updated_at"`
+	CreatedAt        time.Time         `json:"created_at" db:"created_at"`
+	DeletedAt        *time.Time        `json:"-" db:"deleted_at"`
+	ReceivedAt       *time.Time        `json:"received_at,omitempty" db:"received_at"`
+	// Version counts the content writes of the translation, starting at 1.
+	Version int `json:"version" db:"version"`
+	// TenantID isolates translations per tenant with Config.TenantScoped.
+	TenantID *string `json:"-" db:"tenant_id"`
+	// Status is the editorial status: draft, review or published.
+	Status string `json:"status" db:"status"`
+	// ReviewedBy approved the live version, at ReviewedAt. Publishing
+	// without approval clears them.
+	ReviewedBy *uuid.UUID `json:"reviewed_by,omitempty" db:"reviewed_by"`
+	ReviewedAt *time.Time `json:"reviewed_at,omitempty" db:"reviewed_at"`
+	// Entity holds metadata from Config.EntityMetadataResolver on expanded reads.
+	Entity json.RawMessage `json:"entity,omitempty" db:"-"`
+	// User is the author, from the users table, on reads with ?include=user.
+	User *UserSummary `json:"user,omitempty" db:"-"`
 }
 
+// TrashedTranslation is a soft-deleted translation as the trash lists it.
+// Content is nil when Config.TrashHidesContent is set.
+type TrashedTranslation struct {
+	ID           uuid.UUID
+	Translatable string
+	Locale       string
+	Content      *string
+	DeletedAt    time.Time
+	// UpdatedBy deleted the translation, nil when it was deleted anonymously.
+	UpdatedBy *uuid.UUID
+}
+
+// translatableColumns lists the translations columns in the order expected by scanFields.
+const translatableColumns = "id, user_id, translatable_id, translatable, locale, content, published_content, published_at, expires_at, auto_translated, source_checksum, content_raw, updated_at, created_at, deleted_at, received_at, version, tenant_id, status, reviewed_by, reviewed_at"
+
+// TableName is Config.TableName of the last processor built, DefaultTableName
+// before any.
 func (Translatable) TableName() string {
-	return "translations"
+	if table := crudTable.Load(); table != nil {
+		return *table
+	}
+	return DefaultTableName
+}
+
+func (t *Translatable) scanFields() []any {
+	return []any{
+		&t.ID,
+		&t.UserID,
+		&t.TranslatableID,
+		&t.Translatable,
+		&t.Locale,
+		&t.Content,
+		&t.PublishedContent,
+		&t.PublishedAt,
+		&t.ExpiresAt,
+		&t.AutoTranslated,
+		&t.SourceChecksum,
+		&t.ContentRaw,
+		&t.UpdatedAt,
+		&t.CreatedAt,
+		&t.DeletedAt,
+		&t.ReceivedAt,
+		&t.Version,
+		&t.TenantID,
+		&t.Status,
+		&t.ReviewedBy,
+		&t.ReviewedAt,
+	}
+}
+
+// columnValues returns the field values in translatableColumns order.
+func (t *Translatable) columnValues() []any {
+	return []any{
+		t.ID,
+		t.UserID,
+		t.TranslatableID,
+		t.Translatable,
+		t.Locale,
+		t.Content,
+		t.PublishedContent,
+		t.PublishedAt,
+		t.ExpiresAt,
+		t.AutoTranslated,
+		t.SourceChecksum,
+		t.ContentRaw,
+		t.UpdatedAt,
+		t.CreatedAt,
+		t.DeletedAt,
+		t.ReceivedAt,
+		t.Version,
+		t.TenantID,
+		t.Status,
+		t.ReviewedBy,
+		t.ReviewedAt,
+	}
+}
+
+// isLive reports whether t is served by reads at now: neither soft-deleted nor
+// expired. Other rows still hold their entity, type and locale key.
+func (t *Translatable) isLive(now time.Time) bool {
+	return t.DeletedAt == nil && (t.ExpiresAt == nil || t.ExpiresAt.After(now))
+}
+
+// ContentChecksum returns the hex SHA-256 of content, ignoring surrounding
+// whitespace so that trimming never makes a translation look stale.
+func ContentChecksum(content string) string {
+	sum := sha256.Sum256([]byte(strings.TrimSpace(content)))
+	return hex.EncodeToString(sum[:])
 }
 
 type LocaleInfo struct {
 	Locale    string `json:"locale"`
 	IsDefault bool   `json:"is_default"`
+	// Direction and DisplayName are left out for locales that are not BCP 47
+	// tags. DisplayName is the locale's name in its own language.
+	Direction   string `json:"direction,omitempty"`
+	DisplayName string `json:"display_name,omitempty"`
 }
 
 type LocalesResponse struct {
 	Default string       `json:"default"`
 	Locales []LocaleInfo `json:"locales"`
+	Total   int          `json:"total"`
+}
+
+// CompletenessReport splits the supported locales by whether an entity has a
+// live translation in them. Percentage is the translated share, out of 100.
+type CompletenessReport struct {
+	Translated []LocaleInfo `json:"translated"`
+	Missing    []LocaleInfo `json:"missing"`
+	Total      int          `json:"total"`
+	Percentage float64      `json:"percentage"`
 }
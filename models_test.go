@@ -10,3 +10,19 @@ func TestTranslatableTableName(t *testing.T) {
 		t.Errorf("TableName() = %v, want 'translations'", translatable.TableName())
 	}
 }
+
+func TestContentChecksum(t *testing.T) {
+	base := ContentChecksum("Hello world")
+
+	if len(base) != 64 {
+		t.Fatalf("ContentChecksum() length = %d, want 64", len(base))
+	}
+	for _, variant := range []string{"Hello world ", "Hello world\n", "  Hello world\t"} {
+		if got := ContentChecksum(variant); got != base {
+			t.Errorf("ContentChecksum(%q) = %s, want %s", variant, got, base)
+		}
+	}
+	if ContentChecksum("Hello  world") == base {
+		t.Error("ContentChecksum() should differ when inner content differs")
+	}
+}
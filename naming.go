@@ -0,0 +1,208 @@
+package translatable
+
+import (
+	"bytes"
+	"encoding/json"
+	"mime"
+	"reflect"
+	"strings"
+	"unicode"
+
+	"github.com/gofiber/fiber/v3"
+)
+
+// JSON key styles of Config.JSONNaming.
+const (
+	JSONNamingSnake = "snake"
+	JSONNamingCamel = "camel"
+)
+
+// requestBodyTypes are the bodies the plugin parses. Their tags are the keys
+// it reads, whatever the naming.
+var requestBodyTypes = []reflect.Type{
+	reflect.TypeFor[TranslatableCreateDTO](),
+	reflect.TypeFor[TranslatableUpdateDTO](),
+	reflect.TypeFor[TranslatablePatchDTO](),
+	reflect.TypeFor[CloneEntityDTO](),
+	reflect.TypeFor[CopyLocaleDTO](),
+	reflect.TypeFor[ReplaceLocalesDTO](),
+	reflect.TypeFor[BatchGetDTO](),
+}
+
+// responseBodyTypes are the bodies the plugin writes. Request tags are renamed
+// too, as they key the invalid fields of validation errors.
+var responseBodyTypes = append([]reflect.Type{
+	reflect.TypeFor[TranslatableResponseDTO](),
+	reflect.TypeFor[CountResponseDTO](),
+	reflect.TypeFor[BatchGetResponseDTO](),
+	reflect.TypeFor[SnapshotResponseDTO](),
+	reflect.TypeFor[EntityLocalesResponse](),
+	reflect.TypeFor[CopyLocaleResponse](),
+	reflect.TypeFor[ValidationResultDTO](),
+	reflect.TypeFor[CapabilitiesResponse](),
+	reflect.TypeFor[LocalesResponse](),
+	reflect.TypeFor[LocaleInfo](),
+	reflect.TypeFor[CompletenessReport](),
+	reflect.TypeFor[FanOutResult](),
+	reflect.TypeFor[FanOutFailure](),
+	reflect.TypeFor[ImportReport](),
+	reflect.TypeFor[ImportRowError](),
+	reflect.TypeFor[ProblemDetails](),
+	reflect.TypeFor[ErrorResponse](),
+	reflect.TypeFor[paginationMeta](),
+}, requestBodyTypes...)
+
+// opaqueJSONKeys hold client data, such as field names or locales, whose keys
+// are never renamed.
+var opaqueJSONKeys = map[string]bool{
+	"content":      true,
+	"fields":       true,
+	"translations": true,
+}
+
+// jsonTags lists the keys types are encoded with.
+func jsonTags(types []reflect.Type) []string {
+	var tags []string
+	for _, t := range types {
+		for i := range t.NumField() {
+			tag, _, _ := strings.Cut(t.Field(i).Tag.Get("json"), ",")
+			if tag != "" && tag != "-" {
+				tags = append(tags, tag)
+			}
+		}
+	}
+	return tags
+}
+
+// jsonName spells key in naming.
+func jsonName(key, naming string) string {
+	var name strings.Builder
+	upper := false
+	for i, r := range key {
+		switch {
+		case naming == JSONNamingCamel && r == '_':
+			upper = true
+		case naming == JSONNamingCamel && upper:
+			name.WriteRune(unicode.ToUpper(r))
+			upper = false
+		case naming == JSONNamingSnake && unicode.IsUpper(r):
+			if i > 0 {
+				name.WriteByte('_')
+			}
+			name.WriteRune(unicode.ToLower(r))
+		default:
+			name.WriteRune(r)
+		}
+	}
+	return name.String()
+}
+
+// jsonNamingMiddleware applies Config.JSONNaming to the bodies of the routes:
+// keys of request bodies spelled in the naming are read as the plugin's own,
+// which stay accepted, and the keys of JSON responses, errors included, are
+// spelled in the naming.
+func jsonNamingMiddleware(config *Config) fiber.Handler {
+	naming := config.JSONNaming
+	requestKeys := make(map[string]string)
+	for _, tag := range jsonTags(requestBodyTypes) {
+		if name := jsonName(tag, naming); name != tag {
+			requestKeys[name] = tag
+		}
+	}
+	responseKeys := make(map[string]string)
+	for _, tag := range jsonTags(responseBodyTypes) {
+		responseKeys[tag] = jsonName(tag, naming)
+	}
+
+	return func(c fiber.Ctx) error {
+		if isJSONMediaType(c.Get(fiber.HeaderContentType)) && len(c.Body()) > 0 {
+			// Bodies that are not valid JSON are left to the parser to reject.
+			if body, err := renameJSONKeys(c.Body(), requestKeys); err == nil {
+				c.Request().SetBody(body)
+			}
+		}
+
+		if err := c.Next(); err != nil {
+			return err
+		}
+
+		response := c.Response()
+		if response.IsBodyStream() || !isJSONMediaType(string(response.Header.ContentType())) {
+			return nil
+		}
+		body, err := renameJSONKeys(response.Body(), responseKeys)
+		if err != nil {
+			requestLogger(c.Context()).Warn("response body cannot be renamed", "error", err)
+			return nil
+		}
+		response.SetBody(body)
+		return nil
+	}
+}
+
+func isJSONMediaType(contentType string) bool {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	return err == nil && (mediaType == fiber.MIMEApplicationJSON || strings.HasSuffix(mediaType, "+json"))
+}
+
+// renameJSONKeys rewrites the object keys found in names throughout data,
+// keeping their order and the bytes of every value.
+func renameJSONKeys(data []byte, names map[string]string) ([]byte, error) {
+	data = bytes.TrimSpace(data)
+	if len(data) == 0 || (data[0] != '{' && data[0] != '[') {
+		return data, nil
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	open, err := decoder.Token()
+	if err != nil {
+		return nil, err
+	}
+	object := open == json.Delim('{')
+
+	var out bytes.Buffer
+	out.WriteByte(data[0])
+	for i := 0; decoder.More(); i++ {
+		if i > 0 {
+			out.WriteByte(',')
+		}
+		opaque := false
+		if object {
+			token, err := decoder.Token()
+			if err != nil {
+				return nil, err
+			}
+			key := token.(string)
+			opaque = opaqueJSONKeys[key]
+			if name, ok := names[key]; ok {
+				key = name
+			}
+			encoded, err := json.Marshal(key)
+			if err != nil {
+				return nil, err
+			}
+			out.Write(encoded)
+			out.WriteByte(':')
+		}
+
+		var value json.RawMessage
+		if err := decoder.Decode(&value); err != nil {
+			return nil, err
+		}
+		if !opaque {
+			if value, err = renameJSONKeys(value, names); err != nil {
+				return nil, err
+			}
+		}
+		out.Write(value)
+	}
+	if _, err := decoder.Token(); err != nil {
+		return nil, err
+	}
+	if object {
+		out.WriteByte('}')
+	} else {
+		out.WriteByte(']')
+	}
+	return out.Bytes(), nil
+}
@@ -0,0 +1,87 @@
+package translatable
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gofiber/fiber/v3"
+	"github.com/google/uuid"
+	"github.com/nicolasbonnici/gorest-translatable/mocks"
+	"github.com/nicolasbonnici/gorest/database"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestJSONNaming(t *testing.T) {
+	tests := []struct {
+		name      string
+		naming    string
+		createKey string
+		keys      []string
+	}{
+		{name: "default", createKey: "translatableId", keys: []string{"auto_translated", "created_at", "request_id"}},
+		{name: "snake", naming: JSONNamingSnake, createKey: "translatable_id", keys: []string{"auto_translated", "created_at", "request_id"}},
+		{name: "camel", naming: JSONNamingCamel, createKey: "translatableId", keys: []string{"autoTranslated", "createdAt", "requestId"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := &mocks.MockDatabase{
+				QueryRowFunc: func(ctx context.Context, query string, args ...interface{}) database.Row {
+					return &mocks.MockRow{ScanFunc: func(dest ...interface{}) error { return sql.ErrNoRows }}
+				},
+				ExecFunc: func(ctx context.Context, query string, args ...interface{}) (database.Result, error) {
+					return mocks.NewMockResult(1), nil
+				},
+			}
+			config := DefaultConfig()
+			config.JSONNaming = tt.naming
+			if err := config.Validate(); err != nil {
+				t.Fatal(err)
+			}
+			app := fiber.New()
+			RegisterTranslatableRoutes(app, db, &config, nil, nil)
+
+			post := func(body string) (int, map[string]any) {
+				req := httptest.NewRequest(fiber.MethodPost, "/translations", strings.NewReader(body))
+				req.Header.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+				resp, err := app.Test(req)
+				if err != nil {
+					t.Fatal(err)
+				}
+				var decoded map[string]any
+				assert.NoError(t, json.NewDecoder(resp.Body).Decode(&decoded))
+				return resp.StatusCode, decoded
+			}
+
+			translatableID := uuid.NewString()
+			code, body := post(`{"` + tt.createKey + `":"` + translatableID + `","translatable":"post","locale":"fr","content":"Bonjour"}`)
+			assert.Equal(t, fiber.StatusCreated, code)
+			assert.Equal(t, "/translatables/"+translatableID, body["translatable"])
+			assert.Contains(t, body, tt.keys[0])
+			assert.Contains(t, body, tt.keys[1])
+			assert.Equal(t, "Bonjour", body["content"])
+
+			code, body = post(`{"` + tt.createKey + `":"nope","translatable":"post","locale":"fr","content":"Bonjour"}`)
+			assert.Equal(t, fiber.StatusBadRequest, code)
+			assert.Equal(t, "translatable_id must be a valid UUID", body["detail"])
+			assert.Contains(t, body, tt.keys[2])
+		})
+	}
+}
+
+func TestJSONNaming_Invalid(t *testing.T) {
+	config := DefaultConfig()
+	config.JSONNaming = "kebab"
+	assert.EqualError(t, config.Validate(), "json_naming must be snake or camel")
+}
+
+func TestRenameJSONKeys(t *testing.T) {
+	names := map[string]string{"translatable_id": "translatableId", "fields": "Fields"}
+	body, err := renameJSONKeys([]byte(`{"translatable_id":"a","hydra:member":[{"translatable_id":"b"}],"fields":{"translatable_id":"<kept>"},"n":1.50}`), names)
+	assert.NoError(t, err)
+	assert.Equal(t, `{"translatableId":"a","hydra:member":[{"translatableId":"b"}],"Fields":{"translatable_id":"<kept>"},"n":1.50}`, string(body))
+}
@@ -0,0 +1,224 @@
+package translatable
+
+import (
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v3"
+	"github.com/google/uuid"
+)
+
+// MIMEOpenAPIJSON is the media type of the OpenAPI document.
+const MIMEOpenAPIJSON = "application/vnd.oai.openapi+json"
+
+// openAPIComponents names the component schema of each documented type, so
+// nested uses are emitted as references.
+var openAPIComponents = map[reflect.Type]string{
+	reflect.TypeFor[TranslatableResponseDTO](): "Translation",
+	reflect.TypeFor[TranslatableCreateDTO]():   "TranslationCreate",
+	reflect.TypeFor[TranslatableUpdateDTO]():   "TranslationUpdate",
+	reflect.TypeFor[TranslatablePatchDTO]():    "TranslationPatch",
+	reflect.TypeFor[LocalesResponse]():         "Locales",
+	reflect.TypeFor[ProblemDetails]():          "Problem",
+}
+
+// OpenAPISpec returns an OpenAPI 3.0 document describing the translation CRUD
+// and locale routes, for client generators.
+func (p *TranslatablePlugin) OpenAPISpec() ([]byte, error) {
+	return openAPISpec(&p.config)
+}
+
+// OpenAPI serves the OpenAPI document of the routes.
+func (r *TranslatableResource) OpenAPI(c fiber.Ctx) error {
+	spec, err := openAPISpec(r.config)
+	if err != nil {
+		return fiber.NewError(fiber.StatusInternalServerError, "failed to build OpenAPI document")
+	}
+	c.Set(fiber.HeaderContentType, MIMEOpenAPIJSON)
+	return c.Send(spec)
+}
+
+// openAPISpec builds the OpenAPI document of config. Its validation rules are
+// carried over as schema constraints: types and locales as enums and the
+// content limit as maxLength.
+func openAPISpec(config *Config) ([]byte, error) {
+	prefix := config.routePrefix()
+	idParam := []fiber.Map{{
+		"name": "id", "in": "path", "required": true,
+		"schema": fiber.Map{"type": "string", "format": "uuid"},
+	}}
+
+	schemas := fiber.Map{}
+	for t, name := range openAPIComponents {
+		schemas[name] = openAPIObject(t, config)
+	}
+
+	spec := fiber.Map{
+		"openapi": "3.0.3",
+		"info": fiber.Map{
+			"title":       "Translations API",
+			"description": "Multi-language content translations",
+			"version":     "1.0.0",
+		},
+		"paths": fiber.Map{
+			prefix: fiber.Map{
+				"get": openAPIOperation("listTranslations", "List translations", fiber.StatusOK, nil, openAPICollection(config),
+					fiber.StatusBadRequest),
+				"post": openAPIOperation("createTranslation", "Create a translation", fiber.StatusCreated, openAPIRef("TranslationCreate"),
+					openAPIRef("Translation"), fiber.StatusBadRequest, fiber.StatusConflict),
+			},
+			prefix + "/{id}": fiber.Map{
+				"parameters": idParam,
+				"get": openAPIOperation("getTranslation", "Retrieve a translation", fiber.StatusOK, nil, openAPIRef("Translation"),
+					fiber.StatusNotFound),
+				"put": openAPIOperation("updateTranslation", "Replace a translation", fiber.StatusOK, openAPIRef("TranslationUpdate"),
+					openAPIRef("Translation"), fiber.StatusBadRequest, fiber.StatusNotFound, fiber.StatusConflict),
+				"patch": openAPIOperation("patchTranslation", "Partially update a translation", fiber.StatusOK, openAPIRef("TranslationPatch"),
+					openAPIRef("Translation"), fiber.StatusBadRequest, fiber.StatusNotFound, fiber.StatusConflict),
+				"delete": openAPIOperation("deleteTranslation", "Delete a translation", fiber.StatusNoContent, nil, nil, fiber.StatusNotFound),
+			},
+			"/locales": fiber.Map{
+				"get": openAPIOperation("listLocales", "List supported locales", fiber.StatusOK, nil, openAPIRef("Locales")),
+			},
+		},
+		"components": fiber.Map{"schemas": schemas},
+	}
+	return json.Marshal(spec)
+}
+
+func openAPIRef(component string) fiber.Map {
+	return fiber.Map{"$ref": "#/components/schemas/" + component}
+}
+
+// openAPIOperation describes an operation answering status with response, if
+// any, on success and a problem document on each of errors.
+func openAPIOperation(id, summary string, status int, request, response fiber.Map, errors ...int) fiber.Map {
+	success := fiber.Map{"description": http.StatusText(status)}
+	if response != nil {
+		success["content"] = fiber.Map{fiber.MIMEApplicationJSON: fiber.Map{"schema": response}}
+	}
+	responses := fiber.Map{strconv.Itoa(status): success}
+	for _, code := range errors {
+		responses[strconv.Itoa(code)] = fiber.Map{
+			"description": http.StatusText(code),
+			"content":     fiber.Map{MIMEProblemJSON: fiber.Map{"schema": openAPIRef("Problem")}},
+		}
+	}
+
+	operation := fiber.Map{
+		"operationId": id,
+		"summary":     summary,
+		"tags":        []string{"Translations"},
+		"responses":   responses,
+	}
+	if request != nil {
+		operation["requestBody"] = fiber.Map{
+			"required": true,
+			"content":  fiber.Map{fiber.MIMEApplicationJSON: fiber.Map{"schema": request}},
+		}
+	}
+	return operation
+}
+
+// openAPICollection describes a page of translations in the configured
+// ResponseFormat.
+func openAPICollection(config *Config) fiber.Map {
+	members := fiber.Map{"type": "array", "items": openAPIRef("Translation")}
+	if config.ResponseFormat == ResponseFormatPlain {
+		return fiber.Map{"type": "object", "properties": fiber.Map{
+			"data":   members,
+			"total":  fiber.Map{"type": "integer", "nullable": true},
+			"limit":  fiber.Map{"type": "integer"},
+			"offset": fiber.Map{"type": "integer"},
+		}}
+	}
+	return fiber.Map{"type": "object", "properties": fiber.Map{
+		"hydra:member":     members,
+		"hydra:totalItems": fiber.Map{"type": "integer"},
+	}}
+}
+
+// openAPIObject describes the JSON fields of struct type t. Fields are required
+// unless omitted when empty or nullable, except content, which fields can
+// replace on multi-field types.
+func openAPIObject(t reflect.Type, config *Config) fiber.Map {
+	properties := fiber.Map{}
+	var required []string
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name, opts, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "" || name == "-" {
+			continue
+		}
+
+		schema := openAPIType(field.Type, config)
+		switch name {
+		case "translatable":
+			if field.Type.Kind() == reflect.String {
+				schema["enum"] = config.AllowedTypes
+			}
+		case "locale":
+			schema["enum"] = config.SupportedLocales
+		case "content":
+			schema["maxLength"] = config.MaxContentLength
+		case "translatableId":
+			schema["format"] = "uuid"
+		}
+		properties[name] = schema
+
+		optional := strings.Contains(opts, "omitempty") || field.Type.Kind() == reflect.Ptr
+		if !optional && (name != "content" || len(config.FieldKeys) == 0) {
+			required = append(required, name)
+		}
+	}
+
+	object := fiber.Map{"type": "object", "properties": properties}
+	if len(required) > 0 {
+		object["required"] = required
+	}
+	return object
+}
+
+// openAPIType returns the schema of a field of type t.
+func openAPIType(t reflect.Type, config *Config) fiber.Map {
+	if component, ok := openAPIComponents[t]; ok {
+		return openAPIRef(component)
+	}
+	switch t {
+	case reflect.TypeFor[uuid.UUID]():
+		return fiber.Map{"type": "string", "format": "uuid"}
+	case reflect.TypeFor[time.Time]():
+		return fiber.Map{"type": "string", "format": "date-time"}
+	case reflect.TypeFor[json.RawMessage]():
+		return fiber.Map{}
+	}
+
+	switch t.Kind() {
+	case reflect.Ptr:
+		schema := openAPIType(t.Elem(), config)
+		if _, ref := schema["$ref"]; ref {
+			return fiber.Map{"allOf": []fiber.Map{schema}, "nullable": true}
+		}
+		schema["nullable"] = true
+		return schema
+	case reflect.String:
+		return fiber.Map{"type": "string"}
+	case reflect.Bool:
+		return fiber.Map{"type": "boolean"}
+	case reflect.Int, reflect.Int32, reflect.Int64:
+		return fiber.Map{"type": "integer"}
+	case reflect.Float32, reflect.Float64:
+		return fiber.Map{"type": "number"}
+	case reflect.Slice:
+		return fiber.Map{"type": "array", "items": openAPIType(t.Elem(), config)}
+	case reflect.Map:
+		return fiber.Map{"type": "object", "additionalProperties": openAPIType(t.Elem(), config)}
+	case reflect.Struct:
+		return openAPIObject(t, config)
+	}
+	return fiber.Map{}
+}
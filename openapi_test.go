@@ -0,0 +1,87 @@
+package translatable
+
+import (
+	"encoding/json"
+	"io"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v3"
+	"github.com/stretchr/testify/assert"
+)
+
+type openAPIDocument struct {
+	OpenAPI    string                                `json:"openapi"`
+	Paths      map[string]map[string]json.RawMessage `json:"paths"`
+	Components struct {
+		Schemas map[string]struct {
+			Required   []string `json:"required"`
+			Properties map[string]struct {
+				Format    string   `json:"format"`
+				Enum      []string `json:"enum"`
+				MaxLength int      `json:"maxLength"`
+			} `json:"properties"`
+		} `json:"schemas"`
+	} `json:"components"`
+}
+
+func TestTranslatablePlugin_OpenAPISpec(t *testing.T) {
+	plugin := &TranslatablePlugin{}
+	err := plugin.Initialize(map[string]interface{}{
+		"allowed_types":      []interface{}{"posts", "articles"},
+		"supported_locales":  []interface{}{"en", "fr"},
+		"default_locale":     "en",
+		"max_content_length": 500,
+	})
+	assert.NoError(t, err)
+
+	spec, err := plugin.OpenAPISpec()
+	assert.NoError(t, err)
+
+	var doc openAPIDocument
+	assert.NoError(t, json.Unmarshal(spec, &doc))
+	assert.Equal(t, "3.0.3", doc.OpenAPI)
+
+	operations := map[string][]string{
+		"/translations":      {"get", "post"},
+		"/translations/{id}": {"get", "put", "patch", "delete"},
+		"/locales":           {"get"},
+	}
+	for path, methods := range operations {
+		if assert.Contains(t, doc.Paths, path) {
+			for _, method := range methods {
+				assert.Contains(t, doc.Paths[path], method, path)
+			}
+		}
+	}
+
+	create := doc.Components.Schemas["TranslationCreate"]
+	assert.ElementsMatch(t, []string{"translatableId", "translatable", "locale", "content"}, create.Required)
+	assert.Equal(t, "uuid", create.Properties["translatableId"].Format)
+	assert.Equal(t, []string{"posts", "articles"}, create.Properties["translatable"].Enum)
+	assert.Equal(t, []string{"en", "fr"}, create.Properties["locale"].Enum)
+	assert.Equal(t, 500, create.Properties["content"].MaxLength)
+	assert.Equal(t, 500, doc.Components.Schemas["TranslationUpdate"].Properties["content"].MaxLength)
+	assert.Empty(t, doc.Components.Schemas["TranslationPatch"].Required)
+	assert.Equal(t, "uuid", doc.Components.Schemas["Translation"].Properties["id"].Format)
+}
+
+func TestTranslatableResource_OpenAPI(t *testing.T) {
+	config := DefaultConfig()
+	config.RoutePrefix = "/i18n"
+	app, resource := setupTestApp(nil, &config)
+	app.Get("/i18n/openapi.json", resource.OpenAPI)
+
+	resp, err := app.Test(httptest.NewRequest(fiber.MethodGet, "/i18n/openapi.json", nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	body, _ := io.ReadAll(resp.Body)
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+	assert.Equal(t, MIMEOpenAPIJSON, resp.Header.Get(fiber.HeaderContentType))
+	var doc openAPIDocument
+	assert.NoError(t, json.Unmarshal(body, &doc))
+	assert.Contains(t, doc.Paths, "/i18n")
+	assert.Contains(t, doc.Paths, "/i18n/{id}")
+}
@@ -0,0 +1,20 @@
+package translatable
+
+import (
+	"errors"
+
+	"github.com/gofiber/fiber/v3"
+)
+
+// optionsMiddleware answers OPTIONS requests on the plugin's routes with 204
+// and the Allow header the router builds, when it finds no OPTIONS handler,
+// from the methods of the routes matching the path. HEAD is served by every
+// GET route.
+func optionsMiddleware(c fiber.Ctx) error {
+	err := c.Next()
+	if c.Method() != fiber.MethodOptions || !errors.Is(err, fiber.ErrMethodNotAllowed) {
+		return err
+	}
+	c.Append(fiber.HeaderAllow, fiber.MethodOptions)
+	return c.SendStatus(fiber.StatusNoContent)
+}
@@ -0,0 +1,54 @@
+package translatable
+
+import (
+	"context"
+	"errors"
+
+	"github.com/gofiber/fiber/v3"
+	"github.com/google/uuid"
+)
+
+// Ownership policies of Config.OwnershipPolicy. Unset, ownership is only
+// enforced on authenticated callers and anonymous ones can change any
+// translation.
+const (
+	OwnershipNone         = "none"
+	OwnershipOwnerOnly    = "owner-only"
+	OwnershipOwnerOrAdmin = "owner-or-admin"
+)
+
+var ownershipPolicies = []string{OwnershipNone, OwnershipOwnerOnly, OwnershipOwnerOrAdmin}
+
+// checkOwnership reports whether the caller userID may change a translation
+// owned by owner: ErrUnauthenticated when the policy needs a caller and there
+// is none, ErrForbidden when the translation is someone else's. Translations
+// without an owner are open to every caller the policy lets through.
+func (c *Config) checkOwnership(ctx context.Context, userID, owner *uuid.UUID) error {
+	switch c.OwnershipPolicy {
+	case OwnershipNone:
+		return nil
+	case OwnershipOwnerOnly, OwnershipOwnerOrAdmin:
+		if userID == nil {
+			return ErrUnauthenticated
+		}
+		if c.OwnershipPolicy == OwnershipOwnerOrAdmin && c.IsAdmin(ctx) {
+			return nil
+		}
+	}
+	if userID != nil && owner != nil && *owner != *userID {
+		return ErrForbidden
+	}
+	return nil
+}
+
+// errOwnership answers an ownership failure of checkOwnership, or returns nil
+// for any other error.
+func errOwnership(err error, verb string) error {
+	switch {
+	case errors.Is(err, ErrUnauthenticated):
+		return fiber.NewError(fiber.StatusUnauthorized, "Authentication is required to "+verb+" translations")
+	case errors.Is(err, ErrForbidden):
+		return fiber.NewError(fiber.StatusForbidden, "You can only "+verb+" your own translations")
+	}
+	return nil
+}
@@ -0,0 +1,120 @@
+package translatable
+
+import (
+	"context"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gofiber/fiber/v3"
+	"github.com/google/uuid"
+	"github.com/nicolasbonnici/gorest-translatable/mocks"
+	authcontext "github.com/nicolasbonnici/gorest/auth/context"
+	"github.com/nicolasbonnici/gorest/database"
+	"github.com/nicolasbonnici/gorest/rbac"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOwnershipPolicy(t *testing.T) {
+	owner, other := uuid.New(), uuid.New()
+	callers := []struct {
+		name   string
+		userID *uuid.UUID
+		roles  []string
+	}{
+		{name: "owner", userID: &owner},
+		{name: "non-owner", userID: &other},
+		{name: "admin", userID: &other, roles: []string{"admin"}},
+		{name: "anonymous"},
+	}
+	tests := []struct {
+		name   string
+		policy string
+		codes  []int
+	}{
+		{name: "unset", codes: []int{fiber.StatusOK, fiber.StatusForbidden, fiber.StatusForbidden, fiber.StatusOK}},
+		{name: OwnershipNone, policy: OwnershipNone, codes: []int{fiber.StatusOK, fiber.StatusOK, fiber.StatusOK, fiber.StatusOK}},
+		{name: OwnershipOwnerOnly, policy: OwnershipOwnerOnly, codes: []int{fiber.StatusOK, fiber.StatusForbidden, fiber.StatusForbidden, fiber.StatusUnauthorized}},
+		{name: OwnershipOwnerOrAdmin, policy: OwnershipOwnerOrAdmin, codes: []int{fiber.StatusOK, fiber.StatusForbidden, fiber.StatusOK, fiber.StatusUnauthorized}},
+	}
+
+	for _, tt := range tests {
+		for i, caller := range callers {
+			for _, method := range []string{fiber.MethodPut, fiber.MethodDelete} {
+				t.Run(tt.name+"/"+caller.name+"/"+method, func(t *testing.T) {
+					id := uuid.New()
+					writes := 0
+					db := &mocks.MockDatabase{
+						QueryRowFunc: func(ctx context.Context, query string, args ...interface{}) database.Row {
+							if strings.HasPrefix(query, "SELECT content ") {
+								return &mocks.MockRow{}
+							}
+							return &mocks.MockRow{ScanFunc: func(dest ...interface{}) error {
+								*dest[0].(*uuid.UUID) = id
+								*dest[1].(**uuid.UUID) = &owner
+								*dest[3].(*string) = "post"
+								*dest[4].(*string) = "fr"
+								*dest[5].(*string) = "Bonjour"
+								return nil
+							}}
+						},
+						ExecFunc: func(ctx context.Context, query string, args ...interface{}) (database.Result, error) {
+							writes++
+							return mocks.NewMockResult(1), nil
+						},
+					}
+					config := DefaultConfig()
+					config.OwnershipPolicy = tt.policy
+					if err := config.Validate(); err != nil {
+						t.Fatal(err)
+					}
+					app, resource := setupTestApp(db, &config)
+					app.Use(func(c fiber.Ctx) error {
+						if caller.userID != nil {
+							authcontext.SetUserID(c, caller.userID.String())
+						}
+						c.SetContext(rbac.WithRoles(c.Context(), caller.roles))
+						return c.Next()
+					})
+					app.Put("/translations/:id", resource.Update)
+					app.Delete("/translations/:id", resource.Delete)
+
+					req := httptest.NewRequest(method, "/translations/"+id.String(), strings.NewReader(`{"locale":"fr","content":"Salut"}`))
+					req.Header.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+					resp, err := app.Test(req)
+					if err != nil {
+						t.Fatal(err)
+					}
+
+					code := tt.codes[i]
+					if method == fiber.MethodDelete && code == fiber.StatusOK {
+						code = fiber.StatusNoContent
+					}
+					assert.Equal(t, code, resp.StatusCode)
+					assert.Equal(t, code < 300, writes > 0)
+				})
+			}
+		}
+	}
+}
+
+func TestOwnershipPolicy_AdminCheck(t *testing.T) {
+	owner, other := uuid.New(), uuid.New()
+	config := DefaultConfig()
+	config.OwnershipPolicy = OwnershipOwnerOrAdmin
+	config.AdminCheck = func(ctx context.Context) bool { return true }
+	ctx := context.Background()
+
+	assert.NoError(t, config.checkOwnership(ctx, &other, &owner))
+	assert.ErrorIs(t, config.checkOwnership(ctx, nil, &owner), ErrUnauthenticated)
+
+	config.AdminCheck = func(ctx context.Context) bool { return false }
+	assert.ErrorIs(t, config.checkOwnership(ctx, &other, &owner), ErrForbidden)
+	assert.NoError(t, config.checkOwnership(ctx, &other, nil), "translations without an owner stay open")
+}
+
+func TestOwnershipPolicy_Invalid(t *testing.T) {
+	config := DefaultConfig()
+	config.OwnershipPolicy = "owner"
+	assert.EqualError(t, config.Validate(), "ownership_policy must be one of none, owner-only, owner-or-admin")
+}
@@ -0,0 +1,169 @@
+package translatable
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/gofiber/fiber/v3"
+	"github.com/nicolasbonnici/gorest/pagination"
+)
+
+func queryParams(c fiber.Ctx) url.Values {
+	params := make(url.Values)
+	for key, value := range c.Request().URI().QueryArgs().All() {
+		params.Add(string(key), string(value))
+	}
+	return params
+}
+
+// paginationURL mirrors the gorest Hydra view URLs: limit is omitted when it
+// equals the default and page when it is the first one.
+func paginationURL(basePath string, params url.Values, limit, page, defaultLimit int) string {
+	values := url.Values{}
+	for key, v := range params {
+		if key == "limit" || key == "page" || len(v) == 0 || v[0] == "" {
+			continue
+		}
+		values[key] = v
+	}
+
+	if limit > 0 && limit != defaultLimit {
+		values.Set("limit", strconv.Itoa(limit))
+	}
+	if page > 1 {
+		values.Set("page", strconv.Itoa(page))
+	}
+
+	if len(values) == 0 {
+		return basePath
+	}
+	return basePath + "?" + values.Encode()
+}
+
+// paginationLinks renders an RFC 8288 Link header value for a collection page.
+func paginationLinks(basePath string, params url.Values, limit, page, defaultLimit, total int) string {
+	lastPage := (total + limit - 1) / limit
+	if lastPage < 1 {
+		lastPage = 1
+	}
+
+	links := []string{`<` + paginationURL(basePath, params, limit, 1, defaultLimit) + `>; rel="first"`}
+	if page > 1 {
+		links = append(links, `<`+paginationURL(basePath, params, limit, page-1, defaultLimit)+`>; rel="prev"`)
+	}
+	if page < lastPage {
+		links = append(links, `<`+paginationURL(basePath, params, limit, page+1, defaultLimit)+`>; rel="next"`)
+	}
+	links = append(links, `<`+paginationURL(basePath, params, limit, lastPage, defaultLimit)+`>; rel="last"`)
+
+	return strings.Join(links, ", ")
+}
+
+// pageWindow reads ?limit= and ?page= the same way for every collection. A
+// limit below 1 falls back to PaginationLimit rather than reading without a
+// bound; the window moves by ?page= only.
+func pageWindow(c fiber.Ctx, config *Config) (limit, page int) {
+	limit = pagination.ParseIntQuery(c, "limit", config.PaginationLimit, config.MaxPaginationLimit)
+	if limit < 1 {
+		limit = config.PaginationLimit
+	}
+	page = pagination.ParseIntQuery(c, "page", 1, 10000)
+	if page < 1 {
+		page = 1
+	}
+	return limit, page
+}
+
+// paginationMeta reports the page window a collection was read with next to
+// the limit the client asked for, so clamped limits can be detected.
+type paginationMeta struct {
+	AppliedLimit   int  `json:"applied_limit"`
+	AppliedOffset  int  `json:"applied_offset"`
+	RequestedLimit *int `json:"requested_limit,omitempty"`
+}
+
+func newPaginationMeta(c fiber.Ctx, limit, page int) paginationMeta {
+	return paginationMeta{
+		AppliedLimit:   limit,
+		AppliedOffset:  (page - 1) * limit,
+		RequestedLimit: requestedInt(c, "limit"),
+	}
+}
+
+func requestedInt(c fiber.Ctx, key string) *int {
+	value, err := strconv.Atoi(c.Query(key))
+	if err != nil {
+		return nil
+	}
+	return &value
+}
+
+// plainCollection is the collection shape of ResponseFormatPlain.
+type plainCollection struct {
+	Data   []json.RawMessage `json:"data"`
+	Total  *int              `json:"total"`
+	Limit  int               `json:"limit"`
+	Offset int               `json:"offset"`
+}
+
+// requestPlainMembers has gorest render the members of the collection about
+// to be sent as plain JSON rather than JSON-LD under ResponseFormatPlain.
+func requestPlainMembers(c fiber.Ctx, config *Config) {
+	if config.ResponseFormat == ResponseFormatPlain {
+		c.Request().Header.Set(fiber.HeaderAccept, fiber.MIMEApplicationJSON)
+	}
+}
+
+// finishCollection completes a Hydra collection body sent by gorest: it is
+// reshaped into a plainCollection under ResponseFormatPlain, then given the
+// pagination meta.
+func finishCollection(c fiber.Ctx, config *Config, meta paginationMeta) error {
+	if config.ResponseFormat == ResponseFormatPlain && c.Response().StatusCode() == fiber.StatusOK {
+		var hydra struct {
+			TotalItems *int              `json:"hydra:totalItems"`
+			Member     []json.RawMessage `json:"hydra:member"`
+		}
+		if err := json.Unmarshal(c.Response().Body(), &hydra); err != nil {
+			return err
+		}
+		if hydra.Member == nil {
+			hydra.Member = []json.RawMessage{}
+		}
+
+		body, err := json.Marshal(plainCollection{Data: hydra.Member, Total: hydra.TotalItems, Limit: meta.AppliedLimit, Offset: meta.AppliedOffset})
+		if err != nil {
+			return err
+		}
+		c.Response().SetBodyRaw(body)
+	}
+	return appendPaginationMeta(c, meta)
+}
+
+// appendPaginationMeta adds meta after the existing fields of a successful JSON
+// collection body, leaving other responses untouched.
+func appendPaginationMeta(c fiber.Ctx, meta paginationMeta) error {
+	if c.Response().StatusCode() != fiber.StatusOK {
+		return nil
+	}
+	body := bytes.TrimRight(c.Response().Body(), " \n")
+	if len(body) < 2 || body[len(body)-1] != '}' {
+		return nil
+	}
+
+	fields, err := json.Marshal(meta)
+	if err != nil {
+		return err
+	}
+
+	enriched := make([]byte, 0, len(body)+len(fields))
+	enriched = append(enriched, body[:len(body)-1]...)
+	if len(bytes.TrimSpace(body[1:len(body)-1])) > 0 {
+		enriched = append(enriched, ',')
+	}
+	enriched = append(enriched, fields[1:]...)
+	c.Response().SetBodyRaw(enriched)
+	return nil
+}
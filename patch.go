@@ -0,0 +1,74 @@
+package translatable
+
+import (
+	"context"
+	"maps"
+
+	"github.com/gofiber/fiber/v3"
+)
+
+const patchKey contextKey = "translatable_patch"
+
+func withPatch(ctx context.Context, patch *TranslatablePatchDTO) context.Context {
+	return context.WithValue(ctx, patchKey, patch)
+}
+
+func patchFromContext(ctx context.Context) *TranslatablePatchDTO {
+	patch, _ := ctx.Value(patchKey).(*TranslatablePatchDTO)
+	return patch
+}
+
+// applyPatch merges the fields of patch into model over those of existing,
+// validating only what patch supplies. Plain content patches the FieldValue
+// field of a multi-field type and supplied fields replace only themselves.
+func (h *TranslatableHooks) applyPatch(patch *TranslatablePatchDTO, existing, model *Translatable) error {
+	if patch.Content != nil && patch.Fields != nil {
+		return fiber.NewError(400, "content and fields cannot both be set")
+	}
+
+	fields := patch.Fields
+	if patch.Content != nil {
+		if !h.config.IsMultiField(existing.Translatable) {
+			content, err := h.prepareContent(existing.Translatable, *patch.Content)
+			if err != nil {
+				return err
+			}
+			model.Content = content
+			model.ContentRaw = h.rawContent(existing.Translatable, *patch.Content)
+			return nil
+		}
+		fields = map[string]string{FieldValue: *patch.Content}
+	}
+
+	model.Content = existing.Content
+	model.ContentRaw = existing.ContentRaw
+	if fields == nil {
+		return nil
+	}
+	if !h.config.IsMultiField(existing.Translatable) {
+		return fiber.NewError(400, "fields are not enabled for type "+existing.Translatable)
+	}
+
+	merged := decodeFields(existing.Content)
+	raw := maps.Clone(merged)
+	if existing.ContentRaw != nil {
+		raw = decodeFields(*existing.ContentRaw)
+	}
+	for key, value := range fields {
+		content, rawContent, err := h.prepareField(existing.Translatable, key, value)
+		if err != nil {
+			return err
+		}
+		merged[key] = content
+		if rawContent != nil {
+			raw[key] = *rawContent
+		}
+	}
+
+	model.Content = encodeFields(merged)
+	if h.config.StoreRawContent {
+		encoded := encodeFields(raw)
+		model.ContentRaw = &encoded
+	}
+	return nil
+}
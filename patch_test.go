@@ -0,0 +1,93 @@
+package translatable
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gofiber/fiber/v3"
+	"github.com/google/uuid"
+	"github.com/nicolasbonnici/gorest-translatable/mocks"
+	"github.com/nicolasbonnici/gorest/database"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTranslatableResource_Patch(t *testing.T) {
+	id := uuid.New()
+	tests := []struct {
+		name         string
+		translatable string
+		stored       string
+		body         string
+		status       int
+		locale       string
+		content      string
+		fields       map[string]string
+	}{
+		{name: "locale only", translatable: "comment", stored: "Bonjour", body: `{"locale":"de"}`, status: fiber.StatusOK, locale: "de", content: "Bonjour"},
+		{name: "content only", translatable: "comment", stored: "Bonjour", body: `{"content":"Salut"}`, status: fiber.StatusOK, locale: "fr", content: "Salut"},
+		{name: "empty patch", translatable: "comment", stored: "Bonjour", body: `{}`, status: fiber.StatusOK, locale: "fr", content: "Bonjour"},
+		{
+			name: "one field", translatable: "post", stored: `{"body":"Le corps","title":"Bonjour"}`, body: `{"fields":{"title":"Salut"}}`,
+			status: fiber.StatusOK, locale: "fr", content: `{"body":"Le corps","title":"Salut"}`,
+			fields: map[string]string{"title": "Salut", "body": "Le corps"},
+		},
+		{name: "empty content", translatable: "comment", stored: "Bonjour", body: `{"content":""}`, status: fiber.StatusBadRequest},
+		{name: "unsupported locale", translatable: "comment", stored: "Bonjour", body: `{"locale":"it"}`, status: fiber.StatusBadRequest},
+		{name: "unknown field", translatable: "post", stored: `{"title":"Bonjour"}`, body: `{"fields":{"subtitle":"Salut"}}`, status: fiber.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var updateArgs []interface{}
+			db := &mocks.MockDatabase{
+				QueryRowFunc: func(ctx context.Context, query string, args ...interface{}) database.Row {
+					return &mocks.MockRow{ScanFunc: func(dest ...interface{}) error {
+						if len(dest) == 1 {
+							return sql.ErrNoRows
+						}
+						*dest[0].(*uuid.UUID) = id
+						*dest[3].(*string) = tt.translatable
+						*dest[4].(*string) = "fr"
+						*dest[5].(*string) = tt.stored
+						*dest[16].(*int) = 2
+						return nil
+					}}
+				},
+				ExecFunc: func(ctx context.Context, query string, args ...interface{}) (database.Result, error) {
+					updateArgs = args
+					return mocks.NewMockResult(1), nil
+				},
+			}
+			config := fieldsConfig()
+			config.SupportedLocales = []string{"en", "fr", "de"}
+			app, resource := setupTestApp(db, &config)
+			app.Patch("/translations/:id", resource.Patch)
+
+			req := httptest.NewRequest(fiber.MethodPatch, "/translations/"+id.String(), strings.NewReader(tt.body))
+			req.Header.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+			resp, err := app.Test(req)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			assert.Equal(t, tt.status, resp.StatusCode)
+			if tt.status != fiber.StatusOK {
+				assert.Nil(t, updateArgs)
+				return
+			}
+			assert.Contains(t, updateArgs, tt.locale)
+			assert.Contains(t, updateArgs, tt.content)
+
+			var body TranslatableResponseDTO
+			assert.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+			assert.Equal(t, tt.locale, body.Locale)
+			assert.Equal(t, tt.content, body.Content)
+			assert.Equal(t, tt.fields, body.Fields)
+			assert.Equal(t, 3, body.Version)
+		})
+	}
+}
@@ -1,6 +1,11 @@
 package translatable
 
 import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
 	"github.com/gofiber/fiber/v3"
 	"github.com/nicolasbonnici/gorest-translatable/migrations"
 	"github.com/nicolasbonnici/gorest/auth/jwt"
@@ -8,6 +13,7 @@ import (
 	gorestconfig "github.com/nicolasbonnici/gorest/config"
 	"github.com/nicolasbonnici/gorest/database"
 	"github.com/nicolasbonnici/gorest/plugin"
+	"github.com/prometheus/client_golang/prometheus"
 )
 
 type TranslatablePlugin struct {
@@ -34,7 +40,12 @@ func (p *TranslatablePlugin) Initialize(config map[string]interface{}) error {
 		p.config.Database = db
 	}
 
-	if allowedTypes, ok := config["allowed_types"].([]interface{}); ok {
+	allowedTypes, ok := config["allowed_types"].([]interface{})
+	if !ok {
+		// allowed_tables is the deprecated name of allowed_types.
+		allowedTypes, ok = config["allowed_tables"].([]interface{})
+	}
+	if ok {
 		types := make([]string, 0, len(allowedTypes))
 		for _, t := range allowedTypes {
 			if str, ok := t.(string); ok {
@@ -62,6 +73,14 @@ func (p *TranslatablePlugin) Initialize(config map[string]interface{}) error {
 		p.config.DefaultLocale = defaultLocale
 	}
 
+	if strictLocales, ok := config["strict_locales"].(bool); ok {
+		p.config.StrictLocales = &strictLocales
+	}
+
+	if canonicalLocales, ok := config["canonical_locales"].(bool); ok {
+		p.config.CanonicalLocales = canonicalLocales
+	}
+
 	if paginationLimit, ok := config["pagination_limit"].(int); ok {
 		p.config.PaginationLimit = paginationLimit
 	}
@@ -74,6 +93,363 @@ func (p *TranslatablePlugin) Initialize(config map[string]interface{}) error {
 		p.config.MaxContentLength = maxContentLength
 	}
 
+	if countRunes, ok := config["count_runes"].(bool); ok {
+		p.config.CountRunes = &countRunes
+	}
+
+	if maxLocalesPerPage, ok := config["max_locales_per_page"].(int); ok {
+		p.config.MaxLocalesPerPage = maxLocalesPerPage
+	}
+
+	if maxBulkLookup, ok := config["max_bulk_lookup"].(int); ok {
+		p.config.MaxBulkLookup = maxBulkLookup
+	}
+
+	if sortableColumns, ok := config["sortable_columns"].([]interface{}); ok {
+		fields := make([]string, 0, len(sortableColumns))
+		for _, f := range sortableColumns {
+			if str, ok := f.(string); ok {
+				fields = append(fields, str)
+			}
+		}
+		p.config.SortableColumns = fields
+	}
+
+	if contentSchemas, ok := config["content_schemas"].(map[string]interface{}); ok {
+		schemas := make(map[string]json.RawMessage, len(contentSchemas))
+		for typeName, raw := range contentSchemas {
+			schema, err := json.Marshal(raw)
+			if err != nil {
+				return fmt.Errorf("invalid content_schemas for %s: %w", typeName, err)
+			}
+			schemas[typeName] = schema
+		}
+		p.config.ContentSchemas = schemas
+	}
+
+	if defaultSchema, ok := config["default_content_schema"].(map[string]interface{}); ok {
+		schema, err := json.Marshal(defaultSchema)
+		if err != nil {
+			return fmt.Errorf("invalid default_content_schema: %w", err)
+		}
+		p.config.DefaultContentSchema = schema
+	}
+
+	if maxSnapshots, ok := config["max_snapshots"].(int); ok {
+		p.config.MaxSnapshots = maxSnapshots
+	}
+
+	if snapshotTTL, ok := config["snapshot_ttl"].(string); ok {
+		ttl, err := time.ParseDuration(snapshotTTL)
+		if err != nil {
+			return fmt.Errorf("invalid snapshot_ttl: %w", err)
+		}
+		p.config.SnapshotTTL = ttl
+	}
+
+	if partialIndexTypes, ok := config["partial_index_types"].([]interface{}); ok {
+		types := make([]string, 0, len(partialIndexTypes))
+		for _, t := range partialIndexTypes {
+			if str, ok := t.(string); ok {
+				types = append(types, str)
+			}
+		}
+		p.config.PartialIndexTypes = types
+	}
+
+	if hydraDocs, ok := config["hydra_docs"].(bool); ok {
+		p.config.HydraDocs = hydraDocs
+	}
+
+	if trimContent, ok := config["trim_content"].(bool); ok {
+		p.config.TrimContent = &trimContent
+	}
+
+	if collapseWhitespace, ok := config["collapse_whitespace"].(bool); ok {
+		p.config.CollapseWhitespace = collapseWhitespace
+	}
+
+	if trimLines, ok := config["trim_lines"].(bool); ok {
+		p.config.TrimLines = trimLines
+	}
+
+	if normalizeUnicode, ok := config["normalize_unicode"].(bool); ok {
+		p.config.NormalizeUnicode = normalizeUnicode
+	}
+
+	if fallbackChain, ok := config["fallback_chain"].(map[string]interface{}); ok {
+		chains := make(map[string][]string, len(fallbackChain))
+		for locale, raw := range fallbackChain {
+			list, _ := raw.([]interface{})
+			for _, l := range list {
+				if str, ok := l.(string); ok {
+					chains[locale] = append(chains[locale], str)
+				}
+			}
+		}
+		p.config.FallbackChain = chains
+	}
+
+	if fallbackStrategy, ok := config["fallback_strategy"].(string); ok {
+		p.config.FallbackStrategy = fallbackStrategy
+	}
+
+	if translateOnMiss, ok := config["translate_on_miss"].(bool); ok {
+		p.config.TranslateOnMiss = translateOnMiss
+	}
+
+	if translateOnMissTimeout, ok := config["translate_on_miss_timeout"].(string); ok {
+		timeout, err := time.ParseDuration(translateOnMissTimeout)
+		if err != nil {
+			return fmt.Errorf("invalid translate_on_miss_timeout: %w", err)
+		}
+		p.config.TranslateOnMissTimeout = timeout
+	}
+
+	if storeRawContent, ok := config["store_raw_content"].(bool); ok {
+		p.config.StoreRawContent = storeRawContent
+	}
+
+	if defaultStatus, ok := config["default_status"].(string); ok {
+		p.config.DefaultStatus = defaultStatus
+	}
+
+	if requireReview, ok := config["require_review"].(bool); ok {
+		p.config.RequireReview = requireReview
+	}
+
+	if defaultStatusFilter, ok := config["default_status_filter"].(string); ok {
+		p.config.DefaultStatusFilter = defaultStatusFilter
+	}
+
+	if trackReceivedAt, ok := config["track_received_at"].(bool); ok {
+		p.config.TrackReceivedAt = trackReceivedAt
+	}
+
+	if softDelete, ok := config["soft_delete"].(bool); ok {
+		p.config.SoftDelete = softDelete
+	}
+
+	if trashHidesContent, ok := config["trash_hides_content"].(bool); ok {
+		p.config.TrashHidesContent = trashHidesContent
+	}
+
+	if sanitizeMode, ok := config["sanitize_mode"].(string); ok {
+		p.config.SanitizeMode = sanitizeMode
+	}
+
+	if typeSanitizeModes, ok := config["type_sanitize_modes"].(map[string]interface{}); ok {
+		modes := make(map[string]string, len(typeSanitizeModes))
+		for typeName, raw := range typeSanitizeModes {
+			if mode, ok := raw.(string); ok {
+				modes[typeName] = mode
+			}
+		}
+		p.config.TypeSanitizeModes = modes
+	}
+
+	if typeValidators, ok := config["type_validators"].(map[string]interface{}); ok {
+		validators := make(map[string]string, len(typeValidators))
+		for typeName, raw := range typeValidators {
+			if kind, ok := raw.(string); ok {
+				validators[typeName] = kind
+			}
+		}
+		p.config.TypeValidators = validators
+	}
+
+	if defaultLocaleFirst, ok := config["default_locale_first"].(bool); ok {
+		p.config.DefaultLocaleFirst = defaultLocaleFirst
+	}
+
+	if warnOnUntranslated, ok := config["warn_on_untranslated"].(bool); ok {
+		p.config.WarnOnUntranslated = warnOnUntranslated
+	}
+
+	if adminRole, ok := config["admin_role"].(string); ok {
+		p.config.AdminRole = adminRole
+	}
+
+	if ownershipPolicy, ok := config["ownership_policy"].(string); ok {
+		p.config.OwnershipPolicy = ownershipPolicy
+	}
+
+	if deepLAPIKey, ok := config["deepl_api_key"].(string); ok {
+		p.config.DeepLAPIKey = deepLAPIKey
+	}
+
+	if contentFormat, ok := config["content_format"].(string); ok {
+		p.config.ContentFormat = contentFormat
+	}
+
+	if responseFormat, ok := config["response_format"].(string); ok {
+		p.config.ResponseFormat = responseFormat
+	}
+
+	if maxJSONDepth, ok := config["max_json_depth"].(int); ok {
+		p.config.MaxJSONDepth = maxJSONDepth
+	}
+
+	if maxJSONKeys, ok := config["max_json_keys"].(int); ok {
+		p.config.MaxJSONKeys = maxJSONKeys
+	}
+
+	if messages, ok := config["messages"].(map[string]interface{}); ok {
+		catalog := make(map[string]map[string]string, len(messages))
+		for locale, raw := range messages {
+			entries, _ := raw.(map[string]interface{})
+			catalog[locale] = make(map[string]string, len(entries))
+			for key, message := range entries {
+				if str, ok := message.(string); ok {
+					catalog[locale][key] = str
+				}
+			}
+		}
+		p.config.Messages = catalog
+	}
+
+	if fieldKeys, ok := config["field_keys"].(map[string]interface{}); ok {
+		keys := make(map[string][]string, len(fieldKeys))
+		for typeName, raw := range fieldKeys {
+			list, _ := raw.([]interface{})
+			for _, key := range list {
+				if str, ok := key.(string); ok {
+					keys[typeName] = append(keys[typeName], str)
+				}
+			}
+		}
+		p.config.FieldKeys = keys
+	}
+
+	if typeTTLs, ok := config["type_ttls"].(map[string]interface{}); ok {
+		ttls := make(map[string]time.Duration, len(typeTTLs))
+		for typeName, raw := range typeTTLs {
+			switch v := raw.(type) {
+			case string:
+				ttl, err := time.ParseDuration(v)
+				if err != nil {
+					return fmt.Errorf("invalid type_ttls duration for %s: %w", typeName, err)
+				}
+				ttls[typeName] = ttl
+			case int:
+				ttls[typeName] = time.Duration(v) * time.Second
+			}
+		}
+		p.config.TypeTTLs = ttls
+	}
+
+	if translatorTimeout, ok := config["translator_timeout"].(string); ok {
+		timeout, err := time.ParseDuration(translatorTimeout)
+		if err != nil {
+			return fmt.Errorf("invalid translator_timeout: %w", err)
+		}
+		p.config.TranslatorTimeout = timeout
+	}
+
+	if queryTimeout, ok := config["query_timeout"].(string); ok {
+		timeout, err := time.ParseDuration(queryTimeout)
+		if err != nil {
+			return fmt.Errorf("invalid query_timeout: %w", err)
+		}
+		p.config.QueryTimeout = timeout
+	}
+
+	if cacheSize, ok := config["cache_size"].(int); ok {
+		p.config.CacheSize = cacheSize
+	}
+
+	if cacheTTL, ok := config["cache_ttl"].(string); ok {
+		ttl, err := time.ParseDuration(cacheTTL)
+		if err != nil {
+			return fmt.Errorf("invalid cache_ttl: %w", err)
+		}
+		p.config.CacheTTL = ttl
+	}
+
+	if tableName, ok := config["table_name"].(string); ok {
+		p.config.TableName = tableName
+	}
+
+	if routePrefix, ok := config["route_prefix"].(string); ok {
+		p.config.RoutePrefix = routePrefix
+	}
+
+	if tenantScoped, ok := config["tenant_scoped"].(bool); ok {
+		p.config.TenantScoped = tenantScoped
+	}
+
+	if legacyErrors, ok := config["legacy_errors"].(bool); ok {
+		p.config.LegacyErrors = legacyErrors
+	}
+
+	if jsonNaming, ok := config["json_naming"].(string); ok {
+		p.config.JSONNaming = jsonNaming
+	}
+
+	if corsConfig, ok := config["cors"].(map[string]interface{}); ok {
+		p.config.CORS.AllowOrigins = stringList(corsConfig["allow_origins"])
+		p.config.CORS.AllowMethods = stringList(corsConfig["allow_methods"])
+		p.config.CORS.AllowHeaders = stringList(corsConfig["allow_headers"])
+		if allowCredentials, ok := corsConfig["allow_credentials"].(bool); ok {
+			p.config.CORS.AllowCredentials = allowCredentials
+		}
+	}
+
+	if rateLimit, ok := config["rate_limit"].(map[string]interface{}); ok {
+		if requests, ok := rateLimit["requests"].(int); ok {
+			p.config.RateLimit.Requests = requests
+		}
+		if raw, ok := rateLimit["window"].(string); ok {
+			window, err := time.ParseDuration(raw)
+			if err != nil {
+				return fmt.Errorf("invalid rate_limit window: %w", err)
+			}
+			p.config.RateLimit.Window = window
+		}
+	}
+
+	if enableMetrics, ok := config["enable_metrics"].(bool); ok {
+		p.config.EnableMetrics = enableMetrics
+	}
+
+	if refresh, ok := config["metrics_refresh_interval"].(string); ok {
+		interval, err := time.ParseDuration(refresh)
+		if err != nil {
+			return fmt.Errorf("invalid metrics_refresh_interval: %w", err)
+		}
+		p.config.MetricsRefreshInterval = interval
+	}
+
+	if webhooks, ok := config["webhooks"].([]interface{}); ok {
+		urls := make([]string, 0, len(webhooks))
+		for _, w := range webhooks {
+			if str, ok := w.(string); ok {
+				urls = append(urls, str)
+			}
+		}
+		p.config.Webhooks = urls
+	}
+
+	if webhookSecret, ok := config["webhook_secret"].(string); ok {
+		p.config.WebhookSecret = webhookSecret
+	}
+
+	if webhookRetries, ok := config["webhook_retries"].(int); ok {
+		p.config.WebhookRetries = &webhookRetries
+	}
+
+	if webhookWorkers, ok := config["webhook_workers"].(int); ok {
+		p.config.WebhookWorkers = webhookWorkers
+	}
+
+	if webhookTimeout, ok := config["webhook_timeout"].(string); ok {
+		timeout, err := time.ParseDuration(webhookTimeout)
+		if err != nil {
+			return fmt.Errorf("invalid webhook_timeout: %w", err)
+		}
+		p.config.WebhookTimeout = timeout
+	}
+
 	if appCfg, ok := config["config"].(*gorestconfig.Config); ok && appCfg.Auth.Enabled && p.db != nil {
 		jwtSvc := jwt.NewService(appCfg.Auth.JWTSecret, appCfg.Auth.JWTTTL)
 		p.authMiddleware = authmiddleware.AuthMiddleware(jwtSvc, p.db)
@@ -95,6 +471,63 @@ func (p *TranslatablePlugin) SetTranslator(t Translator) {
 	p.translator = t
 }
 
+// SetTextTranslator overrides the provider used by POST /translations/:id/translate.
+func (p *TranslatablePlugin) SetTextTranslator(t TextTranslator) {
+	p.config.TextTranslator = t
+}
+
+// SetEventHandler registers a listener for translation change events.
+func (p *TranslatablePlugin) SetEventHandler(h EventHandler) {
+	p.config.EventHandler = h
+}
+
+// SetLifecycleHooks registers callbacks run around every translation write.
+func (p *TranslatablePlugin) SetLifecycleHooks(h LifecycleHooks) {
+	p.config.Hooks = h
+}
+
+// SetEventSink registers an in-process listener for translation changes.
+// Combine several with MultiEventSink.
+func (p *TranslatablePlugin) SetEventSink(s EventSink) {
+	p.config.EventSink = s
+}
+
+// SetRateLimitStore replaces the in-memory store behind Config.RateLimit, for
+// instance with one shared by every instance of the application.
+func (p *TranslatablePlugin) SetRateLimitStore(s RateLimitStore) {
+	p.config.RateLimitStore = s
+}
+
+// MetricsCollector returns the collector of the plugin metrics, to register in
+// the host's Prometheus registry, or nil unless enable_metrics is set.
+func (p *TranslatablePlugin) MetricsCollector() prometheus.Collector {
+	if p.config.metrics == nil {
+		return nil
+	}
+	return p.config.metrics
+}
+
+// MetricsHandler serves the plugin metrics in the Prometheus exposition format,
+// or is nil unless enable_metrics is set. The plugin does not mount it: the
+// host does, behind its own authentication, e.g. with fiber's adaptor package.
+func (p *TranslatablePlugin) MetricsHandler() http.Handler {
+	if p.config.metrics == nil {
+		return nil
+	}
+	return p.config.metrics.handler()
+}
+
+// SetCache replaces the in-memory store of the read cache enabled by
+// Config.CacheTTL, for a store shared across instances.
+func (p *TranslatablePlugin) SetCache(c Cache) {
+	p.config.Cache = c
+}
+
+// SetSecondaryWriter mirrors translation writes to an external store.
+func (p *TranslatablePlugin) SetSecondaryWriter(w SecondaryWriter) {
+	p.config.SecondaryWriter = w
+}
+
 func (p *TranslatablePlugin) Handler() fiber.Handler {
 	return func(c fiber.Ctx) error {
 		return c.Next()
@@ -111,7 +544,7 @@ func (p *TranslatablePlugin) SetupEndpoints(router fiber.Router) error {
 }
 
 func (p *TranslatablePlugin) MigrationSource() interface{} {
-	return migrations.GetMigrations()
+	return migrations.GetMigrations(p.config.table(), p.config.PartialIndexTypes...)
 }
 
 func (p *TranslatablePlugin) Dependencies() []string {
@@ -126,7 +559,7 @@ func (p *TranslatablePlugin) GetOpenAPIResources() []plugin.OpenAPIResource {
 	return []plugin.OpenAPIResource{{
 		Name:          "translation",
 		PluralName:    "translations",
-		BasePath:      "/translations",
+		BasePath:      p.config.routePrefix(),
 		Tags:          []string{"Translations"},
 		ResponseModel: TranslatableResponseDTO{},
 		CreateModel:   TranslatableCreateDTO{},
@@ -134,3 +567,15 @@ func (p *TranslatablePlugin) GetOpenAPIResources() []plugin.OpenAPIResource {
 		Description:   "Multi-language content translations",
 	}}
 }
+
+// stringList keeps the strings of a list read from the plugin configuration.
+func stringList(value interface{}) []string {
+	items, _ := value.([]interface{})
+	var list []string
+	for _, item := range items {
+		if str, ok := item.(string); ok {
+			list = append(list, str)
+		}
+	}
+	return list
+}
@@ -41,6 +41,13 @@ func TestTranslatablePlugin_Initialize(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "deprecated allowed_tables",
+			config: map[string]interface{}{
+				"allowed_tables": []interface{}{"posts", "articles"},
+			},
+			wantErr: false,
+		},
 	}
 
 	for _, tt := range tests {
@@ -0,0 +1,309 @@
+package translatable
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/nicolasbonnici/gorest/database"
+	"github.com/nicolasbonnici/gorest/hooks"
+)
+
+const (
+	ExportFormatPO = "po"
+
+	// poBatchSize bounds how many exported rows wait for their source content,
+	// which is looked up with one query per batch.
+	poBatchSize = 100
+)
+
+var exportFormats = []string{ExportFormatCSV, ExportFormatPO}
+
+// entityKey identifies the translations of one entity across locales.
+type entityKey struct {
+	Translatable   string
+	TranslatableID uuid.UUID
+}
+
+// sourceLookup returns the source-locale translations of the given entities.
+type sourceLookup func(ctx context.Context, keys []entityKey) (map[entityKey]Translatable, error)
+
+// writeTranslationsPO streams rows to w as a gettext catalog for locale: each
+// translation becomes an entry whose msgid is the source-locale content of its
+// entity and msgstr its own content, referenced as translatable:translatable_id.
+// Machine translations and translations of an outdated source are marked fuzzy.
+// Rows without a source translation have no msgid and are left out.
+func writeTranslationsPO(ctx context.Context, w io.Writer, rows database.Rows, serializer *translatableCRUDHooks, locale string, sources sourceLookup) error {
+	out := bufio.NewWriter(w)
+	fmt.Fprintf(out, "msgid \"\"\nmsgstr %s\n", poQuote("Content-Type: text/plain; charset=UTF-8\nLanguage: "+locale+"\n"))
+
+	batch := make([]Translatable, 0, poBatchSize)
+	flush := func() error {
+		if len(batch) == 0 {
+			return nil
+		}
+		keys := make([]entityKey, len(batch))
+		for i, t := range batch {
+			keys[i] = entityKey{Translatable: t.Translatable, TranslatableID: t.TranslatableID}
+		}
+		found, err := sources(ctx, keys)
+		if err != nil {
+			return err
+		}
+		for _, t := range batch {
+			source, ok := found[entityKey{Translatable: t.Translatable, TranslatableID: t.TranslatableID}]
+			if !ok {
+				continue
+			}
+			stale := t.SourceChecksum == nil || source.SourceChecksum == nil || *t.SourceChecksum != *source.SourceChecksum
+			if err := serializer.SerializeOne(ctx, hooks.OperationGetByID, &source); err != nil {
+				return err
+			}
+			if err := serializer.SerializeOne(ctx, hooks.OperationGetByID, &t); err != nil {
+				return err
+			}
+
+			fmt.Fprintf(out, "\n#: %s:%s\n", t.Translatable, t.TranslatableID)
+			if stale || t.AutoTranslated {
+				out.WriteString("#, fuzzy\n")
+			}
+			fmt.Fprintf(out, "msgid %s\nmsgstr %s\n", poQuote(source.Content), poQuote(t.Content))
+		}
+		batch = batch[:0]
+		return out.Flush()
+	}
+
+	for rows.Next() {
+		var t Translatable
+		if err := rows.Scan(t.scanFields()...); err != nil {
+			return err
+		}
+		batch = append(batch, t)
+		if len(batch) == poBatchSize {
+			if err := flush(); err != nil {
+				return err
+			}
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+	if err := flush(); err != nil {
+		return err
+	}
+	return out.Flush()
+}
+
+// poQuote renders s as a PO string, escaped per the gettext format. Multiline
+// values start with an empty string and continue with one string per line.
+func poQuote(s string) string {
+	if !strings.Contains(strings.TrimSuffix(s, "\n"), "\n") {
+		return poEscape(s)
+	}
+	lines := strings.SplitAfter(s, "\n")
+	var b strings.Builder
+	b.WriteString(`""`)
+	for _, line := range lines {
+		if line != "" {
+			b.WriteString("\n" + poEscape(line))
+		}
+	}
+	return b.String()
+}
+
+func poEscape(s string) string {
+	r := strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`, "\t", `\t`, "\r", `\r`)
+	return `"` + r.Replace(s) + `"`
+}
+
+// poEntry is one message of a PO file.
+type poEntry struct {
+	Line       int
+	References []entityKey
+	Fuzzy      bool
+	MsgID      string
+	MsgStr     string
+}
+
+// poCatalog is a parsed PO file. Language comes from the header entry.
+type poCatalog struct {
+	Language string
+	Entries  []poEntry
+}
+
+// readPO parses a PO file, skipping obsolete entries. Plural forms are not
+// supported. Comments after a msgstr start the next entry, so entries need not
+// be separated by blank lines.
+func readPO(r io.Reader) (*poCatalog, error) {
+	catalog := &poCatalog{}
+	var entry poEntry
+	var target *string
+	started, inMsgStr := false, false
+
+	finish := func() {
+		if started && entry.MsgID == "" {
+			for _, field := range strings.Split(entry.MsgStr, "\n") {
+				if value, ok := strings.CutPrefix(field, "Language:"); ok {
+					catalog.Language = strings.TrimSpace(value)
+				}
+			}
+		} else if started {
+			catalog.Entries = append(catalog.Entries, entry)
+		}
+		entry, target = poEntry{}, nil
+		started, inMsgStr = false, false
+	}
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if inMsgStr && (strings.HasPrefix(text, "#") || strings.HasPrefix(text, "msgctxt") || strings.HasPrefix(text, "msgid")) {
+			finish()
+		}
+
+		switch {
+		case text == "":
+			finish()
+		case strings.HasPrefix(text, "#~"):
+			// Obsolete entry.
+		case strings.HasPrefix(text, "#:"):
+			for _, ref := range strings.Fields(text[2:]) {
+				translatable, rawID, ok := strings.Cut(ref, ":")
+				id, err := uuid.Parse(rawID)
+				if !ok || err != nil {
+					return nil, &ImportFormatError{Line: line, Message: "invalid reference " + strconv.Quote(ref)}
+				}
+				entry.References = append(entry.References, entityKey{Translatable: translatable, TranslatableID: id})
+			}
+		case strings.HasPrefix(text, "#,"):
+			for _, flag := range strings.Split(text[2:], ",") {
+				if strings.TrimSpace(flag) == "fuzzy" {
+					entry.Fuzzy = true
+				}
+			}
+		case strings.HasPrefix(text, "#"):
+			// Translator and extracted comments.
+		case strings.HasPrefix(text, "msgid_plural"), strings.HasPrefix(text, "msgstr["):
+			return nil, &ImportFormatError{Line: line, Message: "plural forms are not supported"}
+		case strings.HasPrefix(text, "msgctxt "):
+			target = nil
+		case strings.HasPrefix(text, "msgid "), strings.HasPrefix(text, "msgstr "):
+			keyword, quoted, _ := strings.Cut(text, " ")
+			if keyword == "msgstr" && !started {
+				return nil, &ImportFormatError{Line: line, Message: "msgstr without msgid"}
+			}
+			value, err := poUnquote(strings.TrimSpace(quoted))
+			if err != nil {
+				return nil, &ImportFormatError{Line: line, Message: err.Error()}
+			}
+			if keyword == "msgid" {
+				started = true
+				entry.Line = line
+				target = &entry.MsgID
+			} else {
+				inMsgStr = true
+				target = &entry.MsgStr
+			}
+			*target = value
+		case strings.HasPrefix(text, `"`):
+			value, err := poUnquote(text)
+			if err != nil {
+				return nil, &ImportFormatError{Line: line, Message: err.Error()}
+			}
+			if target != nil {
+				*target += value
+			}
+		default:
+			return nil, &ImportFormatError{Line: line, Message: "unexpected " + strconv.Quote(text)}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	finish()
+	return catalog, nil
+}
+
+var errPOString = errors.New("invalid PO string")
+
+func poUnquote(s string) (string, error) {
+	if len(s) < 2 || s[0] != '"' || s[len(s)-1] != '"' {
+		return "", errPOString
+	}
+	var b strings.Builder
+	body := s[1 : len(s)-1]
+	for i := 0; i < len(body); i++ {
+		c := body[i]
+		if c == '"' {
+			return "", errPOString
+		}
+		if c != '\\' {
+			b.WriteByte(c)
+			continue
+		}
+		i++
+		if i == len(body) {
+			return "", errPOString
+		}
+		switch body[i] {
+		case 'n':
+			b.WriteByte('\n')
+		case 't':
+			b.WriteByte('\t')
+		case 'r':
+			b.WriteByte('\r')
+		case '"', '\\':
+			b.WriteByte(body[i])
+		default:
+			return "", errPOString
+		}
+	}
+	return b.String(), nil
+}
+
+// poImportRecords turns the entries of catalog into import records for locale.
+// An entry's msgid is matched against the source-locale content, narrowed to
+// its references when it has some; one record is built per matching entity.
+// Fuzzy and untranslated entries are skipped and counted.
+func (s *TranslatableService) poImportRecords(ctx context.Context, catalog *poCatalog, locale string) ([]importRecord, int, []ImportRowError, error) {
+	var records []importRecord
+	var rowErrors []ImportRowError
+	skipped := 0
+	for _, entry := range catalog.Entries {
+		if entry.Fuzzy || entry.MsgStr == "" {
+			skipped++
+			continue
+		}
+
+		keys, err := s.entitiesWithSource(ctx, entry.MsgID)
+		if err != nil {
+			return nil, 0, nil, err
+		}
+		if len(entry.References) > 0 {
+			keys = slices.DeleteFunc(keys, func(key entityKey) bool {
+				return !slices.ContainsFunc(entry.References, func(ref entityKey) bool {
+					return ref.TranslatableID == key.TranslatableID && strings.EqualFold(ref.Translatable, key.Translatable)
+				})
+			})
+		}
+		if len(keys) == 0 {
+			rowErrors = append(rowErrors, ImportRowError{Line: entry.Line, Error: "msgid does not match any " + s.config.DefaultLocale + " translation"})
+			continue
+		}
+
+		for _, key := range keys {
+			records = append(records, importRecord{
+				Line:   entry.Line,
+				Fields: []string{key.TranslatableID.String(), key.Translatable, locale, entry.MsgStr},
+			})
+		}
+	}
+	return records, skipped, rowErrors, nil
+}
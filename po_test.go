@@ -0,0 +1,311 @@
+package translatable
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"mime/multipart"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gofiber/fiber/v3"
+	"github.com/google/uuid"
+	"github.com/nicolasbonnici/gorest-translatable/mocks"
+	"github.com/nicolasbonnici/gorest/database"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPOQuote(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{name: "single line", in: `Say "hi"`, want: `"Say \"hi\""`},
+		{name: "trailing newline", in: "Hello\n", want: `"Hello\n"`},
+		{name: "escapes", in: "a\\b\tc\r", want: `"a\\b\tc\r"`},
+		{name: "multiline", in: "Hello\nWorld\n", want: "\"\"\n\"Hello\\n\"\n\"World\\n\""},
+		{name: "multiline without trailing newline", in: "Hello\nWorld", want: "\"\"\n\"Hello\\n\"\n\"World\""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, poQuote(tt.in))
+		})
+	}
+}
+
+func TestWriteTranslationsPO(t *testing.T) {
+	ids := []uuid.UUID{
+		uuid.MustParse("550e8400-e29b-41d4-a716-446655440001"),
+		uuid.MustParse("550e8400-e29b-41d4-a716-446655440002"),
+		uuid.MustParse("550e8400-e29b-41d4-a716-446655440003"),
+		uuid.MustParse("550e8400-e29b-41d4-a716-446655440004"),
+	}
+	current, outdated := ContentChecksum("Hello\nWorld"), ContentChecksum("Hello")
+	rows := mocks.NewMockRows(4)
+	rows.ScanFunc = func(row int, dest ...interface{}) error {
+		*dest[2].(*uuid.UUID) = ids[row]
+		*dest[3].(*string) = "post"
+		*dest[4].(*string) = "fr"
+		*dest[5].(*string) = []string{"Bonjour\nle \"monde\"", "Salut", "Coucou", "Orphelin"}[row]
+		*dest[9].(*bool) = row == 2
+		checksum := []string{current, outdated, current, current}[row]
+		*dest[10].(**string) = &checksum
+		return nil
+	}
+
+	sources := func(ctx context.Context, keys []entityKey) (map[entityKey]Translatable, error) {
+		assert.Len(t, keys, 4)
+		found := map[entityKey]Translatable{}
+		for _, id := range ids[:3] {
+			found[entityKey{Translatable: "post", TranslatableID: id}] = Translatable{
+				TranslatableID: id, Translatable: "post", Locale: "en", Content: "Hello\nWorld", SourceChecksum: &current,
+			}
+		}
+		return found, nil
+	}
+
+	var out bytes.Buffer
+	err := writeTranslationsPO(context.Background(), &out, rows, newTranslatableCRUDHooks(&Config{}), "fr", sources)
+
+	assert.NoError(t, err)
+	assert.Equal(t, `msgid ""
+msgstr ""
+"Content-Type: text/plain; charset=UTF-8\n"
+"Language: fr\n"
+
+#: post:550e8400-e29b-41d4-a716-446655440001
+msgid ""
+"Hello\n"
+"World"
+msgstr ""
+"Bonjour\n"
+"le \"monde\""
+
+#: post:550e8400-e29b-41d4-a716-446655440002
+#, fuzzy
+msgid ""
+"Hello\n"
+"World"
+msgstr "Salut"
+
+#: post:550e8400-e29b-41d4-a716-446655440003
+#, fuzzy
+msgid ""
+"Hello\n"
+"World"
+msgstr "Coucou"
+`, out.String())
+}
+
+func TestReadPO(t *testing.T) {
+	file := `# Translator comment
+msgid ""
+msgstr ""
+"Language: fr\n"
+
+#: post:550e8400-e29b-41d4-a716-446655440001
+msgid ""
+"Hello\n"
+"World"
+msgstr ""
+"Bonjour\n"
+"le \"monde\""
+#: post:550e8400-e29b-41d4-a716-446655440002
+#, fuzzy, c-format
+msgid "Bye"
+msgstr "Salut"
+
+#~ msgid "Old"
+#~ msgstr "Vieux"
+`
+
+	catalog, err := readPO(strings.NewReader(file))
+
+	assert.NoError(t, err)
+	assert.Equal(t, "fr", catalog.Language)
+	assert.Equal(t, []poEntry{
+		{
+			Line:       7,
+			References: []entityKey{{Translatable: "post", TranslatableID: uuid.MustParse("550e8400-e29b-41d4-a716-446655440001")}},
+			MsgID:      "Hello\nWorld",
+			MsgStr:     "Bonjour\nle \"monde\"",
+		},
+		{
+			Line:       15,
+			References: []entityKey{{Translatable: "post", TranslatableID: uuid.MustParse("550e8400-e29b-41d4-a716-446655440002")}},
+			Fuzzy:      true,
+			MsgID:      "Bye",
+			MsgStr:     "Salut",
+		},
+	}, catalog.Entries)
+}
+
+func TestReadPO_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		file    string
+		wantErr string
+	}{
+		{name: "plural", file: "msgid \"file\"\nmsgid_plural \"files\"\nmsgstr[0] \"fichier\"\n", wantErr: "line 2: plural forms are not supported"},
+		{name: "unterminated string", file: "msgid \"Hello\n", wantErr: "line 1: invalid PO string"},
+		{name: "bad reference", file: "#: post:42\nmsgid \"Hello\"\nmsgstr \"Bonjour\"\n", wantErr: `line 1: invalid reference "post:42"`},
+		{name: "msgstr first", file: "msgstr \"Bonjour\"\n", wantErr: "line 1: msgstr without msgid"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := readPO(strings.NewReader(tt.file))
+			assert.EqualError(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestTranslatableResource_ImportPO(t *testing.T) {
+	first := uuid.MustParse("550e8400-e29b-41d4-a716-446655440001")
+	second := uuid.MustParse("550e8400-e29b-41d4-a716-446655440002")
+	file := `msgid ""
+msgstr "Language: fr\n"
+
+msgid "Hello"
+msgstr "Bonjour"
+
+#: post:550e8400-e29b-41d4-a716-446655440002
+msgid "Bye"
+msgstr "Au revoir"
+
+#, fuzzy
+msgid "Later"
+msgstr "Plus tard"
+
+msgid "Untranslated"
+msgstr ""
+`
+
+	var sourceArgs [][]interface{}
+	var inserted []interface{}
+	tx := &mocks.MockTx{
+		QueryFunc: func(ctx context.Context, query string, args ...interface{}) (database.Rows, error) {
+			return mocks.NewMockRows(0), nil
+		},
+		ExecFunc: func(ctx context.Context, query string, args ...interface{}) (database.Result, error) {
+			inserted = append(inserted, args[2])
+			return mocks.NewMockResult(1), nil
+		},
+	}
+	db := &mocks.MockDatabase{
+		QueryFunc: func(ctx context.Context, query string, args ...interface{}) (database.Rows, error) {
+			sourceArgs = append(sourceArgs, args[:2])
+			// Both entities share each msgid; the reference of "Bye" narrows it to the second.
+			matches := map[string][]uuid.UUID{"Hello": {first, second}, "Bye": {first, second}}[args[1].(string)]
+			rows := mocks.NewMockRows(len(matches))
+			rows.ScanFunc = func(row int, dest ...interface{}) error {
+				*dest[0].(*string) = "post"
+				*dest[1].(*uuid.UUID) = matches[row]
+				return nil
+			}
+			return rows, nil
+		},
+		BeginFunc: func(ctx context.Context) (database.Tx, error) { return tx, nil },
+	}
+	config := DefaultConfig()
+	app, resource := setupTestApp(db, &config)
+	app.Post("/translations/import", resource.Import)
+
+	var body bytes.Buffer
+	form := multipart.NewWriter(&body)
+	part, _ := form.CreateFormFile("file", "fr.po")
+	_, _ = part.Write([]byte(file))
+	_ = form.Close()
+
+	req := httptest.NewRequest(fiber.MethodPost, "/translations/import", &body)
+	req.Header.Set(fiber.HeaderContentType, form.FormDataContentType())
+	resp, err := app.Test(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	raw, _ := io.ReadAll(resp.Body)
+
+	var report ImportReport
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+	assert.NoError(t, json.Unmarshal(raw, &report))
+	assert.Equal(t, ImportReport{Created: 3, Skipped: 2, Errors: []ImportRowError{}}, report)
+	assert.Equal(t, [][]interface{}{{"en", "Hello"}, {"en", "Bye"}}, sourceArgs)
+	assert.Equal(t, []interface{}{first, second, second}, inserted)
+	assert.True(t, tx.Committed)
+}
+
+func TestTranslatableResource_ImportPO_UnmatchedMsgid(t *testing.T) {
+	db := &mocks.MockDatabase{
+		QueryFunc: func(ctx context.Context, query string, args ...interface{}) (database.Rows, error) {
+			return mocks.NewMockRows(0), nil
+		},
+	}
+	config := DefaultConfig()
+	app, resource := setupTestApp(db, &config)
+	app.Post("/translations/import", resource.Import)
+
+	var body bytes.Buffer
+	form := multipart.NewWriter(&body)
+	_ = form.WriteField("format", ExportFormatPO)
+	_ = form.WriteField("locale", "es")
+	part, _ := form.CreateFormFile("file", "upload.txt")
+	_, _ = part.Write([]byte("msgid \"Hello\"\nmsgstr \"Hola\"\n"))
+	_ = form.Close()
+
+	req := httptest.NewRequest(fiber.MethodPost, "/translations/import", &body)
+	req.Header.Set(fiber.HeaderContentType, form.FormDataContentType())
+	resp, err := app.Test(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	raw, _ := io.ReadAll(resp.Body)
+
+	var report ImportReport
+	assert.Equal(t, fiber.StatusUnprocessableEntity, resp.StatusCode)
+	assert.NoError(t, json.Unmarshal(raw, &report))
+	assert.Equal(t, []ImportRowError{{Line: 1, Error: "msgid does not match any en translation"}}, report.Errors)
+}
+
+func TestTranslatableResource_ExportPO(t *testing.T) {
+	var queries []string
+	db := &mocks.MockDatabase{
+		QueryFunc: func(ctx context.Context, query string, args ...interface{}) (database.Rows, error) {
+			queries = append(queries, query)
+			rows := mocks.NewMockRows(1)
+			rows.ScanFunc = func(row int, dest ...interface{}) error {
+				*dest[3].(*string) = "post"
+				*dest[4].(*string) = []string{"fr", "en"}[len(queries)-1]
+				*dest[5].(*string) = []string{"Bonjour", "Hello"}[len(queries)-1]
+				return nil
+			}
+			return rows, nil
+		},
+	}
+	config := DefaultConfig()
+	app, resource := setupTestApp(db, &config)
+	app.Get("/translations/export", resource.Export)
+
+	resp, err := app.Test(httptest.NewRequest(fiber.MethodGet, "/translations/export?format=po&locale=fr", nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	body, _ := io.ReadAll(resp.Body)
+
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+	assert.Equal(t, "text/x-gettext-translation; charset=utf-8", resp.Header.Get(fiber.HeaderContentType))
+	assert.Equal(t, `attachment; filename="translations.po"`, resp.Header.Get(fiber.HeaderContentDisposition))
+	assert.Contains(t, string(body), "#, fuzzy\nmsgid \"Hello\"\nmsgstr \"Bonjour\"\n")
+	assert.Len(t, queries, 2)
+
+	for _, target := range []string{"/translations/export?format=po", "/translations/export?format=po&locale=en"} {
+		resp, err = app.Test(httptest.NewRequest(fiber.MethodGet, target, nil))
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode, target)
+	}
+}
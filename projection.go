@@ -0,0 +1,95 @@
+package translatable
+
+import (
+	"encoding/json"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/gofiber/fiber/v3"
+)
+
+// projectableFields lists the JSON keys of a served translation, the values
+// ?fields= accepts.
+var projectableFields = func() []string {
+	var keys []string
+	dto := reflect.TypeFor[TranslatableResponseDTO]()
+	for i := 0; i < dto.NumField(); i++ {
+		key, _, _ := strings.Cut(dto.Field(i).Tag.Get("json"), ",")
+		keys = append(keys, key)
+	}
+	return keys
+}()
+
+// parseFieldsParam reads the comma-separated ?fields= projection. No parameter
+// returns nil, meaning every field is served.
+func parseFieldsParam(c fiber.Ctx) ([]string, *AllowedValuesError) {
+	param := c.Query("fields")
+	if param == "" {
+		return nil, nil
+	}
+
+	var fields []string
+	for _, field := range strings.Split(param, ",") {
+		field = strings.TrimSpace(field)
+		if !slices.Contains(projectableFields, field) {
+			return nil, &AllowedValuesError{
+				Message: "unknown field: " + field,
+				Code:    CodeInvalidField,
+				Allowed: projectableFields,
+				Params:  map[string]string{"field": field},
+			}
+		}
+		fields = append(fields, field)
+	}
+	return fields, nil
+}
+
+// projectResponse trims a successful JSON body, a translation or a collection
+// of them, to fields. JSON-LD keywords such as @id are always kept so
+// projected documents stay linked.
+func projectResponse(c fiber.Ctx, fields []string) error {
+	if fields == nil || c.Response().StatusCode() != fiber.StatusOK {
+		return nil
+	}
+
+	var body map[string]json.RawMessage
+	if err := json.Unmarshal(c.Response().Body(), &body); err != nil {
+		return nil
+	}
+
+	projected := false
+	for _, key := range []string{"hydra:member", "data"} {
+		var members []map[string]json.RawMessage
+		if err := json.Unmarshal(body[key], &members); err != nil {
+			continue
+		}
+		for _, member := range members {
+			project(member, fields)
+		}
+		encoded, err := json.Marshal(members)
+		if err != nil {
+			return err
+		}
+		body[key] = encoded
+		projected = true
+	}
+	if !projected {
+		project(body, fields)
+	}
+
+	encoded, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+	c.Response().SetBodyRaw(encoded)
+	return nil
+}
+
+func project(document map[string]json.RawMessage, fields []string) {
+	for key := range document {
+		if !strings.HasPrefix(key, "@") && !slices.Contains(fields, key) {
+			delete(document, key)
+		}
+	}
+}
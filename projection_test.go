@@ -0,0 +1,119 @@
+package translatable
+
+import (
+	"context"
+	"encoding/json"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v3"
+	"github.com/google/uuid"
+	"github.com/nicolasbonnici/gorest-translatable/mocks"
+	"github.com/nicolasbonnici/gorest/database"
+	"github.com/stretchr/testify/assert"
+)
+
+func projectionDatabase(id uuid.UUID) *mocks.MockDatabase {
+	scan := func(dest ...interface{}) {
+		*dest[0].(*uuid.UUID) = id
+		*dest[3].(*string) = "post"
+		*dest[4].(*string) = "fr"
+		*dest[5].(*string) = "Bonjour"
+		*dest[16].(*int) = 1
+	}
+	return &mocks.MockDatabase{
+		QueryFunc: func(ctx context.Context, query string, args ...interface{}) (database.Rows, error) {
+			rows := mocks.NewMockRows(1)
+			rows.ScanFunc = func(row int, dest ...interface{}) error {
+				scan(dest...)
+				return nil
+			}
+			return rows, nil
+		},
+		QueryRowFunc: func(ctx context.Context, query string, args ...interface{}) database.Row {
+			return &mocks.MockRow{ScanFunc: func(dest ...interface{}) error {
+				if len(dest) == 1 {
+					*dest[0].(*int) = 1
+					return nil
+				}
+				scan(dest...)
+				return nil
+			}}
+		},
+	}
+}
+
+func TestTranslatableResource_GetByID_Fields_Projection(t *testing.T) {
+	id := uuid.New()
+	config := DefaultConfig()
+	app, resource := setupTestApp(projectionDatabase(id), &config)
+	app.Get("/translations/:id", resource.GetByID)
+
+	resp, err := app.Test(httptest.NewRequest(fiber.MethodGet, "/translations/"+id.String()+"?fields=id,locale,content", nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+	var body map[string]interface{}
+	assert.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+	assert.Equal(t, id.String(), body["id"])
+	assert.Equal(t, "fr", body["locale"])
+	assert.Equal(t, "Bonjour", body["content"])
+	assert.Contains(t, body, "@id")
+	assert.NotContains(t, body, "created_at")
+	assert.NotContains(t, body, "version")
+}
+
+func TestTranslatableResource_GetAll_Fields_Projection(t *testing.T) {
+	id := uuid.New()
+	config := DefaultConfig()
+	app, resource := setupTestApp(projectionDatabase(id), &config)
+	app.Get("/translations", resource.GetAll)
+
+	resp, err := app.Test(httptest.NewRequest(fiber.MethodGet, "/translations?fields=locale", nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+	var body struct {
+		TotalItems int                      `json:"hydra:totalItems"`
+		Members    []map[string]interface{} `json:"hydra:member"`
+	}
+	assert.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+	assert.Equal(t, 1, body.TotalItems)
+	if assert.Len(t, body.Members, 1) {
+		assert.Equal(t, "fr", body.Members[0]["locale"])
+		assert.NotContains(t, body.Members[0], "content")
+		assert.NotContains(t, body.Members[0], "id")
+	}
+}
+
+func TestTranslatableResource_Fields_Invalid(t *testing.T) {
+	queried := false
+	db := &mocks.MockDatabase{
+		QueryFunc: func(ctx context.Context, query string, args ...interface{}) (database.Rows, error) {
+			queried = true
+			return mocks.NewMockRows(0), nil
+		},
+	}
+	config := DefaultConfig()
+	app, resource := setupTestApp(db, &config)
+	app.Get("/translations", resource.GetAll)
+	app.Get("/translations/:id", resource.GetByID)
+
+	for _, path := range []string{"/translations?fields=id,password", "/translations/" + uuid.NewString() + "?fields=password"} {
+		resp, err := app.Test(httptest.NewRequest(fiber.MethodGet, path, nil))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+		var problem ProblemDetails
+		assert.NoError(t, json.NewDecoder(resp.Body).Decode(&problem))
+		assert.Equal(t, CodeInvalidField, problem.Code)
+		assert.Equal(t, "unknown field: password", problem.Detail)
+	}
+	assert.False(t, queried)
+}
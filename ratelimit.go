@@ -0,0 +1,153 @@
+package translatable
+
+import (
+	"context"
+	"errors"
+	"math"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/gofiber/fiber/v3"
+)
+
+// RateLimitConfig caps each client at Requests write requests per Window.
+// Clients are told apart by their user id when authenticated, their IP
+// otherwise. A zero Requests disables the limit.
+type RateLimitConfig struct {
+	Requests int           `json:"requests" yaml:"requests"`
+	Window   time.Duration `json:"window" yaml:"window"`
+}
+
+// RateLimitStore keeps the request budget of each client key. Take spends cost
+// requests from key's budget of limit per window. A request is allowed while
+// the budget holds at least one request, and may then spend it into debt, so
+// a bulk write is admitted whole and the client waits until its rows are paid
+// back. Once the budget is exhausted, Take spends nothing and reports how long
+// until the next request will be accepted. Implementations backed by a shared
+// store such as Redis apply the limit across instances.
+type RateLimitStore interface {
+	Take(ctx context.Context, key string, cost, limit int, window time.Duration) (allowed bool, retryAfter time.Duration, err error)
+}
+
+// MemoryRateLimitStore is a token bucket RateLimitStore local to the process.
+// Each key holds up to limit tokens, refilled evenly over window.
+type MemoryRateLimitStore struct {
+	mu      sync.Mutex
+	buckets map[string]*tokenBucket
+	swept   time.Time
+	now     func() time.Time
+}
+
+type tokenBucket struct {
+	tokens float64
+	last   time.Time
+}
+
+func NewMemoryRateLimitStore() *MemoryRateLimitStore {
+	return &MemoryRateLimitStore{buckets: make(map[string]*tokenBucket), now: time.Now}
+}
+
+func (s *MemoryRateLimitStore) Take(ctx context.Context, key string, cost, limit int, window time.Duration) (bool, time.Duration, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	rate := float64(limit) / window.Seconds()
+	s.sweep(now, rate, limit, window)
+
+	bucket, ok := s.buckets[key]
+	if !ok {
+		bucket = &tokenBucket{tokens: float64(limit), last: now}
+		s.buckets[key] = bucket
+	}
+	bucket.tokens = min(float64(limit), bucket.tokens+now.Sub(bucket.last).Seconds()*rate)
+	bucket.last = now
+
+	if bucket.tokens < 1 {
+		return false, time.Duration((1 - bucket.tokens) / rate * float64(time.Second)), nil
+	}
+	bucket.tokens -= float64(cost)
+	return true, 0, nil
+}
+
+// sweep drops, at most once per window, the buckets refilled since their last
+// request: they are the same as no bucket at all. Buckets in debt are kept
+// until it is paid back.
+func (s *MemoryRateLimitStore) sweep(now time.Time, rate float64, limit int, window time.Duration) {
+	if now.Sub(s.swept) < window {
+		return
+	}
+	for key, bucket := range s.buckets {
+		if bucket.tokens+now.Sub(bucket.last).Seconds()*rate >= float64(limit) {
+			delete(s.buckets, key)
+		}
+	}
+	s.swept = now
+}
+
+// rateLimited guards a write handler with Config.RateLimit, answering 429 with
+// a Retry-After header once the client has spent its budget. It returns next
+// unchanged when no limit is configured.
+func rateLimited(config *Config, next fiber.Handler) fiber.Handler {
+	if !config.rateLimits() {
+		return next
+	}
+
+	return func(c fiber.Ctx) error {
+		if limited, err := takeRateLimit(c, config, 1); limited {
+			return err
+		}
+		return next(c)
+	}
+}
+
+// chargeRows spends the rows of a bulk write, beyond the one request
+// rateLimited already took, from the client's budget. It reports whether the
+// client is over its budget, in which case the 429 has been sent and nothing
+// must be written.
+func chargeRows(c fiber.Ctx, config *Config, rows int) (bool, error) {
+	if !config.rateLimits() || rows <= 1 {
+		return false, nil
+	}
+	return takeRateLimit(c, config, rows-1)
+}
+
+// takeRateLimit spends cost from the budget of the client of c, sending 429
+// when it is exhausted.
+func takeRateLimit(c fiber.Ctx, config *Config, cost int) (bool, error) {
+	if allowed, retryAfter := spendRateLimit(c, config, cost); !allowed {
+		c.Set(fiber.HeaderRetryAfter, strconv.Itoa(int(math.Ceil(retryAfter.Seconds()))))
+		return true, sendCodedError(c, fiber.StatusTooManyRequests, CodeRateLimited, "Too many requests")
+	}
+	return false, nil
+}
+
+// spendRateLimit spends cost from the budget of the client of c, keyed by user
+// or else by IP, and reports whether it was available. Store failures let the
+// request through rather than take writes down with the store.
+func spendRateLimit(c fiber.Ctx, config *Config, cost int) (bool, time.Duration) {
+	key := "ip:" + c.IP()
+	if userID := getUserIDFromFiberContext(c); userID != nil {
+		key = "user:" + userID.String()
+	}
+
+	limit := config.RateLimit
+	allowed, retryAfter, err := config.RateLimitStore.Take(c.Context(), key, cost, limit.Requests, limit.Window)
+	if err != nil {
+		requestLogger(c.Context()).Warn("rate limit store failed", "key", key, "error", err)
+		return true, 0
+	}
+	return allowed, retryAfter
+}
+
+func (c *Config) rateLimits() bool {
+	return c.RateLimit.Requests > 0 && c.RateLimitStore != nil
+}
+
+func (c *Config) validateRateLimit() error {
+	if c.RateLimit.Requests < 0 {
+		return errors.New("rate_limit requests cannot be negative")
+	}
+	return nil
+}
@@ -0,0 +1,194 @@
+package translatable
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v3"
+	"github.com/nicolasbonnici/gorest-translatable/mocks"
+	"github.com/nicolasbonnici/gorest/database"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRateLimit_RejectsWritesOverTheLimit(t *testing.T) {
+	db := &mocks.MockDatabase{
+		QueryRowFunc: func(ctx context.Context, query string, args ...interface{}) database.Row {
+			return &mocks.MockRow{ScanFunc: func(dest ...interface{}) error { return nil }}
+		},
+		QueryFunc: func(ctx context.Context, query string, args ...interface{}) (database.Rows, error) {
+			return mocks.NewMockRows(0), nil
+		},
+		ExecFunc: func(ctx context.Context, query string, args ...interface{}) (database.Result, error) {
+			return mocks.NewMockResult(1), nil
+		},
+	}
+	config := DefaultConfig()
+	config.RateLimit = RateLimitConfig{Requests: 2, Window: time.Minute}
+	if err := config.Validate(); err != nil {
+		t.Fatal(err)
+	}
+	app, resource := setupTestApp(db, &config)
+	app.Post("/translations", rateLimited(&config, resource.Create))
+	app.Get("/translations", resource.GetAll)
+
+	send := func(req *http.Request) *http.Response {
+		resp, err := app.Test(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return resp
+	}
+	create := func() *http.Response {
+		req := httptest.NewRequest(fiber.MethodPost, "/translations", strings.NewReader(`{"translatableId":"550e8400-e29b-41d4-a716-446655440000","translatable":"post","locale":"fr","content":"Bonjour"}`))
+		req.Header.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+		return send(req)
+	}
+
+	assert.Equal(t, fiber.StatusCreated, create().StatusCode)
+	assert.Equal(t, fiber.StatusCreated, create().StatusCode)
+	resp := create()
+	assert.Equal(t, fiber.StatusTooManyRequests, resp.StatusCode)
+	assert.Equal(t, "30", resp.Header.Get(fiber.HeaderRetryAfter))
+
+	for range 3 {
+		assert.Equal(t, fiber.StatusOK, send(httptest.NewRequest(fiber.MethodGet, "/translations", nil)).StatusCode)
+	}
+}
+
+func TestMemoryRateLimitStore_Take(t *testing.T) {
+	now := time.Now()
+	store := NewMemoryRateLimitStore()
+	store.now = func() time.Time { return now }
+	ctx := context.Background()
+
+	for range 3 {
+		allowed, _, err := store.Take(ctx, "user:a", 1, 3, time.Minute)
+		assert.NoError(t, err)
+		assert.True(t, allowed)
+	}
+	allowed, retryAfter, _ := store.Take(ctx, "user:a", 1, 3, time.Minute)
+	assert.False(t, allowed)
+	assert.Equal(t, 20*time.Second, retryAfter)
+
+	allowed, _, _ = store.Take(ctx, "user:b", 1, 3, time.Minute)
+	assert.True(t, allowed, "keys have separate budgets")
+
+	now = now.Add(20 * time.Second)
+	allowed, _, _ = store.Take(ctx, "user:a", 1, 3, time.Minute)
+	assert.True(t, allowed, "a token is refilled after window/limit")
+	allowed, _, _ = store.Take(ctx, "user:a", 1, 3, time.Minute)
+	assert.False(t, allowed)
+}
+
+func TestMemoryRateLimitStore_TakeCost(t *testing.T) {
+	now := time.Now()
+	store := NewMemoryRateLimitStore()
+	store.now = func() time.Time { return now }
+	ctx := context.Background()
+
+	allowed, _, err := store.Take(ctx, "user:a", 5, 3, time.Minute)
+	assert.NoError(t, err)
+	assert.True(t, allowed, "a bulk write larger than the budget is admitted")
+	allowed, retryAfter, _ := store.Take(ctx, "user:a", 1, 3, time.Minute)
+	assert.False(t, allowed)
+	assert.Equal(t, time.Minute, retryAfter, "the debt is paid back first")
+
+	now = now.Add(61 * time.Second)
+	allowed, _, _ = store.Take(ctx, "user:b", 1, 3, time.Minute)
+	assert.True(t, allowed)
+	assert.Contains(t, store.buckets, "user:a", "buckets in debt survive a sweep")
+}
+
+func TestRateLimit_ChargesBulkRows(t *testing.T) {
+	config := DefaultConfig()
+	config.RateLimit = RateLimitConfig{Requests: 3, Window: time.Minute}
+	if err := config.Validate(); err != nil {
+		t.Fatal(err)
+	}
+	app := fiber.New()
+	app.Post("/bulk", rateLimited(&config, func(c fiber.Ctx) error {
+		if limited, err := chargeRows(c, &config, 3); limited {
+			return err
+		}
+		return c.SendStatus(fiber.StatusNoContent)
+	}))
+
+	for _, code := range []int{fiber.StatusNoContent, fiber.StatusTooManyRequests} {
+		resp, err := app.Test(httptest.NewRequest(fiber.MethodPost, "/bulk", nil))
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.Equal(t, code, resp.StatusCode)
+	}
+}
+
+func TestRateLimit_MutatingRoutes(t *testing.T) {
+	id := "550e8400-e29b-41d4-a716-446655440000"
+	routes := []struct{ method, path string }{
+		{fiber.MethodPost, "/translations"},
+		{fiber.MethodPut, "/translations"},
+		{fiber.MethodPut, "/translations/" + id},
+		{fiber.MethodPatch, "/translations/" + id},
+		{fiber.MethodDelete, "/translations/" + id},
+		{fiber.MethodPost, "/translations/import"},
+		{fiber.MethodPut, "/translations/" + id + "/locales"},
+		{fiber.MethodPost, "/translations/copy"},
+		{fiber.MethodPost, "/translations/clone-entity"},
+		{fiber.MethodPost, "/translations/" + id + "/fan-out"},
+		{fiber.MethodPost, "/translations/" + id + "/restore"},
+		{fiber.MethodPost, "/translations/" + id + "/translate"},
+		{fiber.MethodPost, "/translations/post/" + id + "/translate"},
+		{fiber.MethodPost, "/translations/" + id + "/review"},
+		{fiber.MethodPost, "/translations/" + id + "/publish"},
+		{fiber.MethodPost, "/translations/" + id + "/approve"},
+		{fiber.MethodPost, "/translations/" + id + "/unpublish"},
+	}
+
+	for _, route := range routes {
+		t.Run(route.method+" "+route.path, func(t *testing.T) {
+			config := DefaultConfig()
+			config.RateLimit = RateLimitConfig{Requests: 1, Window: time.Minute}
+			if err := config.Validate(); err != nil {
+				t.Fatal(err)
+			}
+			app := fiber.New()
+			RegisterTranslatableRoutes(app, &mocks.MockDatabase{}, &config, nil, nil)
+
+			codes := make([]int, 2)
+			for i := range codes {
+				resp, err := app.Test(httptest.NewRequest(route.method, route.path, nil))
+				if err != nil {
+					t.Fatal(err)
+				}
+				codes[i] = resp.StatusCode
+			}
+			assert.NotEqual(t, fiber.StatusTooManyRequests, codes[0])
+			assert.Equal(t, fiber.StatusTooManyRequests, codes[1])
+		})
+	}
+}
+
+type failingRateLimitStore struct{}
+
+func (failingRateLimitStore) Take(ctx context.Context, key string, cost, limit int, window time.Duration) (bool, time.Duration, error) {
+	return false, 0, errors.New("redis down")
+}
+
+func TestRateLimit_StoreFailureLetsRequestsThrough(t *testing.T) {
+	config := DefaultConfig()
+	config.RateLimit = RateLimitConfig{Requests: 1, Window: time.Minute}
+	config.RateLimitStore = failingRateLimitStore{}
+	app := fiber.New()
+	app.Post("/translations", rateLimited(&config, func(c fiber.Ctx) error { return c.SendStatus(fiber.StatusCreated) }))
+
+	resp, err := app.Test(httptest.NewRequest(fiber.MethodPost, "/translations", nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, fiber.StatusCreated, resp.StatusCode)
+}
@@ -0,0 +1,36 @@
+package translatable
+
+import (
+	"context"
+	"time"
+
+	"github.com/gofiber/fiber/v3"
+)
+
+const receivedAtKey contextKey = "translatable_received_at"
+
+// receivedAtMiddleware records when the request entered the plugin, before any
+// body parsing or database work, for Config.TrackReceivedAt.
+func receivedAtMiddleware(c fiber.Ctx) error {
+	c.SetContext(context.WithValue(c.Context(), receivedAtKey, time.Now()))
+	return c.Next()
+}
+
+// receivedAt returns the time recorded by receivedAtMiddleware, or now when the
+// request did not go through it.
+func receivedAt(ctx context.Context) time.Time {
+	if t, ok := ctx.Value(receivedAtKey).(time.Time); ok {
+		return t
+	}
+	return time.Now()
+}
+
+// trackReceivedAt returns the receive time to store on new translations, or nil
+// unless Config.TrackReceivedAt is set.
+func (h *TranslatableHooks) trackReceivedAt(ctx context.Context) *time.Time {
+	if !h.config.TrackReceivedAt {
+		return nil
+	}
+	t := receivedAt(ctx)
+	return &t
+}
@@ -0,0 +1,41 @@
+package translatable
+
+import (
+	"context"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v3"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTranslatableHooks_TrackReceivedAt(t *testing.T) {
+	config := DefaultConfig()
+	h := NewTranslatableHooks(nil, &config)
+	assert.Nil(t, h.trackReceivedAt(context.Background()))
+
+	config.TrackReceivedAt = true
+	var received *time.Time
+	var handled time.Time
+	app := fiber.New()
+	app.Use(receivedAtMiddleware)
+	app.Post("/translations", func(c fiber.Ctx) error {
+		time.Sleep(5 * time.Millisecond)
+		handled = time.Now()
+		received = h.trackReceivedAt(c.Context())
+		return c.SendStatus(fiber.StatusCreated)
+	})
+
+	before := time.Now()
+	resp, err := app.Test(httptest.NewRequest(fiber.MethodPost, "/translations", nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, fiber.StatusCreated, resp.StatusCode)
+	if assert.NotNil(t, received) {
+		assert.False(t, received.Before(before))
+		assert.GreaterOrEqual(t, handled.Sub(*received), 5*time.Millisecond)
+	}
+}
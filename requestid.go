@@ -0,0 +1,76 @@
+package translatable
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+
+	"github.com/gofiber/fiber/v3"
+	"github.com/google/uuid"
+	"github.com/nicolasbonnici/gorest/logger"
+)
+
+const (
+	HeaderRequestID = "X-Request-Id"
+
+	requestIDKey       contextKey = "translatable_request_id"
+	maxRequestIDLength            = 128
+)
+
+// requestIDMiddleware reuses the caller's X-Request-Id, or generates one, and
+// threads it through the request context, the response headers and error bodies.
+func requestIDMiddleware(c fiber.Ctx) error {
+	id := c.Get(HeaderRequestID)
+	if !validRequestID(id) {
+		id = uuid.NewString()
+	}
+
+	c.Set(HeaderRequestID, id)
+	c.SetContext(withRequestID(c.Context(), id))
+
+	err := c.Next()
+	if err == nil {
+		return nil
+	}
+
+	var fiberErr *fiber.Error
+	if errors.As(err, &fiberErr) {
+		return sendFiberError(c, fiberErr, err)
+	}
+	if errors.Is(err, context.DeadlineExceeded) {
+		return sendFiberError(c, errQueryTimeout, err)
+	}
+	requestLogger(c.Context()).Error("unhandled error", "error", err)
+	return sendError(c, fiber.StatusInternalServerError, "Internal server error")
+}
+
+// validRequestID accepts client-supplied ids only when they are short and made
+// of printable ASCII, so they are safe to echo in headers and logs.
+func validRequestID(id string) bool {
+	if id == "" || len(id) > maxRequestIDLength {
+		return false
+	}
+	for i := 0; i < len(id); i++ {
+		if id[i] < 0x21 || id[i] > 0x7e {
+			return false
+		}
+	}
+	return true
+}
+
+func withRequestID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, requestIDKey, id)
+}
+
+func requestIDFromContext(ctx context.Context) string {
+	id, _ := ctx.Value(requestIDKey).(string)
+	return id
+}
+
+// requestLogger returns the plugin logger annotated with the request id, if any.
+func requestLogger(ctx context.Context) *slog.Logger {
+	if id := requestIDFromContext(ctx); id != "" {
+		return logger.Log.With("request_id", id)
+	}
+	return logger.Log
+}
@@ -0,0 +1,73 @@
+package translatable
+
+import (
+	"encoding/json"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gofiber/fiber/v3"
+	"github.com/nicolasbonnici/gorest-translatable/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRequestIDMiddleware(t *testing.T) {
+	app := fiber.New()
+	app.Use(requestIDMiddleware)
+	var seen string
+	app.Get("/translations/:id", func(c fiber.Ctx) error {
+		seen = requestIDFromContext(c.Context())
+		return fiber.NewError(fiber.StatusNotFound, "Translation not found")
+	})
+
+	tests := []struct {
+		name     string
+		incoming string
+		reused   bool
+	}{
+		{name: "generated when absent", incoming: "", reused: false},
+		{name: "reused from client", incoming: "client-req-42", reused: true},
+		{name: "replaced when unsafe", incoming: "bad id\twith spaces", reused: false},
+		{name: "replaced when too long", incoming: strings.Repeat("a", maxRequestIDLength+1), reused: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(fiber.MethodGet, "/translations/abc", nil)
+			if tt.incoming != "" {
+				req.Header.Set(HeaderRequestID, tt.incoming)
+			}
+			resp, err := app.Test(req)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			id := resp.Header.Get(HeaderRequestID)
+			assert.NotEmpty(t, id)
+			assert.Equal(t, id, seen)
+			if tt.reused {
+				assert.Equal(t, tt.incoming, id)
+			} else {
+				assert.NotEqual(t, tt.incoming, id)
+			}
+
+			var body ProblemDetails
+			assert.Equal(t, fiber.StatusNotFound, resp.StatusCode)
+			assert.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+			assert.Equal(t, "Translation not found", body.Detail)
+			assert.Equal(t, id, body.RequestID)
+		})
+	}
+}
+
+func TestRegisterTranslatableRoutes_RequestID(t *testing.T) {
+	app := fiber.New()
+	config := DefaultConfig()
+	RegisterTranslatableRoutes(app, &mocks.MockDatabase{}, &config, nil, nil)
+
+	resp, err := app.Test(httptest.NewRequest(fiber.MethodGet, "/locales", nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.NotEmpty(t, resp.Header.Get(HeaderRequestID))
+}
@@ -1,96 +1,1088 @@
 package translatable
 
 import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
 	"github.com/gofiber/fiber/v3"
 	"github.com/google/uuid"
+	auth "github.com/nicolasbonnici/gorest/auth"
 	"github.com/nicolasbonnici/gorest/crud"
 	"github.com/nicolasbonnici/gorest/database"
+	"github.com/nicolasbonnici/gorest/pagination"
 	"github.com/nicolasbonnici/gorest/processor"
+	"github.com/nicolasbonnici/gorest/response"
 )
 
+var translatableFieldMap = map[string]string{
+	"id":              "id",
+	"user_id":         "user_id",
+	"translatable_id": "translatable_id",
+	"translatable":    "translatable",
+	"locale":          "locale",
+	"content":         "content",
+	"published_at":    "published_at",
+	"expires_at":      "expires_at",
+	"updated_at":      "updated_at",
+	"created_at":      "created_at",
+	"status":          "status",
+}
+
+var translatableAllowedFields = []string{"id", "user_id", "translatable_id", "translatable", "locale", "content", "published_at", "expires_at", "updated_at", "created_at", "status"}
+
 type TranslatableResource struct {
 	processor      processor.Processor[Translatable, TranslatableCreateDTO, TranslatableUpdateDTO, TranslatableResponseDTO]
+	config         *Config
 	service        *TranslatableService
+	converter      *TranslatableConverter
 	translator     *Translator
+	snapshots      *snapshotRegistry
 	authMiddleware fiber.Handler
 }
 
 func RegisterTranslatableRoutes(router fiber.Router, db database.Database, config *Config, translator *Translator, authMiddleware fiber.Handler) {
-	service := NewTranslatableService(db, config)
+	resource := &TranslatableResource{
+		processor:      newTranslatableProcessor(db, config),
+		config:         config,
+		service:        NewTranslatableService(db, config),
+		converter:      &TranslatableConverter{config: config},
+		translator:     translator,
+		snapshots:      newSnapshotRegistry(db, config.MaxSnapshots, config.SnapshotTTL),
+		authMiddleware: authMiddleware,
+	}
 
-	translatableCRUD := crud.New[Translatable](db)
-	hooks := NewTranslatableHooks(db, config)
-	converter := &TranslatableConverter{}
+	prefix := config.routePrefix()
+	if len(config.CORS.AllowOrigins) > 0 {
+		router.Use([]string{prefix, "/locales"}, corsMiddleware(config.CORS))
+	}
+	if config.LegacyErrors {
+		router.Use([]string{prefix, "/locales"}, legacyErrorsMiddleware)
+	}
+	if config.JSONNaming != "" {
+		router.Use([]string{prefix, "/locales"}, jsonNamingMiddleware(config))
+	}
+	router.Use([]string{prefix, "/locales"}, requestIDMiddleware, messagesMiddleware(config), optionsMiddleware)
+	if config.TenantScoped {
+		router.Use(prefix, tenantMiddleware)
+	}
+	if config.TrackReceivedAt {
+		router.Use(prefix, receivedAtMiddleware)
+	}
+
+	router.Post(prefix, rateLimited(config, resource.Create))
+	if authMiddleware != nil {
+		router.Post(prefix+"/clone-entity", authMiddleware, rateLimited(config, resource.CloneEntity))
+		router.Post(prefix+"/copy", authMiddleware, rateLimited(config, resource.CopyLocale))
+		router.Get(prefix+"/snapshot", authMiddleware, resource.OpenSnapshot)
+		router.Delete(prefix+"/snapshot/:token", authMiddleware, resource.CloseSnapshot)
+		router.Get(prefix+"/trash", authMiddleware, resource.Trash)
+	} else {
+		router.Post(prefix+"/clone-entity", rateLimited(config, resource.CloneEntity))
+		router.Post(prefix+"/copy", rateLimited(config, resource.CopyLocale))
+		router.Get(prefix+"/snapshot", resource.OpenSnapshot)
+		router.Delete(prefix+"/snapshot/:token", resource.CloseSnapshot)
+		router.Get(prefix+"/trash", resource.Trash)
+	}
+	if config.metrics != nil {
+		config.metrics.db = resource.service.db
+	}
+	router.Get(prefix+"/resolve", resource.Resolve)
+	router.Get(prefix+"/schema", resource.GetSchema)
+	router.Get(prefix+"/stale", resource.Stale)
+	router.Get(prefix+"/untranslated", resource.Untranslated)
+	router.Get(prefix+"/capabilities", resource.GetCapabilities)
+	router.Get(prefix+"/export", resource.Export)
+	router.Post(prefix+"/import", rateLimited(config, resource.Import))
+	router.Post(prefix+"/batch-get", resource.BatchGet)
+	router.Post(prefix+"/validate", resource.Validate)
+	router.Get(prefix+"/count", resource.Count)
+	router.Get(prefix+"/openapi.json", resource.OpenAPI)
+	router.Get(prefix+"/exists", resource.Exists)
+	router.Get(prefix+"/lookup", resource.Lookup)
+	router.Get(prefix+"/:id", resource.GetByID)
+	router.Get(prefix, resource.GetAll)
+	router.Put(prefix, rateLimited(config, resource.Upsert))
+	router.Head(prefix, resource.HeadAll)
+	router.Put(prefix+"/:id", rateLimited(config, resource.Update))
+	router.Patch(prefix+"/:id", rateLimited(config, resource.Patch))
+	router.Get(prefix+"/:translatable_id/locales", resource.EntityLocales)
+	router.Put(prefix+"/:translatable_id/locales", rateLimited(config, resource.ReplaceLocales))
+	router.Get(prefix+"/:translatable_id/completeness", resource.Completeness)
+	router.Delete(prefix+"/:id", rateLimited(config, resource.Delete))
+	router.Get("/locales", resource.GetLocales)
 
-	fieldMapping := map[string]string{
-		"id":              "id",
-		"user_id":         "user_id",
-		"translatable_id": "translatable_id",
-		"translatable":    "translatable",
-		"locale":          "locale",
-		"content":         "content",
-		"updated_at":      "updated_at",
-		"created_at":      "created_at",
+	if authMiddleware != nil {
+		router.Post(prefix+"/:type/:id/translate", authMiddleware, rateLimited(config, resource.Translate))
+		router.Post(prefix+"/:id/translate", authMiddleware, rateLimited(config, resource.TranslateTo))
+		router.Post(prefix+"/:translatable_id/fan-out", authMiddleware, rateLimited(config, resource.FanOut))
+		router.Post(prefix+"/:id/review", authMiddleware, rateLimited(config, resource.SubmitForReview))
+		router.Post(prefix+"/:id/publish", authMiddleware, rateLimited(config, resource.Publish))
+		router.Post(prefix+"/:id/approve", authMiddleware, rateLimited(config, resource.Approve))
+		router.Post(prefix+"/:id/unpublish", authMiddleware, rateLimited(config, resource.Unpublish))
+		router.Post(prefix+"/:id/restore", authMiddleware, rateLimited(config, resource.Restore))
+	} else {
+		router.Post(prefix+"/:type/:id/translate", rateLimited(config, resource.Translate))
+		router.Post(prefix+"/:id/translate", rateLimited(config, resource.TranslateTo))
+		router.Post(prefix+"/:translatable_id/fan-out", rateLimited(config, resource.FanOut))
+		router.Post(prefix+"/:id/review", rateLimited(config, resource.SubmitForReview))
+		router.Post(prefix+"/:id/publish", rateLimited(config, resource.Publish))
+		router.Post(prefix+"/:id/approve", rateLimited(config, resource.Approve))
+		router.Post(prefix+"/:id/unpublish", rateLimited(config, resource.Unpublish))
+		router.Post(prefix+"/:id/restore", rateLimited(config, resource.Restore))
 	}
+}
 
-	proc := processor.New(processor.ProcessorConfig[Translatable, TranslatableCreateDTO, TranslatableUpdateDTO, TranslatableResponseDTO]{
+func newTranslatableProcessor(db database.Database, config *Config) processor.Processor[Translatable, TranslatableCreateDTO, TranslatableUpdateDTO, TranslatableResponseDTO] {
+	db = withQueryTimeout(config.metrics.instrument(db), config)
+	crudHooks := newTranslatableCRUDHooks(config)
+	crudHooks.db = db
+	useTable(config)
+	translatableCRUD := crud.NewWithHooks[Translatable](guardedDatabase{db}, crudHooks)
+	hooks := NewTranslatableHooks(db, config)
+	converter := &TranslatableConverter{config: config}
+
+	return processor.New(processor.ProcessorConfig[Translatable, TranslatableCreateDTO, TranslatableUpdateDTO, TranslatableResponseDTO]{
 		DB:                 db,
 		CRUD:               translatableCRUD,
 		Converter:          converter,
 		PaginationLimit:    config.PaginationLimit,
 		PaginationMaxLimit: config.MaxPaginationLimit,
-		FieldMap:           fieldMapping,
-		AllowedFields:      []string{"id", "user_id", "translatable_id", "translatable", "locale", "content", "updated_at", "created_at"},
+		FieldMap:           translatableFieldMap,
+		AllowedFields:      translatableAllowedFields,
+		ErrorHandler:       &translatableErrorHandler{},
 	}).
 		WithCreateHook(hooks.CreateHook).
 		WithUpdateHook(hooks.UpdateHook).
 		WithDeleteHook(hooks.DeleteHook).
 		WithGetByIDHook(hooks.GetByIDHook).
 		WithGetAllHook(hooks.GetAllHook)
+}
 
-	resource := &TranslatableResource{
-		processor:      proc,
-		service:        service,
-		translator:     translator,
-		authMiddleware: authMiddleware,
+func (r *TranslatableResource) Create(c fiber.Ctx) error {
+	if r.config.SoftDelete {
+		if recreated, err := r.recreate(c); recreated || err != nil {
+			return err
+		}
+	}
+	ctx, recorder := withETagRecorder(c.Context())
+	c.SetContext(ctx)
+	if err := r.processor.Create(c); err != nil {
+		return err
 	}
+	sendLocation(c, recorder.served)
+	return nil
+}
 
-	router.Post("/translations", resource.Create)
-	router.Get("/translations/:id", resource.GetByID)
-	router.Get("/translations", resource.GetAll)
-	router.Put("/translations/:id", resource.Update)
-	router.Delete("/translations/:id", resource.Delete)
-	router.Get("/locales", resource.GetLocales)
+// recreate serves a create whose entity, type and locale are still held by a
+// soft-deleted or expired translation, which the unique index would otherwise
+// answer 409 for: that translation is restored with the submitted content. It
+// reports false, leaving the create to the processor, for any other body.
+func (r *TranslatableResource) recreate(c fiber.Ctx) (bool, error) {
+	var dto TranslatableCreateDTO
+	if err := c.Bind().Body(&dto); err != nil {
+		return false, nil
+	}
+	translatableID, err := uuid.Parse(dto.TranslatableID)
+	if err != nil || !r.config.IsAllowedType(dto.Translatable) {
+		return false, nil
+	}
+	existing, err := r.service.getByNaturalKey(auth.Context(c), r.service.db, dto.Translatable, translatableID, r.config.normalizeLocale(dto.Locale))
+	if errors.Is(err, ErrTranslationNotFound) || (err == nil && existing.isLive(time.Now())) {
+		return false, nil
+	}
+	if err != nil {
+		return true, errDatabase(err, "failed to create translation")
+	}
 
-	if authMiddleware != nil {
-		router.Post("/translations/:type/:id/translate", authMiddleware, resource.Translate)
-	} else {
-		router.Post("/translations/:type/:id/translate", resource.Translate)
+	model := r.converter.CreateDTOToModel(dto)
+	if err := r.service.hooks.CreateHook(c, dto, &model); err != nil {
+		return true, (&translatableErrorHandler{}).HandleError(c, err, "hook")
+	}
+	recreated, err := r.service.Recreate(auth.Context(c), &model, getUserIDFromFiberContext(c))
+	if errors.Is(err, ErrTranslationExists) {
+		return true, sendProblemError(c, errTranslationExists(model.Locale))
+	}
+	if err := errOwnership(err, "restore"); err != nil {
+		return true, err
+	}
+	if err != nil {
+		return true, errDatabase(err, "failed to create translation")
 	}
+
+	if err := response.SendFormatted(c, fiber.StatusCreated, r.converter.ModelToResponseDTO(*recreated)); err != nil {
+		return true, err
+	}
+	sendLocation(c, recreated)
+	return true, nil
 }
 
-func (r *TranslatableResource) Create(c fiber.Ctx) error {
-	return r.processor.Create(c)
+// sendLocation points a 201 Created response at the translation it created,
+// with a Location header and an @id in plain JSON bodies, as JSON-LD ones
+// already have.
+func sendLocation(c fiber.Ctx, created *Translatable) {
+	if created == nil || c.Response().StatusCode() != fiber.StatusCreated {
+		return
+	}
+	location := strings.TrimRight(c.Path(), "/") + "/" + created.ID.String()
+	c.Set(fiber.HeaderLocation, location)
+
+	body := c.Response().Body()
+	if !strings.HasPrefix(string(c.Response().Header.ContentType()), fiber.MIMEApplicationJSON) || len(body) < 2 || body[0] != '{' {
+		return
+	}
+	id, _ := json.Marshal(location)
+	separator := ","
+	if body[1] == '}' {
+		separator = ""
+	}
+	c.Response().SetBody(slices.Concat([]byte(`{"@id":`), id, []byte(separator), body[1:]))
 }
 
 func (r *TranslatableResource) GetByID(c fiber.Ctx) error {
-	return r.processor.GetByID(c)
+	fields, allowedErr := parseFieldsParam(c)
+	if allowedErr != nil {
+		return sendAllowedValuesError(c, allowedErr)
+	}
+	if err := r.getByID(c); err != nil {
+		return err
+	}
+	return projectResponse(c, fields)
+}
+
+func (r *TranslatableResource) getByID(c fiber.Ctx) error {
+	if locale := c.Query("locale"); locale != "" {
+		return r.getByEntityAndLocale(c, r.config.normalizeLocale(locale))
+	}
+	if locale, ok := r.negotiateLocale(c); ok {
+		return r.getByEntityAndLocale(c, locale)
+	}
+
+	return r.getByTranslationID(c)
+}
+
+// getByTranslationID serves GET /translations/:id where :id is a translation,
+// from the read cache when it holds it.
+func (r *TranslatableResource) getByTranslationID(c fiber.Ctx) error {
+	var key string
+	if id, err := uuid.Parse(c.Params("id")); err == nil && r.config.cacheEnabled() && !includesUser(c) {
+		applyCacheControl(c)
+		if err := applyReadState(c); err != nil {
+			return err
+		}
+		key = r.config.idCacheKey(c.Context(), id)
+		if t, etag, ok := r.config.cachedRead(c.Context(), key); ok {
+			if err := response.SendFormatted(c, fiber.StatusOK, r.converter.ModelToResponseDTO(*t)); err != nil {
+				return err
+			}
+			sendETag(c, etag)
+			return nil
+		}
+	}
+
+	ctx, recorder := withETagRecorder(c.Context())
+	c.SetContext(ctx)
+	if err := r.processor.GetByID(c); err != nil {
+		return err
+	}
+	if key != "" && c.Response().StatusCode() == fiber.StatusOK {
+		r.config.cacheRead(c.Context(), key, recorder.served, recorder.etag)
+	}
+	sendETag(c, recorder.etag)
+	return nil
+}
+
+// negotiateLocale picks the locale to serve from Accept-Language when :id is
+// a translated entity rather than a translation. Only ?translatable= marks :id
+// as an entity, so translation reads, cached ones included, cost no query.
+func (r *TranslatableResource) negotiateLocale(c fiber.Ctx) (string, bool) {
+	header := c.Get(fiber.HeaderAcceptLanguage)
+	if header == "" || c.Query("translatable") == "" {
+		return "", false
+	}
+	translatableID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return "", false
+	}
+	available, err := r.service.LiveLocales(auth.Context(c), c.Query("translatable"), translatableID)
+	if err != nil || len(available) == 0 {
+		return "", false
+	}
+	c.Vary(fiber.HeaderAcceptLanguage)
+	return r.config.negotiateLocale(header, available), true
+}
+
+// getByEntityAndLocale serves GET /translations/:id?locale=xx&translatable=yy
+// where :id is the translated entity: it returns the best translation of type
+// yy along the fallback chain of locale.
+func (r *TranslatableResource) getByEntityAndLocale(c fiber.Ctx, locale string) error {
+	applyCacheControl(c)
+	if err := applyReadState(c); err != nil {
+		return err
+	}
+
+	translatableID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "id must be a valid UUID")
+	}
+	// A translation id and an entity id are both UUIDs: only the type tells
+	// the entity apart from a translation sharing its id.
+	translatable := c.Query("translatable")
+	if translatable == "" {
+		return fiber.NewError(fiber.StatusBadRequest, "translatable is required with locale, or use /translations/lookup")
+	}
+	if !r.config.IsAllowedType(translatable) {
+		return sendAllowedValuesError(c, errTypeNotAllowed(r.config))
+	}
+
+	t, err := r.service.Resolve(auth.Context(c), translatable, translatableID, []string{locale})
+	if err != nil && !errors.Is(err, ErrTranslationNotFound) {
+		return errDatabase(err, "failed to resolve translation")
+	}
+	if t = r.translateOnMiss(c, translatable, translatableID, locale, t); t == nil {
+		return fiber.NewError(fiber.StatusNotFound, "Translation not found")
+	}
+
+	c.Set(fiber.HeaderContentLanguage, t.Locale)
+	if t.Locale != locale {
+		c.Set(HeaderLocaleFallback, "true")
+	}
+	setTranslationSource(c, t)
+	if includesUser(c) {
+		models := []Translatable{*t}
+		attachUsers(c.Context(), r.service.db, models)
+		t = &models[0]
+	}
+	return c.JSON(r.converter.ModelToResponseDTO(*t))
 }
 
 func (r *TranslatableResource) GetAll(c fiber.Ctx) error {
-	return r.processor.GetAll(c)
+	if r.config.HydraDocs && wantsHydraDocs(c) {
+		return c.JSON(hydraAPIDocumentation(c.Path()), "application/ld+json")
+	}
+	fields, allowedErr := parseFieldsParam(c)
+	if allowedErr != nil {
+		return sendAllowedValuesError(c, allowedErr)
+	}
+	if err := r.getAll(c); err != nil {
+		return err
+	}
+	return projectResponse(c, fields)
+}
+
+func (r *TranslatableResource) getAll(c fiber.Ctx) error {
+	if err := expandListFilters(c, r.config); err != nil {
+		return sendAllowedValuesError(c, err)
+	}
+	if err := applyDefaultStatus(c, r.config); err != nil {
+		return sendAllowedValuesError(c, err)
+	}
+	if token := c.Query("snapshot"); token != "" {
+		return r.getAllInSnapshot(c, token)
+	}
+	if ids := c.Query("ids"); ids != "" {
+		return r.batchGet(c, strings.Split(ids, ","))
+	}
+	limit, page := pageWindow(c, r.config)
+	meta := newPaginationMeta(c, limit, page)
+	if c.Query("limit") != "" {
+		// The processor reads limit=0 as no limit at all.
+		c.Request().URI().QueryArgs().Set("limit", strconv.Itoa(limit))
+	}
+	requestPlainMembers(c, r.config)
+	if err := r.processor.GetAll(c); err != nil {
+		return err
+	}
+	return finishCollection(c, r.config, meta)
+}
+
+// OpenSnapshot starts a point-in-time view that later collection reads can be
+// pinned to with ?snapshot=<token>, e.g. for consistent multi-page exports.
+func (r *TranslatableResource) OpenSnapshot(c fiber.Ctx) error {
+	token, expiresAt, err := r.snapshots.Open(auth.Context(c), requestSnapshotOwner(c))
+	if errors.Is(err, ErrTooManySnapshots) {
+		return fiber.NewError(fiber.StatusTooManyRequests, err.Error())
+	}
+	if err != nil {
+		return errDatabase(err, "failed to open snapshot")
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(SnapshotResponseDTO{Token: token, ExpiresAt: expiresAt})
+}
+
+func (r *TranslatableResource) CloseSnapshot(c fiber.Ctx) error {
+	if err := r.snapshots.Close(c.Params("token"), requestSnapshotOwner(c)); errors.Is(err, ErrSnapshotNotFound) {
+		return fiber.NewError(fiber.StatusNotFound, err.Error())
+	}
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+func (r *TranslatableResource) getAllInSnapshot(c fiber.Ctx, token string) error {
+	if err := applyReadState(c); err != nil {
+		return err
+	}
+
+	limit, page := pageWindow(c, r.config)
+
+	var translations []Translatable
+	var total int
+	err := r.snapshots.Read(token, requestSnapshotOwner(c), func(tx database.Tx) error {
+		var err error
+		translations, total, err = r.service.ListInSnapshot(auth.Context(c), tx, queryParams(c), limit, (page-1)*limit)
+		return err
+	})
+	if errors.Is(err, ErrSnapshotNotFound) {
+		return fiber.NewError(fiber.StatusNotFound, err.Error())
+	}
+	if errors.Is(err, ErrInvalidFilter) {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+	if err != nil {
+		return errDatabase(err, "failed to read snapshot")
+	}
+
+	requestPlainMembers(c, r.config)
+	if err := pagination.SendHydraCollection(c, r.converter.ModelsToResponseDTOs(translations), &total, limit, page, r.config.PaginationLimit); err != nil {
+		return err
+	}
+	return finishCollection(c, r.config, newPaginationMeta(c, limit, page))
+}
+
+// Resolve serves an entity's translation in the first available locale of the
+// ?locale= chain (comma-separated, most preferred first).
+func (r *TranslatableResource) Resolve(c fiber.Ctx) error {
+	applyCacheControl(c)
+	if err := applyReadState(c); err != nil {
+		return err
+	}
+
+	translatableID, err := uuid.Parse(c.Query("translatable_id"))
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "translatable_id must be a valid UUID")
+	}
+	translatable := c.Query("translatable")
+	if !r.config.IsAllowedType(translatable) {
+		return sendAllowedValuesError(c, errTypeNotAllowed(r.config))
+	}
+
+	chain := parseLocaleChain(c.Query("locale"))
+	for i, locale := range chain {
+		chain[i] = r.config.normalizeLocale(locale)
+	}
+	t, err := r.service.Resolve(auth.Context(c), translatable, translatableID, chain)
+	if err != nil && !errors.Is(err, ErrTranslationNotFound) {
+		return errDatabase(err, "failed to resolve translation")
+	}
+	if len(chain) > 0 {
+		t = r.translateOnMiss(c, translatable, translatableID, chain[0], t)
+	}
+	if t == nil {
+		return fiber.NewError(fiber.StatusNotFound, "Translation not found")
+	}
+
+	c.Set(fiber.HeaderContentLanguage, t.Locale)
+	if len(chain) > 0 && t.Locale != chain[0] {
+		c.Set(HeaderLocaleFallback, "true")
+	}
+	setTranslationSource(c, t)
+	return c.JSON(r.converter.ModelToResponseDTO(*t))
+}
+
+// translateOnMiss machine-translates locale when resolving it only found a
+// fallback (served) or nothing. Each provider call is a write: it is only made
+// for authenticated users, and spends their rate limit budget. Any translator
+// failure, timeout or exhausted budget degrades to served. Clients opt out per
+// request with ?translate=false.
+func (r *TranslatableResource) translateOnMiss(c fiber.Ctx, translatable string, translatableID uuid.UUID, locale string, served *Translatable) *Translatable {
+	if served != nil && served.Locale == locale {
+		return served
+	}
+	if !r.config.TranslateOnMiss || r.translator == nil || *r.translator == nil ||
+		translatable == "" || !r.config.IsSupportedLocale(locale) || c.Query("translate") == "false" {
+		return served
+	}
+	userID := getUserIDFromFiberContext(c)
+	if userID == nil {
+		return served
+	}
+	if r.config.rateLimits() {
+		if allowed, _ := spendRateLimit(c, r.config, 1); !allowed {
+			return served
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(auth.Context(c), r.config.TranslateOnMissTimeout)
+	defer cancel()
+
+	translated, err := r.service.TranslateMissing(ctx, *r.translator, translatable, translatableID, locale, userID)
+	if err != nil {
+		if !errors.Is(err, ErrTranslationNotFound) {
+			requestLogger(ctx).Warn("translate on miss failed", "type", translatable, "id", translatableID,
+				"locale", locale, "timeout", errors.Is(err, context.DeadlineExceeded))
+		}
+		return served
+	}
+	return translated
+}
+
+// setTranslationSource flags machine-translated reads.
+func setTranslationSource(c fiber.Ctx, t *Translatable) {
+	if t.AutoTranslated {
+		c.Set(HeaderTranslationSource, TranslationSourceMachine)
+	}
+}
+
+// Stale lists translations whose source-locale content changed since they were
+// written, so editors know what needs re-review.
+func (r *TranslatableResource) Stale(c fiber.Ctx) error {
+	return r.listAgainstSource(c, r.service.Stale, "failed to list stale translations")
+}
+
+// Untranslated lists translations whose content is identical to their
+// source-locale content, so QA can find placeholders left untranslated.
+func (r *TranslatableResource) Untranslated(c fiber.Ctx) error {
+	return r.listAgainstSource(c, r.service.Untranslated, "failed to list untranslated translations")
+}
+
+// listAgainstSource serves a page of list, a comparison of translations with
+// their source-locale content, filtered by ?translatable=.
+func (r *TranslatableResource) listAgainstSource(c fiber.Ctx, list func(ctx context.Context, translatable string, limit, offset int) ([]Translatable, int, error), failure string) error {
+	translatable := c.Query("translatable")
+	if translatable != "" && !r.config.IsAllowedType(translatable) {
+		return sendAllowedValuesError(c, errTypeNotAllowed(r.config))
+	}
+
+	limit, page := pageWindow(c, r.config)
+
+	translations, total, err := list(auth.Context(c), translatable, limit, (page-1)*limit)
+	if err != nil {
+		return errDatabase(err, failure)
+	}
+
+	requestPlainMembers(c, r.config)
+	if err := pagination.SendHydraCollection(c, r.converter.ModelsToResponseDTOs(translations), &total, limit, page, r.config.PaginationLimit); err != nil {
+		return err
+	}
+	return finishCollection(c, r.config, newPaginationMeta(c, limit, page))
+}
+
+// Export streams the translations matching the collection filters as a file
+// for external translators: a CSV of every matching row, or a gettext catalog
+// of one target locale with the source content as msgid.
+func (r *TranslatableResource) Export(c fiber.Ctx) error {
+	format := c.Query("format", ExportFormatCSV)
+	if !slices.Contains(exportFormats, format) {
+		return sendAllowedValuesError(c, &AllowedValuesError{Message: "format is not supported", Allowed: exportFormats})
+	}
+	locale := r.config.normalizeLocale(c.Query("locale"))
+	if format == ExportFormatPO {
+		if locale == "" || locale == r.config.DefaultLocale {
+			return sendError(c, fiber.StatusBadRequest, "locale must be set to a locale other than "+r.config.DefaultLocale)
+		}
+		if !r.config.IsSupportedLocale(locale) {
+			return sendInvalidLocale(c, r.config, locale)
+		}
+	}
+	if err := applyReadState(c); err != nil {
+		return err
+	}
+
+	ctx := auth.Context(c)
+	rows, err := r.service.Export(ctx, queryParams(c))
+	if errors.Is(err, ErrInvalidFilter) {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+	if err != nil {
+		return errDatabase(err, "failed to export translations")
+	}
+
+	write := func(w io.Writer) error {
+		return writeTranslationsCSV(ctx, w, rows, r.service.crudHooks)
+	}
+	c.Set(fiber.HeaderContentType, "text/csv; charset=utf-8")
+	c.Set(fiber.HeaderContentDisposition, `attachment; filename="translations.csv"`)
+	if format == ExportFormatPO {
+		write = func(w io.Writer) error {
+			return writeTranslationsPO(ctx, w, rows, r.service.crudHooks, locale, r.service.sourceTranslations)
+		}
+		c.Set(fiber.HeaderContentType, "text/x-gettext-translation; charset=utf-8")
+		c.Set(fiber.HeaderContentDisposition, `attachment; filename="translations.po"`)
+	}
+	return c.SendStreamWriter(func(w *bufio.Writer) {
+		defer func() { _ = rows.Close() }()
+		if err := write(w); err != nil {
+			// Headers are already sent: the truncated file is all we can do.
+			requestLogger(ctx).Error("translation export failed", "error", err)
+		}
+	})
+}
+
+// Import upserts the translations of an uploaded file laid out like the
+// export, all or nothing. Invalid rows are reported with their line number.
+func (r *TranslatableResource) Import(c fiber.Ctx) error {
+	strategy := c.FormValue("strategy", ImportFailOnConflict)
+	if !slices.Contains(importStrategies, strategy) {
+		return sendAllowedValuesError(c, &AllowedValuesError{Message: "strategy is not supported", Allowed: importStrategies})
+	}
+
+	header, err := c.FormFile("file")
+	if err != nil {
+		return sendError(c, fiber.StatusBadRequest, "file is required")
+	}
+	format := ExportFormatCSV
+	if strings.HasSuffix(strings.ToLower(header.Filename), ".po") {
+		format = ExportFormatPO
+	}
+	format = c.FormValue("format", format)
+	if !slices.Contains(exportFormats, format) {
+		return sendAllowedValuesError(c, &AllowedValuesError{Message: "format is not supported", Allowed: exportFormats})
+	}
+
+	file, err := header.Open()
+	if err != nil {
+		return fiber.NewError(fiber.StatusInternalServerError, "failed to read file")
+	}
+	defer func() { _ = file.Close() }()
+
+	report := &ImportReport{Errors: []ImportRowError{}}
+	var records []importRecord
+	if format == ExportFormatPO {
+		records, err = r.readImportPO(c, file, report)
+	} else {
+		records, err = readImportCSV(file)
+	}
+	var formatErr *ImportFormatError
+	var fiberErr *fiber.Error
+	switch {
+	case errors.As(err, &formatErr):
+		return sendError(c, fiber.StatusBadRequest, formatErr.Error())
+	case errors.As(err, &fiberErr):
+		return err
+	case err != nil:
+		return fiber.NewError(fiber.StatusInternalServerError, "failed to read file")
+	}
+
+	translations := make([]Translatable, 0, len(records))
+	lines := make([]int, 0, len(records))
+	for _, record := range records {
+		t, err := r.service.hooks.prepareImportRecord(c, record)
+		if err != nil {
+			report.Errors = append(report.Errors, ImportRowError{Line: record.Line, Error: importErrorMessage(err)})
+			continue
+		}
+		translations = append(translations, t)
+		lines = append(lines, record.Line)
+	}
+	if len(report.Errors) > 0 {
+		return c.Status(fiber.StatusUnprocessableEntity).JSON(report)
+	}
+
+	if limited, err := chargeRows(c, r.config, len(translations)); limited {
+		return err
+	}
+	skipped := report.Skipped
+	report, err = r.service.Import(auth.Context(c), translations, lines, strategy, getUserIDFromFiberContext(c))
+	if err != nil {
+		return errDatabase(err, "failed to import translations")
+	}
+	report.Skipped += skipped
+	if len(report.Errors) > 0 {
+		return c.Status(fiber.StatusConflict).JSON(report)
+	}
+	return c.JSON(report)
+}
+
+// readImportPO parses an uploaded PO file into import records for the locale
+// given in the form, or else in the catalog header. Entries left out of the
+// import are counted in report, and those matching no entity reported there.
+func (r *TranslatableResource) readImportPO(c fiber.Ctx, file io.Reader, report *ImportReport) ([]importRecord, error) {
+	catalog, err := readPO(file)
+	if err != nil {
+		return nil, err
+	}
+	locale := r.config.normalizeLocale(c.FormValue("locale", catalog.Language))
+	if locale == "" || locale == r.config.DefaultLocale {
+		return nil, fiber.NewError(fiber.StatusBadRequest, "locale must be set to a locale other than "+r.config.DefaultLocale)
+	}
+
+	records, skipped, rowErrors, err := r.service.poImportRecords(auth.Context(c), catalog, locale)
+	if err != nil {
+		return nil, err
+	}
+	report.Skipped = skipped
+	report.Errors = append(report.Errors, rowErrors...)
+	return records, nil
+}
+
+func importErrorMessage(err error) string {
+	var fiberErr *fiber.Error
+	if errors.As(err, &fiberErr) {
+		return fiberErr.Message
+	}
+	return err.Error()
+}
+
+// GetSchema serves the JSON Schema of a type's content so clients can render
+// matching edit forms.
+func (r *TranslatableResource) GetSchema(c fiber.Ctx) error {
+	translatable := c.Query("translatable")
+	if !r.config.IsAllowedType(translatable) {
+		return sendAllowedValuesError(c, errTypeNotAllowed(r.config))
+	}
+
+	schema := r.config.ContentSchema(translatable)
+	if schema == nil {
+		return fiber.NewError(fiber.StatusNotFound, "no content schema for this type")
+	}
+
+	c.Set(fiber.HeaderContentType, "application/schema+json")
+	return c.Send(schema)
+}
+
+// BatchGet fetches up to MaxBulkLookup translations by id in one round trip and
+// reports the ids that were not found.
+func (r *TranslatableResource) BatchGet(c fiber.Ctx) error {
+	var dto BatchGetDTO
+	if err := c.Bind().Body(&dto); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "invalid request body")
+	}
+	return r.batchGet(c, dto.IDs)
+}
+
+// Validate runs the checks and sanitization of a create on a translation, or
+// an array of them, without touching the database, and reports the content
+// each would be stored with, so forms can validate and preview as users type.
+// Checks needing stored rows, such as default_locale_first or an existing
+// locale, are left to the create.
+func (r *TranslatableResource) Validate(c fiber.Ctx) error {
+	hooks := NewTranslatableHooks(nil, r.config)
+	body := bytes.TrimSpace(c.Body())
+	if len(body) == 0 || body[0] != '[' {
+		var dto TranslatableCreateDTO
+		if err := c.Bind().Body(&dto); err != nil {
+			return sendCodedError(c, fiber.StatusBadRequest, CodeInvalidBody, "Invalid request body")
+		}
+		return c.JSON(validationResult(c, hooks, dto))
+	}
+
+	var dtos []TranslatableCreateDTO
+	if err := c.Bind().Body(&dtos); err != nil {
+		return sendCodedError(c, fiber.StatusBadRequest, CodeInvalidBody, "Invalid request body")
+	}
+	if len(dtos) > r.config.MaxBulkLookup {
+		return fiber.NewError(fiber.StatusBadRequest, "too many translations: at most "+strconv.Itoa(r.config.MaxBulkLookup)+" allowed")
+	}
+	results := make([]ValidationResultDTO, len(dtos))
+	for i, dto := range dtos {
+		results[i] = validationResult(c, hooks, dto)
+	}
+	return c.JSON(results)
+}
+
+func validationResult(c fiber.Ctx, hooks *TranslatableHooks, dto TranslatableCreateDTO) ValidationResultDTO {
+	var model Translatable
+	invalid := hooks.validateCreate(c, dto, &model)
+	if len(invalid.Fields) > 0 {
+		messages := make(map[string]string, len(invalid.Fields))
+		for field, err := range invalid.Fields {
+			messages[field] = localizedMessage(c, err)
+		}
+		return ValidationResultDTO{Locale: model.Locale, Errors: messages}
+	}
+
+	model.Translatable = dto.Translatable
+	hooks.config.serveFields(&model)
+	result := ValidationResultDTO{Valid: true, Locale: model.Locale, Fields: model.Fields}
+	if model.Fields == nil {
+		result.Content = model.Content
+	}
+	return result
+}
+
+// batchGet serves BatchGet and GET /translations?ids=, which share their
+// limit and response.
+func (r *TranslatableResource) batchGet(c fiber.Ctx, rawIDs []string) error {
+	applyCacheControl(c)
+	if err := applyReadState(c); err != nil {
+		return err
+	}
+
+	ids, err := parseBulkIDs(rawIDs, r.config.MaxBulkLookup)
+	if err != nil {
+		return err
+	}
+
+	translations, err := r.service.GetByIDs(auth.Context(c), ids)
+	if err != nil {
+		return errDatabase(err, "failed to fetch translations")
+	}
+
+	found := make(map[uuid.UUID]bool, len(translations))
+	for _, t := range translations {
+		found[t.ID] = true
+	}
+	missing := make([]uuid.UUID, 0)
+	for _, id := range ids {
+		if !found[id] {
+			missing = append(missing, id)
+		}
+	}
+
+	return c.JSON(BatchGetResponseDTO{
+		Data:    r.converter.ModelsToResponseDTOs(translations),
+		Missing: missing,
+	})
+}
+
+// HeadAll answers HEAD requests on the collection with its size and pagination
+// links in headers, running only the count query.
+func (r *TranslatableResource) HeadAll(c fiber.Ctx) error {
+	if err := applyReadState(c); err != nil {
+		return err
+	}
+	if err := expandListFilters(c, r.config); err != nil {
+		return sendAllowedValuesError(c, err)
+	}
+
+	limit, page := pageWindow(c, r.config)
+
+	params := queryParams(c)
+	total, err := r.service.Count(auth.Context(c), params)
+	if errors.Is(err, ErrInvalidFilter) {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+	if err != nil {
+		return errDatabase(err, "failed to count translations")
+	}
+
+	c.Set("X-Total-Count", strconv.Itoa(total))
+	c.Set("Link", paginationLinks(c.Path(), params, limit, page, r.config.PaginationLimit, total))
+	return c.SendStatus(fiber.StatusOK)
+}
+
+// Count returns the number of translations matching the collection filters,
+// running only the count query.
+func (r *TranslatableResource) Count(c fiber.Ctx) error {
+	if err := applyReadState(c); err != nil {
+		return err
+	}
+	if err := expandListFilters(c, r.config); err != nil {
+		return sendAllowedValuesError(c, err)
+	}
+	if err := applyDefaultStatus(c, r.config); err != nil {
+		return sendAllowedValuesError(c, err)
+	}
+
+	total, err := r.service.Count(auth.Context(c), queryParams(c))
+	if errors.Is(err, ErrInvalidFilter) {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+	if err != nil {
+		return errDatabase(err, "failed to count translations")
+	}
+	return c.JSON(CountResponseDTO{Count: total})
+}
+
+// Lookup serves the translation of an entity in a locale, identified by
+// ?translatable_id=, ?translatable= and ?locale= rather than by its own id.
+// With TranslateOnMiss, a missing locale is machine-translated first.
+func (r *TranslatableResource) Lookup(c fiber.Ctx) error {
+	applyCacheControl(c)
+	if err := applyReadState(c); err != nil {
+		return err
+	}
+
+	translatableID, err := uuid.Parse(c.Query("translatable_id"))
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "translatable_id must be a valid UUID")
+	}
+	translatable := c.Query("translatable")
+	if !r.config.IsAllowedType(translatable) {
+		return sendAllowedValuesError(c, errTypeNotAllowed(r.config))
+	}
+	locale := r.config.normalizeLocale(c.Query("locale"))
+	if locale == "" {
+		return fiber.NewError(fiber.StatusBadRequest, "locale is required")
+	}
+	if !r.config.IsSupportedLocale(locale) {
+		return sendInvalidLocale(c, r.config, c.Query("locale"))
+	}
+
+	t, err := r.service.GetByNaturalKey(auth.Context(c), translatableID, translatable, locale)
+	if err != nil && !errors.Is(err, ErrTranslationNotFound) {
+		return errDatabase(err, "failed to look up translation")
+	}
+	if t = r.translateOnMiss(c, translatable, translatableID, locale, t); t == nil {
+		return fiber.NewError(fiber.StatusNotFound, "Translation not found")
+	}
+
+	c.Set(fiber.HeaderContentLanguage, t.Locale)
+	setTranslationSource(c, t)
+	return c.JSON(r.converter.ModelToResponseDTO(*t))
+}
+
+// Exists answers 200 when the entity has a translation in the requested
+// locale and 404 otherwise, without a body either way.
+func (r *TranslatableResource) Exists(c fiber.Ctx) error {
+	if err := applyReadState(c); err != nil {
+		return err
+	}
+
+	translatableID, err := uuid.Parse(c.Query("translatable_id"))
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "translatable_id must be a valid UUID")
+	}
+	translatable := c.Query("translatable")
+	if !r.config.IsAllowedType(translatable) {
+		return sendAllowedValuesError(c, errTypeNotAllowed(r.config))
+	}
+	locale := r.config.normalizeLocale(c.Query("locale"))
+	if locale == "" {
+		return fiber.NewError(fiber.StatusBadRequest, "locale is required")
+	}
+
+	exists, err := r.service.Exists(auth.Context(c), translatable, translatableID, locale)
+	if errors.Is(err, ErrInvalidFilter) {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+	if err != nil {
+		return errDatabase(err, "failed to check translation")
+	}
+	status := fiber.StatusOK
+	if !exists {
+		status = fiber.StatusNotFound
+	}
+	return c.Status(status).Send(nil)
+}
+
+// Upsert creates a translation or replaces the content of the one stored for
+// the same entity, type and locale: 201 when created, 200 when updated.
+func (r *TranslatableResource) Upsert(c fiber.Ctx) error {
+	var dto TranslatableCreateDTO
+	if err := c.Bind().Body(&dto); err != nil {
+		return sendError(c, fiber.StatusBadRequest, "Invalid request body")
+	}
+
+	model := r.converter.CreateDTOToModel(dto)
+	if err := r.service.hooks.prepareCreate(c, dto, &model); err != nil {
+		var validationErr *ValidationError
+		if errors.As(err, &validationErr) {
+			return sendValidationError(c, validationErr)
+		}
+		var allowedErr *AllowedValuesError
+		if errors.As(err, &allowedErr) {
+			return sendAllowedValuesError(c, allowedErr)
+		}
+		return err
+	}
+
+	upserted, created, err := r.service.Upsert(auth.Context(c), &model)
+	if err := errOwnership(err, "update"); err != nil {
+		return err
+	}
+	if err != nil {
+		return errDatabase(err, "failed to save translation")
+	}
+
+	status := fiber.StatusOK
+	if created {
+		status = fiber.StatusCreated
+	}
+	return c.Status(status).JSON(r.converter.ModelToResponseDTO(*upserted))
 }
 
 func (r *TranslatableResource) Update(c fiber.Ctx) error {
 	return r.processor.Update(c)
 }
 
+// Patch updates only the locale, content or fields the request sets and
+// returns the merged translation.
+func (r *TranslatableResource) Patch(c fiber.Ctx) error {
+	var patch TranslatablePatchDTO
+	if err := c.Bind().Body(&patch); err != nil {
+		return sendError(c, fiber.StatusBadRequest, "Invalid request body")
+	}
+	c.SetContext(withPatch(c.Context(), &patch))
+	return r.processor.Update(c)
+}
+
 func (r *TranslatableResource) Delete(c fiber.Ctx) error {
 	return r.processor.Delete(c)
 }
 
+// GetCapabilities serves the content rules and enabled features of this
+// deployment. It only reads the configuration.
+func (r *TranslatableResource) GetCapabilities(c fiber.Ctx) error {
+	return c.JSON(r.config.capabilities(r.translator != nil && *r.translator != nil))
+}
+
 func (r *TranslatableResource) GetLocales(c fiber.Ctx) error {
-	return c.JSON(r.service.GetLocales())
+	limit := pagination.ParseIntQuery(c, "limit", r.config.MaxLocalesPerPage, r.config.MaxLocalesPerPage)
+	offset := pagination.ParseIntQuery(c, "offset", 0, len(r.config.SupportedLocales))
+	return c.JSON(r.service.GetLocalesPage(limit, offset))
+}
+
+// Completeness reports which supported locales an entity is translated in.
+func (r *TranslatableResource) Completeness(c fiber.Ctx) error {
+	translatableID, err := uuid.Parse(c.Params("translatable_id"))
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "translatable_id must be a valid UUID")
+	}
+	translatable := c.Query("translatable")
+	if !r.config.IsAllowedType(translatable) {
+		return sendAllowedValuesError(c, errTypeNotAllowed(r.config))
+	}
+
+	report, err := r.service.Completeness(auth.Context(c), translatable, translatableID)
+	if err != nil {
+		return errDatabase(err, "failed to load translations")
+	}
+	return c.JSON(report)
+}
+
+// EntityLocales serves the content of an entity in each of its locales, keyed
+// by locale, from a single query.
+func (r *TranslatableResource) EntityLocales(c fiber.Ctx) error {
+	if err := applyReadState(c); err != nil {
+		return err
+	}
+
+	translatableID, err := uuid.Parse(c.Params("translatable_id"))
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "translatable_id must be a valid UUID")
+	}
+	translatable := c.Query("translatable")
+	if !r.config.IsAllowedType(translatable) {
+		return sendAllowedValuesError(c, errTypeNotAllowed(r.config))
+	}
+
+	translations, err := r.service.EntityTranslations(auth.Context(c), translatable, translatableID)
+	if err != nil {
+		return errDatabase(err, "failed to load translations")
+	}
+
+	body := EntityLocalesResponse{
+		TranslatableID: translatableID,
+		Translatable:   translatable,
+		Default:        r.config.DefaultLocale,
+		Translations:   make(LocaleContents, 0, len(translations)),
+	}
+	for _, t := range translations {
+		entry := LocaleContent{Locale: t.Locale, Content: t.Content}
+		if t.Fields != nil {
+			entry.Content = t.Fields
+		}
+		body.Translations = append(body.Translations, entry)
+	}
+	return c.JSON(body)
 }
 
 func (r *TranslatableResource) Translate(c fiber.Ctx) error {
@@ -109,10 +1101,364 @@ func (r *TranslatableResource) Translate(c fiber.Ctx) error {
 		}
 	}
 
-	result, err := (*r.translator).Translate(c.Context(), resourceType, resourceID, userID)
+	ctx, cancel := context.WithTimeout(c.Context(), r.config.TranslatorTimeout)
+	defer cancel()
+
+	result, err := (*r.translator).Translate(ctx, resourceType, resourceID, userID)
+	if err != nil {
+		// Provider errors may echo the submitted content, so only log metadata.
+		timedOut := errors.Is(err, context.DeadlineExceeded)
+		requestLogger(ctx).Error("auto-translation failed", "type", resourceType, "id", resourceID, "timeout", timedOut)
+		if timedOut {
+			return fiber.NewError(fiber.StatusGatewayTimeout, "translation provider timed out")
+		}
+		return fiber.NewError(fiber.StatusInternalServerError, "translation provider request failed")
+	}
+
+	return c.JSON(result)
+}
+
+// TranslateTo machine-translates one translation into the ?target= locale and
+// stores the result as a new translation of the same entity.
+func (r *TranslatableResource) TranslateTo(c fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "id must be a valid UUID")
+	}
+	target := r.config.normalizeLocale(c.Query("target"))
+	if !r.config.IsSupportedLocale(target) {
+		return sendInvalidLocale(c, r.config, target)
+	}
+
+	translator := r.config.textTranslator()
+	if translator == nil {
+		return fiber.NewError(fiber.StatusServiceUnavailable, "machine translation is not configured")
+	}
+
+	ctx := auth.Context(c)
+	source, err := r.service.GetByID(ctx, id)
+	if errors.Is(err, ErrTranslationNotFound) {
+		return fiber.NewError(fiber.StatusNotFound, "Translation not found")
+	}
+	if err != nil {
+		return errDatabase(err, "failed to translate translation")
+	}
+	if !r.config.machineTranslatable(source.Translatable) {
+		return fiber.NewError(fiber.StatusUnprocessableEntity, "machine translation only supports text content")
+	}
+	if source.Locale == target {
+		return fiber.NewError(fiber.StatusBadRequest, "target must differ from the source locale")
+	}
+
+	providerCtx, cancel := context.WithTimeout(ctx, r.config.TranslatorTimeout)
+	defer cancel()
+
+	translated, err := translator.Translate(providerCtx, r.config.providerText(source), source.Locale, target)
+	if err != nil {
+		// Provider errors may echo the submitted content, so only log metadata.
+		timedOut := errors.Is(err, context.DeadlineExceeded)
+		requestLogger(ctx).Error("machine translation failed", "id", id, "target", target, "timeout", timedOut)
+		if timedOut {
+			return fiber.NewError(fiber.StatusGatewayTimeout, "translation provider timed out")
+		}
+		return fiber.NewError(fiber.StatusBadGateway, "translation provider request failed")
+	}
+
+	// The machine translation is created as if submitted by the user.
+	dto := TranslatableCreateDTO{TranslatableID: source.TranslatableID.String(), Translatable: source.Translatable, Locale: target, Content: translated}
+	model := r.converter.CreateDTOToModel(dto)
+	if err := r.service.hooks.prepareCreate(c, dto, &model); err != nil {
+		return (&translatableErrorHandler{}).HandleError(c, err, "hook")
+	}
+	created, err := r.service.CreateMachineTranslation(ctx, &model)
+	if errors.Is(err, ErrTranslationExists) {
+		return sendProblemError(c, errTranslationExists(target))
+	}
+	if err := errOwnership(err, "restore"); err != nil {
+		return err
+	}
+	if err != nil {
+		return errDatabase(err, "failed to store translation")
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(r.converter.ModelToResponseDTO(*created))
+}
+
+// FanOut machine-translates the ?source= translation of an entity, the default
+// locale unless set, into every other supported locale. Locales the entity
+// already has are only replaced with ?overwrite=true.
+func (r *TranslatableResource) FanOut(c fiber.Ctx) error {
+	translatableID, err := uuid.Parse(c.Params("translatable_id"))
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "translatable_id must be a valid UUID")
+	}
+	translatable := c.Query("translatable")
+	if !r.config.IsAllowedType(translatable) {
+		return sendAllowedValuesError(c, errTypeNotAllowed(r.config))
+	}
+	source := r.config.normalizeLocale(c.Query("source", r.config.DefaultLocale))
+	if !r.config.IsSupportedLocale(source) {
+		return sendInvalidLocale(c, r.config, source)
+	}
+
+	translator := r.config.textTranslator()
+	if translator == nil {
+		return fiber.NewError(fiber.StatusServiceUnavailable, "machine translation is not configured")
+	}
+	if typeName, _ := r.config.CanonicalType(translatable); !r.config.machineTranslatable(typeName) {
+		return fiber.NewError(fiber.StatusUnprocessableEntity, "machine translation only supports text content")
+	}
+
+	if limited, err := chargeRows(c, r.config, len(r.config.SupportedLocales)-1); limited {
+		return err
+	}
+	result, err := r.service.FanOut(auth.Context(c), translator, translatable, translatableID, source, c.Query("overwrite") == "true", getUserIDFromFiberContext(c))
+	if errors.Is(err, ErrTranslationNotFound) {
+		return fiber.NewError(fiber.StatusNotFound, "Source translation not found")
+	}
 	if err != nil {
-		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+		return errDatabase(err, "failed to load translations")
 	}
 
 	return c.JSON(result)
 }
+
+func (r *TranslatableResource) Publish(c fiber.Ctx) error {
+	return r.transition(c, "publish", StatusPublished, true, r.service.Publish)
+}
+
+func (r *TranslatableResource) Unpublish(c fiber.Ctx) error {
+	return r.transition(c, "unpublish", StatusDraft, true, r.service.Unpublish)
+}
+
+// Approve publishes a translation under review on behalf of the current user,
+// recorded as its reviewer. Reviewers sign off translations of other users.
+func (r *TranslatableResource) Approve(c fiber.Ctx) error {
+	reviewer := getUserIDFromFiberContext(c)
+	return r.transition(c, "approve", StatusPublished, false, func(ctx context.Context, id uuid.UUID) (*Translatable, error) {
+		return r.service.Approve(ctx, id, reviewer)
+	})
+}
+
+func (r *TranslatableResource) SubmitForReview(c fiber.Ctx) error {
+	return r.transition(c, "submit", StatusReview, true, r.service.SubmitForReview)
+}
+
+// transition moves the translation :id to status with move, answering 409 when
+// its current status does not allow it. With ownOnly, users can only move
+// their own translations.
+func (r *TranslatableResource) transition(c fiber.Ctx, verb, status string, ownOnly bool, move func(context.Context, uuid.UUID) (*Translatable, error)) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "id must be a valid UUID")
+	}
+
+	ctx := auth.Context(c)
+	existing, err := r.service.GetByID(ctx, id)
+	if errors.Is(err, ErrTranslationNotFound) {
+		return fiber.NewError(fiber.StatusNotFound, "Translation not found")
+	}
+	if err != nil {
+		return errDatabase(err, "failed to "+verb+" translation")
+	}
+
+	userID := getUserIDFromFiberContext(c)
+	if ownOnly {
+		if err := errOwnership(r.config.checkOwnership(ctx, userID, existing.UserID), verb); err != nil {
+			return err
+		}
+	}
+
+	moved, err := move(ctx, id)
+	if errors.Is(err, ErrTranslationNotFound) {
+		return fiber.NewError(fiber.StatusNotFound, "Translation not found")
+	}
+	if errors.Is(err, ErrInvalidTransition) {
+		return sendProblemError(c, errInvalidTransition(translationStatus(existing), status))
+	}
+	if err != nil {
+		return errDatabase(err, "failed to "+verb+" translation")
+	}
+
+	return c.JSON(r.converter.ModelToResponseDTO(*moved))
+}
+
+// ReplaceLocales writes the translations of an entity in several locales at
+// once. With ?mode=replace the locales missing from the body are deleted; the
+// default ?mode=merge keeps them.
+func (r *TranslatableResource) ReplaceLocales(c fiber.Ctx) error {
+	translatableID, err := uuid.Parse(c.Params("translatable_id"))
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "translatable_id must be a valid UUID")
+	}
+
+	mode := c.Query("mode", ReplaceModeMerge)
+	if mode != ReplaceModeMerge && mode != ReplaceModeReplace {
+		return sendAllowedValuesError(c, &AllowedValuesError{
+			Message: "mode is not supported",
+			Allowed: []string{ReplaceModeMerge, ReplaceModeReplace},
+		})
+	}
+
+	var dto ReplaceLocalesDTO
+	if err := c.Bind().Body(&dto); err != nil {
+		return sendError(c, fiber.StatusBadRequest, "Invalid request body")
+	}
+
+	translatable := c.Query("translatable")
+	translations, err := r.service.hooks.prepareEntityLocales(c, translatable, translatableID, dto.Translations)
+	if err != nil {
+		var allowedErr *AllowedValuesError
+		if errors.As(err, &allowedErr) {
+			return sendAllowedValuesError(c, allowedErr)
+		}
+		return err
+	}
+
+	if limited, err := chargeRows(c, r.config, len(translations)); limited {
+		return err
+	}
+	result, err := r.service.ReplaceLocales(auth.Context(c), translatable, translatableID, translations, mode, getUserIDFromFiberContext(c))
+	if err := errOwnership(err, "update"); err != nil {
+		return err
+	}
+	if err != nil {
+		return errDatabase(err, "failed to save translations")
+	}
+
+	return c.JSON(r.converter.ModelsToResponseDTOs(result))
+}
+
+// Trash lists the soft-deleted translations, filtered by ?translatable=, so
+// they can be audited and restored.
+func (r *TranslatableResource) Trash(c fiber.Ctx) error {
+	translatable := c.Query("translatable")
+	if translatable != "" && !r.config.IsAllowedType(translatable) {
+		return sendAllowedValuesError(c, errTypeNotAllowed(r.config))
+	}
+
+	limit, page := pageWindow(c, r.config)
+
+	trashed, total, err := r.service.Trash(auth.Context(c), translatable, limit, (page-1)*limit)
+	if err != nil {
+		return errDatabase(err, "failed to list deleted translations")
+	}
+
+	items := make([]TrashedTranslationDTO, len(trashed))
+	for i, t := range trashed {
+		items[i] = TrashedTranslationDTO{
+			ID:           t.ID,
+			Translatable: t.Translatable,
+			Locale:       t.Locale,
+			Content:      t.Content,
+			DeletedAt:    t.DeletedAt,
+			UpdatedBy:    t.UpdatedBy,
+		}
+	}
+
+	requestPlainMembers(c, r.config)
+	if err := pagination.SendHydraCollection(c, items, &total, limit, page, r.config.PaginationLimit); err != nil {
+		return err
+	}
+	return finishCollection(c, r.config, newPaginationMeta(c, limit, page))
+}
+
+// Restore brings back a soft-deleted translation.
+func (r *TranslatableResource) Restore(c fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "id must be a valid UUID")
+	}
+
+	restored, err := r.service.Restore(auth.Context(c), id, getUserIDFromFiberContext(c))
+	if errors.Is(err, ErrTranslationNotFound) {
+		return fiber.NewError(fiber.StatusNotFound, "Translation not found")
+	}
+	if err := errOwnership(err, "restore"); err != nil {
+		return err
+	}
+	if err != nil {
+		return errDatabase(err, "failed to restore translation")
+	}
+
+	return c.JSON(r.converter.ModelToResponseDTO(*restored))
+}
+
+// CloneEntity copies all translations of one entity onto another, typically
+// after the host application duplicated the underlying resource.
+func (r *TranslatableResource) CloneEntity(c fiber.Ctx) error {
+	var dto CloneEntityDTO
+	if err := c.Bind().Body(&dto); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "invalid request body")
+	}
+
+	from, err := uuid.Parse(dto.FromTranslatableID)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "from_translatable_id must be a valid UUID")
+	}
+	to, err := uuid.Parse(dto.ToTranslatableID)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "to_translatable_id must be a valid UUID")
+	}
+	if !r.config.IsAllowedType(dto.Translatable) {
+		return sendAllowedValuesError(c, errTypeNotAllowed(r.config))
+	}
+
+	ctx := auth.Context(c)
+	if r.config.rateLimits() {
+		rows, err := r.service.Count(ctx, url.Values{"translatable": {dto.Translatable}, "translatable_id": {from.String()}})
+		if err != nil {
+			return errDatabase(err, "failed to clone translations")
+		}
+		if limited, err := chargeRows(c, r.config, rows); limited {
+			return err
+		}
+	}
+	created, err := r.service.CloneEntity(ctx, dto.Translatable, from, to, getUserIDFromFiberContext(c))
+	if errors.Is(err, ErrSameEntity) {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+	if err != nil {
+		return errDatabase(err, "failed to clone translations")
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(r.converter.ModelsToResponseDTOs(created))
+}
+
+// CopyLocale copies the translations of a type from one locale to another,
+// e.g. to seed en-GB from en-US before editing it.
+func (r *TranslatableResource) CopyLocale(c fiber.Ctx) error {
+	var dto CopyLocaleDTO
+	if err := c.Bind().Body(&dto); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "invalid request body")
+	}
+	if !r.config.IsAllowedType(dto.Translatable) {
+		return sendAllowedValuesError(c, errTypeNotAllowed(r.config))
+	}
+	dto.FromLocale, dto.ToLocale = r.config.normalizeLocale(dto.FromLocale), r.config.normalizeLocale(dto.ToLocale)
+	for _, locale := range []string{dto.FromLocale, dto.ToLocale} {
+		if !r.config.IsSupportedLocale(locale) {
+			return sendInvalidLocale(c, r.config, locale)
+		}
+	}
+
+	ctx := auth.Context(c)
+	if r.config.rateLimits() {
+		rows, err := r.service.Count(ctx, url.Values{"translatable": {dto.Translatable}, "locale": {dto.FromLocale}})
+		if err != nil {
+			return errDatabase(err, "failed to copy translations")
+		}
+		if limited, err := chargeRows(c, r.config, rows); limited {
+			return err
+		}
+	}
+	copied, err := r.service.CopyLocale(ctx, dto.Translatable, dto.FromLocale, dto.ToLocale, dto.Overwrite, getUserIDFromFiberContext(c))
+	if errors.Is(err, ErrSameLocale) {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+	if err != nil {
+		return errDatabase(err, "failed to copy translations")
+	}
+
+	return c.JSON(CopyLocaleResponse{Copied: copied})
+}
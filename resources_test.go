@@ -1,51 +1,35 @@
 package translatable
 
 import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"net/http/httptest"
+	"slices"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/gofiber/fiber/v3"
-	"github.com/nicolasbonnici/gorest/crud"
+	"github.com/google/uuid"
+	"github.com/nicolasbonnici/gorest-translatable/mocks"
+	authcontext "github.com/nicolasbonnici/gorest/auth/context"
 	"github.com/nicolasbonnici/gorest/database"
-	"github.com/nicolasbonnici/gorest/processor"
+	"github.com/nicolasbonnici/gorest/pagination"
+	"github.com/stretchr/testify/assert"
 )
 
 func setupTestApp(db database.Database, config *Config) (*fiber.App, *TranslatableResource) {
 	app := fiber.New()
-	service := NewTranslatableService(db, config)
-
-	translatableCRUD := crud.New[Translatable](db)
-	hooks := NewTranslatableHooks(db, config)
-	converter := &TranslatableConverter{}
-
-	fieldMapping := map[string]string{
-		"id":              "id",
-		"user_id":         "user_id",
-		"translatable_id": "translatable_id",
-		"translatable":    "translatable",
-		"locale":          "locale",
-		"content":         "content",
-		"updated_at":      "updated_at",
-		"created_at":      "created_at",
-	}
-
-	proc := processor.New(processor.ProcessorConfig[Translatable, TranslatableCreateDTO, TranslatableUpdateDTO, TranslatableResponseDTO]{
-		DB:                 db,
-		CRUD:               translatableCRUD,
-		Converter:          converter,
-		PaginationLimit:    config.PaginationLimit,
-		PaginationMaxLimit: config.MaxPaginationLimit,
-		FieldMap:           fieldMapping,
-		AllowedFields:      []string{"id", "user_id", "translatable_id", "translatable", "locale", "content", "updated_at", "created_at"},
-	}).
-		WithCreateHook(hooks.CreateHook).
-		WithUpdateHook(hooks.UpdateHook).
-		WithDeleteHook(hooks.DeleteHook).
-		WithGetByIDHook(hooks.GetByIDHook).
-		WithGetAllHook(hooks.GetAllHook)
-
 	resource := &TranslatableResource{
-		processor: proc,
-		service:   service,
+		processor: newTranslatableProcessor(db, config),
+		config:    config,
+		service:   NewTranslatableService(db, config),
+		converter: &TranslatableConverter{config: config},
 	}
 	return app, resource
 }
@@ -62,3 +46,1700 @@ func TestTranslatableResourceInitialization(t *testing.T) {
 		t.Fatal("TranslatableResource service should not be nil")
 	}
 }
+
+func TestTranslatableResource_HeadAll(t *testing.T) {
+	config := DefaultConfig()
+	var countSQL string
+	var countArgs []interface{}
+	db := &mocks.MockDatabase{
+		QueryRowFunc: func(ctx context.Context, query string, args ...interface{}) database.Row {
+			countSQL = query
+			countArgs = args
+			return &mocks.MockRow{ScanFunc: func(dest ...interface{}) error {
+				*dest[0].(*int) = 35
+				return nil
+			}}
+		},
+	}
+	app, resource := setupTestApp(db, &config)
+	app.Head("/translations", resource.HeadAll)
+
+	req := httptest.NewRequest(fiber.MethodHead, "/translations?locale=fr&limit=10&page=2", nil)
+	resp, err := app.Test(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	body, _ := io.ReadAll(resp.Body)
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+	assert.Empty(t, body)
+	assert.Equal(t, "35", resp.Header.Get("X-Total-Count"))
+	assert.Equal(t,
+		`</translations?limit=10&locale=fr>; rel="first", `+
+			`</translations?limit=10&locale=fr>; rel="prev", `+
+			`</translations?limit=10&locale=fr&page=3>; rel="next", `+
+			`</translations?limit=10&locale=fr&page=4>; rel="last"`,
+		resp.Header.Get("Link"))
+	assert.Contains(t, countSQL, "SELECT COUNT(*) FROM translations WHERE")
+	assert.Equal(t, "fr", countArgs[len(countArgs)-1])
+}
+
+func TestTranslatableResource_Count(t *testing.T) {
+	tests := []struct {
+		name  string
+		query string
+		where []string
+		args  []interface{}
+	}{
+		{name: "no filter", query: "", where: []string{"deleted_at IS NULL"}},
+		{name: "locale", query: "?locale=fr", where: []string{"locale = "}, args: []interface{}{"fr"}},
+		{name: "locale and type", query: "?locale=fr&translatable=post", where: []string{"locale = ", "translatable = "}, args: []interface{}{"fr", "post"}},
+		{name: "created range", query: "?translatable=post&created_after=2024-01-01T00:00:00Z", where: []string{"translatable = ", "created_at > "}, args: []interface{}{"post"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var queries []string
+			var countArgs []interface{}
+			db := &mocks.MockDatabase{
+				QueryRowFunc: func(ctx context.Context, query string, args ...interface{}) database.Row {
+					queries = append(queries, query)
+					countArgs = args
+					return &mocks.MockRow{ScanFunc: func(dest ...interface{}) error {
+						*dest[0].(*int) = 12
+						return nil
+					}}
+				},
+			}
+			config := DefaultConfig()
+			app, resource := setupTestApp(db, &config)
+			app.Get("/translations/count", resource.Count)
+
+			resp, err := app.Test(httptest.NewRequest(fiber.MethodGet, "/translations/count"+tt.query, nil))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			body, _ := io.ReadAll(resp.Body)
+			assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+			assert.JSONEq(t, `{"count":12}`, string(body))
+			if assert.Len(t, queries, 1) {
+				assert.True(t, strings.HasPrefix(queries[0], "SELECT COUNT(*) FROM translations WHERE"))
+				for _, where := range tt.where {
+					assert.Contains(t, queries[0], where)
+				}
+			}
+			for _, arg := range tt.args {
+				assert.Contains(t, countArgs, arg)
+			}
+		})
+	}
+}
+
+func TestTranslatableResource_Count_InvalidFilter(t *testing.T) {
+	config := DefaultConfig()
+	app, resource := setupTestApp(&mocks.MockDatabase{}, &config)
+	app.Get("/translations/count", resource.Count)
+
+	resp, err := app.Test(httptest.NewRequest(fiber.MethodGet, "/translations/count?created_after=yesterday", nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+}
+
+func TestTranslatableResource_Exists(t *testing.T) {
+	translatableID := uuid.New()
+	tests := []struct {
+		name   string
+		query  string
+		exists bool
+		status int
+	}{
+		{name: "exists", query: "?translatable_id=" + translatableID.String() + "&translatable=post&locale=fr", exists: true, status: fiber.StatusOK},
+		{name: "missing", query: "?translatable_id=" + translatableID.String() + "&translatable=post&locale=fr", status: fiber.StatusNotFound},
+		{name: "invalid id", query: "?translatable_id=nope&translatable=post&locale=fr", status: fiber.StatusBadRequest},
+		{name: "type not allowed", query: "?translatable_id=" + translatableID.String() + "&translatable=users&locale=fr", status: fiber.StatusBadRequest},
+		{name: "no locale", query: "?translatable_id=" + translatableID.String() + "&translatable=post", status: fiber.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var queries []string
+			var existsArgs []interface{}
+			db := &mocks.MockDatabase{
+				QueryRowFunc: func(ctx context.Context, query string, args ...interface{}) database.Row {
+					queries = append(queries, query)
+					existsArgs = args
+					return &mocks.MockRow{ScanFunc: func(dest ...interface{}) error {
+						*dest[0].(*bool) = tt.exists
+						return nil
+					}}
+				},
+			}
+			config := DefaultConfig()
+			app, resource := setupTestApp(db, &config)
+			app.Get("/translations/exists", resource.Exists)
+
+			resp, err := app.Test(httptest.NewRequest(fiber.MethodGet, "/translations/exists"+tt.query, nil))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			assert.Equal(t, tt.status, resp.StatusCode)
+			if tt.status == fiber.StatusBadRequest {
+				assert.Empty(t, queries)
+				return
+			}
+			body, _ := io.ReadAll(resp.Body)
+			assert.Empty(t, body)
+			if assert.Len(t, queries, 1) {
+				assert.True(t, strings.HasPrefix(queries[0], "SELECT EXISTS (SELECT id FROM translations WHERE"))
+				assert.Contains(t, queries[0], "LIMIT 1")
+			}
+			assert.Contains(t, existsArgs, "post")
+			assert.Contains(t, existsArgs, "fr")
+		})
+	}
+}
+
+func TestTranslatableResource_Lookup(t *testing.T) {
+	id := uuid.New()
+	translatableID := uuid.New()
+	keys := "?translatable_id=" + translatableID.String() + "&translatable=post&locale=fr"
+	tests := []struct {
+		name   string
+		query  string
+		found  bool
+		status int
+	}{
+		{name: "found", query: keys, found: true, status: fiber.StatusOK},
+		{name: "not found", query: keys, status: fiber.StatusNotFound},
+		{name: "invalid id", query: "?translatable_id=nope&translatable=post&locale=fr", status: fiber.StatusBadRequest},
+		{name: "type not allowed", query: "?translatable_id=" + translatableID.String() + "&translatable=users&locale=fr", status: fiber.StatusBadRequest},
+		{name: "no locale", query: "?translatable_id=" + translatableID.String() + "&translatable=post", status: fiber.StatusBadRequest},
+		{name: "unsupported locale", query: "?translatable_id=" + translatableID.String() + "&translatable=post&locale=it", status: fiber.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var queries []string
+			var lookupArgs []interface{}
+			db := &mocks.MockDatabase{
+				QueryFunc: func(ctx context.Context, query string, args ...interface{}) (database.Rows, error) {
+					queries = append(queries, query)
+					lookupArgs = args
+					if !tt.found {
+						return mocks.NewMockRows(0), nil
+					}
+					rows := mocks.NewMockRows(1)
+					rows.ScanFunc = func(row int, dest ...interface{}) error {
+						*dest[0].(*uuid.UUID) = id
+						*dest[2].(*uuid.UUID) = translatableID
+						*dest[3].(*string) = "post"
+						*dest[4].(*string) = "fr"
+						*dest[5].(*string) = "Bonjour"
+						return nil
+					}
+					return rows, nil
+				},
+			}
+			config := DefaultConfig()
+			app, resource := setupTestApp(db, &config)
+			app.Get("/translations/lookup", resource.Lookup)
+
+			resp, err := app.Test(httptest.NewRequest(fiber.MethodGet, "/translations/lookup"+tt.query, nil))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			assert.Equal(t, tt.status, resp.StatusCode)
+			if tt.status == fiber.StatusBadRequest {
+				assert.Empty(t, queries)
+				return
+			}
+			assert.Len(t, queries, 1)
+			assert.Contains(t, lookupArgs, translatableID)
+			assert.Contains(t, lookupArgs, "post")
+			assert.Contains(t, lookupArgs, "fr")
+			if !tt.found {
+				return
+			}
+
+			var body TranslatableResponseDTO
+			assert.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+			assert.Equal(t, id, body.ID)
+			assert.Equal(t, "Bonjour", body.Content)
+			assert.Equal(t, "fr", resp.Header.Get(fiber.HeaderContentLanguage))
+		})
+	}
+}
+
+func TestTranslatableResource_Lookup_TranslateOnMiss(t *testing.T) {
+	entityID := uuid.New()
+	for _, enabled := range []bool{false, true} {
+		t.Run(fmt.Sprintf("enabled=%v", enabled), func(t *testing.T) {
+			available := []string{}
+			called := false
+			db := &mocks.MockDatabase{
+				QueryFunc: func(ctx context.Context, query string, args ...interface{}) (database.Rows, error) {
+					rows := mocks.NewMockRows(len(available))
+					rows.ScanFunc = func(row int, dest ...interface{}) error {
+						*dest[2].(*uuid.UUID) = entityID
+						*dest[3].(*string) = "post"
+						*dest[4].(*string) = available[row]
+						*dest[5].(*string) = "Hallo"
+						*dest[9].(*bool) = true
+						return nil
+					}
+					return rows, nil
+				},
+				ExecFunc: func(ctx context.Context, query string, args ...interface{}) (database.Result, error) {
+					return mocks.NewMockResult(1), nil
+				},
+			}
+			config := DefaultConfig()
+			config.SupportedLocales = []string{"en", "de"}
+			config.TranslateOnMiss = enabled
+			app, resource := setupTestApp(db, &config)
+			var translator Translator = translatorFunc(func(ctx context.Context, resourceType, resourceID string, _ *uuid.UUID) (*TranslationResult, error) {
+				called = true
+				available = append(available, "de")
+				return &TranslationResult{Translated: []string{"de"}}, nil
+			})
+			resource.translator = &translator
+			app.Use(func(c fiber.Ctx) error {
+				authcontext.SetUserID(c, uuid.NewString())
+				return c.Next()
+			})
+			app.Get("/translations/lookup", resource.Lookup)
+
+			resp, err := app.Test(httptest.NewRequest(fiber.MethodGet,
+				"/translations/lookup?translatable=post&translatable_id="+entityID.String()+"&locale=de", nil))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			assert.Equal(t, enabled, called)
+			if !enabled {
+				assert.Equal(t, fiber.StatusNotFound, resp.StatusCode)
+				assert.Empty(t, resp.Header.Get(HeaderTranslationSource))
+				return
+			}
+			assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+			assert.Equal(t, TranslationSourceMachine, resp.Header.Get(HeaderTranslationSource))
+			var body TranslatableResponseDTO
+			assert.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+			assert.Equal(t, "de", body.Locale)
+			assert.Equal(t, "Hallo", body.Content)
+		})
+	}
+}
+
+func TestTranslatableResource_GetAll_ResponseFormat(t *testing.T) {
+	id := uuid.New()
+	tests := []struct {
+		format string
+		keys   []string
+	}{
+		{format: ResponseFormatHydra, keys: []string{"@context", "hydra:member", "hydra:totalItems", "hydra:view", "applied_limit"}},
+		{format: ResponseFormatPlain, keys: []string{"data", "total", "limit", "offset", "applied_limit"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.format, func(t *testing.T) {
+			db := &mocks.MockDatabase{
+				QueryFunc: func(ctx context.Context, query string, args ...interface{}) (database.Rows, error) {
+					rows := mocks.NewMockRows(1)
+					rows.ScanFunc = func(row int, dest ...interface{}) error {
+						*dest[0].(*uuid.UUID) = id
+						*dest[3].(*string) = "post"
+						*dest[4].(*string) = "fr"
+						return nil
+					}
+					return rows, nil
+				},
+				QueryRowFunc: func(ctx context.Context, query string, args ...interface{}) database.Row {
+					return &mocks.MockRow{ScanFunc: func(dest ...interface{}) error {
+						*dest[0].(*int) = 21
+						return nil
+					}}
+				},
+			}
+			config := DefaultConfig()
+			config.ResponseFormat = tt.format
+			app, resource := setupTestApp(db, &config)
+			app.Get("/translations", resource.GetAll)
+
+			resp, err := app.Test(httptest.NewRequest(fiber.MethodGet, "/translations?limit=10&page=2", nil))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+			var body map[string]json.RawMessage
+			assert.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+			for _, key := range tt.keys {
+				assert.Contains(t, body, key)
+			}
+			if tt.format != ResponseFormatPlain {
+				return
+			}
+			assert.NotContains(t, body, "hydra:member")
+
+			var plain struct {
+				Data   []map[string]interface{} `json:"data"`
+				Total  int                      `json:"total"`
+				Limit  int                      `json:"limit"`
+				Offset int                      `json:"offset"`
+			}
+			raw, _ := json.Marshal(body)
+			assert.NoError(t, json.Unmarshal(raw, &plain))
+			assert.Equal(t, 21, plain.Total)
+			assert.Equal(t, 10, plain.Limit)
+			assert.Equal(t, 10, plain.Offset)
+			if assert.Len(t, plain.Data, 1) {
+				assert.Equal(t, "post", plain.Data[0]["translatable"])
+				assert.NotContains(t, plain.Data[0], "@id")
+			}
+		})
+	}
+}
+
+func TestTranslatableResource_Stale_PlainFormat(t *testing.T) {
+	db := &mocks.MockDatabase{
+		QueryFunc: func(ctx context.Context, query string, args ...interface{}) (database.Rows, error) {
+			return mocks.NewMockRows(0), nil
+		},
+		QueryRowFunc: func(ctx context.Context, query string, args ...interface{}) database.Row {
+			return &mocks.MockRow{ScanFunc: func(dest ...interface{}) error {
+				*dest[0].(*int) = 0
+				return nil
+			}}
+		},
+	}
+	config := DefaultConfig()
+	config.ResponseFormat = ResponseFormatPlain
+	app, resource := setupTestApp(db, &config)
+	app.Get("/translations/stale", resource.Stale)
+
+	resp, err := app.Test(httptest.NewRequest(fiber.MethodGet, "/translations/stale", nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	body, _ := io.ReadAll(resp.Body)
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+	assert.JSONEq(t, `{"data":[],"total":0,"limit":20,"offset":0,"applied_limit":20,"applied_offset":0}`, string(body))
+}
+
+func TestTranslatableResource_CloneEntity_Validation(t *testing.T) {
+	config := DefaultConfig()
+	config.AllowedTypes = []string{"posts"}
+	app, resource := setupTestApp(&mocks.MockDatabase{}, &config)
+	app.Post("/translations/clone-entity", resource.CloneEntity)
+
+	validID := "550e8400-e29b-41d4-a716-446655440000"
+	tests := []struct {
+		name string
+		body string
+	}{
+		{name: "invalid source id", body: `{"from_translatable_id":"nope","to_translatable_id":"` + validID + `","translatable":"posts"}`},
+		{name: "invalid target id", body: `{"from_translatable_id":"` + validID + `","to_translatable_id":"nope","translatable":"posts"}`},
+		{name: "type not allowed", body: `{"from_translatable_id":"` + validID + `","to_translatable_id":"` + validID + `","translatable":"users"}`},
+		{name: "same entity", body: `{"from_translatable_id":"` + validID + `","to_translatable_id":"` + validID + `","translatable":"posts"}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(fiber.MethodPost, "/translations/clone-entity", strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			resp, err := app.Test(req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+		})
+	}
+}
+
+func TestTranslatableResource_GetLocales_Pagination(t *testing.T) {
+	config := DefaultConfig()
+	config.SupportedLocales = []string{"en", "fr", "es", "de"}
+	config.MaxLocalesPerPage = 3
+	app, resource := setupTestApp(nil, &config)
+	app.Get("/locales", resource.GetLocales)
+
+	tests := []struct {
+		name     string
+		query    string
+		expected []string
+	}{
+		{name: "default is capped", query: "", expected: []string{"en", "fr", "es"}},
+		{name: "limit and offset", query: "?limit=2&offset=1", expected: []string{"fr", "es"}},
+		{name: "limit above max is capped", query: "?limit=50", expected: []string{"en", "fr", "es"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := app.Test(httptest.NewRequest(fiber.MethodGet, "/locales"+tt.query, nil))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var body LocalesResponse
+			assert.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+			locales := make([]string, 0, len(body.Locales))
+			for _, info := range body.Locales {
+				locales = append(locales, info.Locale)
+			}
+			assert.Equal(t, tt.expected, locales)
+			assert.Equal(t, 4, body.Total)
+		})
+	}
+}
+
+func TestTranslatableResource_BatchGet(t *testing.T) {
+	first, second, missing := uuid.New(), uuid.New(), uuid.New()
+	queried := false
+	db := &mocks.MockDatabase{
+		QueryFunc: func(ctx context.Context, query string, args ...interface{}) (database.Rows, error) {
+			queried = true
+			rows := mocks.NewMockRows(2)
+			rows.ScanFunc = func(row int, dest ...interface{}) error {
+				// Rows come back in database order, not request order.
+				*dest[0].(*uuid.UUID) = []uuid.UUID{first, second}[row]
+				return nil
+			}
+			return rows, nil
+		},
+	}
+	config := DefaultConfig()
+	config.MaxBulkLookup = 3
+	app, resource := setupTestApp(db, &config)
+	app.Post("/translations/batch-get", resource.BatchGet)
+
+	body := `{"ids":["` + second.String() + `","` + missing.String() + `","` + first.String() + `"]}`
+	req := httptest.NewRequest(fiber.MethodPost, "/translations/batch-get", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := app.Test(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var result BatchGetResponseDTO
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+	assert.NoError(t, json.NewDecoder(resp.Body).Decode(&result))
+	assert.Len(t, result.Data, 2)
+	assert.Equal(t, second, result.Data[0].ID)
+	assert.Equal(t, first, result.Data[1].ID)
+	assert.Equal(t, []uuid.UUID{missing}, result.Missing)
+
+	queried = false
+	body = `{"ids":["` + uuid.NewString() + `","` + uuid.NewString() + `","` + uuid.NewString() + `","` + uuid.NewString() + `"]}`
+	req = httptest.NewRequest(fiber.MethodPost, "/translations/batch-get", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	resp, err = app.Test(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+	assert.False(t, queried)
+}
+
+func TestTranslatableResource_GetAll_IDs(t *testing.T) {
+	first, missing := uuid.New(), uuid.New()
+	db := &mocks.MockDatabase{
+		QueryFunc: func(ctx context.Context, query string, args ...interface{}) (database.Rows, error) {
+			rows := mocks.NewMockRows(1)
+			rows.ScanFunc = func(row int, dest ...interface{}) error {
+				*dest[0].(*uuid.UUID) = first
+				return nil
+			}
+			return rows, nil
+		},
+	}
+	config := DefaultConfig()
+	config.MaxBulkLookup = 2
+	app, resource := setupTestApp(db, &config)
+	app.Get("/translations", resource.GetAll)
+
+	resp, err := app.Test(httptest.NewRequest(fiber.MethodGet, "/translations?ids="+missing.String()+","+first.String(), nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var result BatchGetResponseDTO
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+	assert.NoError(t, json.NewDecoder(resp.Body).Decode(&result))
+	if assert.Len(t, result.Data, 1) {
+		assert.Equal(t, first, result.Data[0].ID)
+	}
+	assert.Equal(t, []uuid.UUID{missing}, result.Missing)
+
+	for _, ids := range []string{"nope", uuid.NewString() + "," + uuid.NewString() + "," + uuid.NewString()} {
+		resp, err = app.Test(httptest.NewRequest(fiber.MethodGet, "/translations?ids="+ids, nil))
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+	}
+}
+
+func TestTranslatableResource_GetSchema(t *testing.T) {
+	config := DefaultConfig()
+	config.AllowedTypes = []string{"articles", "posts", "products"}
+	config.ContentSchemas = map[string]json.RawMessage{
+		"articles": json.RawMessage(`{"type":"object","required":["title"]}`),
+	}
+	app, resource := setupTestApp(nil, &config)
+	app.Get("/translations/schema", resource.GetSchema)
+
+	resp, err := app.Test(httptest.NewRequest(fiber.MethodGet, "/translations/schema?translatable=articles", nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	body, _ := io.ReadAll(resp.Body)
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+	assert.Equal(t, "application/schema+json", resp.Header.Get(fiber.HeaderContentType))
+	assert.JSONEq(t, `{"type":"object","required":["title"]}`, string(body))
+
+	resp, err = app.Test(httptest.NewRequest(fiber.MethodGet, "/translations/schema?translatable=posts", nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, fiber.StatusNotFound, resp.StatusCode)
+
+	config.DefaultContentSchema = json.RawMessage(`{"type":"string"}`)
+	resp, err = app.Test(httptest.NewRequest(fiber.MethodGet, "/translations/schema?translatable=posts", nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	body, _ = io.ReadAll(resp.Body)
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+	assert.JSONEq(t, `{"type":"string"}`, string(body))
+
+	resp, err = app.Test(httptest.NewRequest(fiber.MethodGet, "/translations/schema?translatable=users", nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+}
+
+func TestTranslatableResource_GetByID_LocaleFallback(t *testing.T) {
+	entityID := uuid.New()
+	available := []string{"en", "fr"}
+	db := &mocks.MockDatabase{
+		QueryFunc: func(ctx context.Context, query string, args ...interface{}) (database.Rows, error) {
+			rows := mocks.NewMockRows(len(available))
+			rows.ScanFunc = func(row int, dest ...interface{}) error {
+				*dest[2].(*uuid.UUID) = entityID
+				*dest[4].(*string) = available[row]
+				return nil
+			}
+			return rows, nil
+		},
+	}
+
+	tests := []struct {
+		name     string
+		strategy string
+		locale   string
+		status   int
+		served   string
+		fallback string
+	}{
+		{name: "exact match", strategy: FallbackChainThenDefault, locale: "fr", status: fiber.StatusOK, served: "fr"},
+		{name: "regional to language", strategy: FallbackChainThenDefault, locale: "fr-CA", status: fiber.StatusOK, served: "fr", fallback: "true"},
+		{name: "missing to default", strategy: FallbackChainThenDefault, locale: "de", status: fiber.StatusOK, served: "en", fallback: "true"},
+		{name: "nothing in chain", strategy: FallbackChainOnly, locale: "de", status: fiber.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := DefaultConfig()
+			config.SupportedLocales = []string{"en", "fr", "de"}
+			config.FallbackChain = map[string][]string{"fr-CA": {"fr"}}
+			config.FallbackStrategy = tt.strategy
+			app, resource := setupTestApp(db, &config)
+			app.Get("/translations/:id", resource.GetByID)
+
+			resp, err := app.Test(httptest.NewRequest(fiber.MethodGet, "/translations/"+entityID.String()+"?translatable=post&locale="+tt.locale, nil))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			assert.Equal(t, tt.status, resp.StatusCode)
+			if tt.status != fiber.StatusOK {
+				return
+			}
+			var body TranslatableResponseDTO
+			assert.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+			assert.Equal(t, tt.served, body.Locale)
+			assert.Equal(t, tt.served, resp.Header.Get(fiber.HeaderContentLanguage))
+			assert.Equal(t, tt.fallback, resp.Header.Get(HeaderLocaleFallback))
+		})
+	}
+
+	t.Run("without a type", func(t *testing.T) {
+		config := DefaultConfig()
+		app, resource := setupTestApp(db, &config)
+		app.Get("/translations/:id", resource.GetByID)
+
+		resp, err := app.Test(httptest.NewRequest(fiber.MethodGet, "/translations/"+entityID.String()+"?locale=fr", nil))
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode, "the id could be a translation's")
+	})
+}
+
+func TestTranslatableResource_GetByID_AcceptLanguage(t *testing.T) {
+	entityID := uuid.New()
+	available := []string{"en", "fr"}
+	db := &mocks.MockDatabase{
+		QueryFunc: func(ctx context.Context, query string, args ...interface{}) (database.Rows, error) {
+			rows := mocks.NewMockRows(len(available))
+			rows.ScanFunc = func(row int, dest ...interface{}) error {
+				if len(dest) == 1 {
+					*dest[0].(*string) = available[row]
+					return nil
+				}
+				*dest[2].(*uuid.UUID) = entityID
+				*dest[4].(*string) = available[row]
+				return nil
+			}
+			return rows, nil
+		},
+	}
+
+	tests := []struct {
+		name   string
+		header string
+		served string
+	}{
+		{name: "best available by quality", header: "de-CH, de;q=0.9, fr;q=0.8, en;q=0.5", served: "fr"},
+		{name: "quality outranks order", header: "en;q=0.3, fr;q=0.7", served: "fr"},
+		{name: "regional variant", header: "en-GB", served: "en"},
+		{name: "nothing matches", header: "ja, de;q=0.5", served: "en"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := DefaultConfig()
+			config.SupportedLocales = []string{"en", "fr", "de"}
+			app, resource := setupTestApp(db, &config)
+			app.Get("/translations/:id", resource.GetByID)
+
+			req := httptest.NewRequest(fiber.MethodGet, "/translations/"+entityID.String()+"?translatable=post", nil)
+			req.Header.Set(fiber.HeaderAcceptLanguage, tt.header)
+			resp, err := app.Test(req)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+			var body TranslatableResponseDTO
+			assert.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+			assert.Equal(t, tt.served, body.Locale)
+			assert.Equal(t, tt.served, resp.Header.Get(fiber.HeaderContentLanguage))
+			assert.Equal(t, fiber.HeaderAcceptLanguage, resp.Header.Get(fiber.HeaderVary))
+		})
+	}
+}
+
+func TestTranslatableResource_GetByID_AcceptLanguageOnTranslation(t *testing.T) {
+	id := uuid.New()
+	queries := 0
+	db := &mocks.MockDatabase{
+		QueryFunc: func(ctx context.Context, query string, args ...interface{}) (database.Rows, error) {
+			queries++
+			return mocks.NewMockRows(0), nil
+		},
+		QueryRowFunc: func(ctx context.Context, query string, args ...interface{}) database.Row {
+			return &mocks.MockRow{ScanFunc: func(dest ...interface{}) error {
+				*dest[0].(*uuid.UUID) = id
+				*dest[4].(*string) = "de"
+				return nil
+			}}
+		},
+	}
+	config := DefaultConfig()
+	app, resource := setupTestApp(db, &config)
+	app.Get("/translations/:id", resource.GetByID)
+
+	req := httptest.NewRequest(fiber.MethodGet, "/translations/"+id.String(), nil)
+	req.Header.Set(fiber.HeaderAcceptLanguage, "fr, en;q=0.5")
+	resp, err := app.Test(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+	assert.Zero(t, queries, "a translation id is read without looking for an entity")
+	assert.Empty(t, resp.Header.Get(fiber.HeaderVary))
+}
+
+func TestTranslatableResource_GetAll_HydraDocs(t *testing.T) {
+	config := DefaultConfig()
+	config.HydraDocs = true
+	db := &mocks.MockDatabase{
+		QueryRowFunc: func(ctx context.Context, query string, args ...interface{}) database.Row {
+			return &mocks.MockRow{ScanFunc: func(dest ...interface{}) error {
+				*dest[0].(*int) = 0
+				return nil
+			}}
+		},
+	}
+	app, resource := setupTestApp(db, &config)
+	app.Get("/translations", resource.GetAll)
+
+	req := httptest.NewRequest(fiber.MethodGet, "/translations", nil)
+	req.Header.Set(fiber.HeaderAccept, "application/ld+json")
+	resp, err := app.Test(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var doc map[string]any
+	assert.NoError(t, json.NewDecoder(resp.Body).Decode(&doc))
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+	assert.Equal(t, "application/ld+json", resp.Header.Get(fiber.HeaderContentType))
+	assert.Equal(t, "hydra:ApiDocumentation", doc["@type"])
+	assert.Len(t, doc["hydra:supportedClass"], 2)
+
+	req = httptest.NewRequest(fiber.MethodGet, "/translations?locale=fr", nil)
+	req.Header.Set(fiber.HeaderAccept, "application/ld+json")
+	resp, err = app.Test(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	body, _ := io.ReadAll(resp.Body)
+	assert.Contains(t, string(body), "hydra:member")
+
+	config.HydraDocs = false
+	req = httptest.NewRequest(fiber.MethodGet, "/translations", nil)
+	req.Header.Set(fiber.HeaderAccept, "application/ld+json")
+	resp, err = app.Test(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	body, _ = io.ReadAll(resp.Body)
+	assert.Contains(t, string(body), "hydra:member")
+}
+
+type translatorFunc func(ctx context.Context, resourceType, resourceID string, userID *uuid.UUID) (*TranslationResult, error)
+
+func (f translatorFunc) Translate(ctx context.Context, resourceType, resourceID string, userID *uuid.UUID) (*TranslationResult, error) {
+	return f(ctx, resourceType, resourceID, userID)
+}
+
+func TestTranslatableResource_Resolve_TranslateOnMiss(t *testing.T) {
+	entityID := uuid.New()
+
+	tests := []struct {
+		name       string
+		query      string
+		translator func(available *[]string) Translator
+		anonymous  bool
+		exhausted  bool
+		served     string
+		auto       bool
+		flagged    bool
+	}{
+		{
+			name: "stores and serves the machine translation",
+			translator: func(available *[]string) Translator {
+				return translatorFunc(func(ctx context.Context, resourceType, resourceID string, _ *uuid.UUID) (*TranslationResult, error) {
+					assert.Equal(t, "post", resourceType)
+					assert.Equal(t, entityID.String(), resourceID)
+					*available = append(*available, "de")
+					return &TranslationResult{Translated: []string{"de"}}, nil
+				})
+			},
+			served:  "de",
+			auto:    true,
+			flagged: true,
+		},
+		{
+			name:      "anonymous readers get the fallback",
+			anonymous: true,
+			translator: func(*[]string) Translator {
+				return &mockTranslator{err: errors.New("must not be called")}
+			},
+			served: "en",
+		},
+		{
+			name:      "exhausted rate limit degrades to fallback",
+			exhausted: true,
+			translator: func(*[]string) Translator {
+				return &mockTranslator{err: errors.New("must not be called")}
+			},
+			served: "en",
+		},
+		{
+			name:  "opted out per request",
+			query: "&translate=false",
+			translator: func(*[]string) Translator {
+				return &mockTranslator{err: errors.New("must not be called")}
+			},
+			served: "en",
+		},
+		{
+			name: "provider timeout degrades to fallback",
+			translator: func(*[]string) Translator {
+				return blockingTranslator{}
+			},
+			served: "en",
+		},
+		{
+			name: "locale not produced degrades to fallback",
+			translator: func(*[]string) Translator {
+				return &mockTranslator{result: &TranslationResult{Failed: []string{"de"}}}
+			},
+			served: "en",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			available := []string{"en"}
+			flagged := false
+			db := &mocks.MockDatabase{
+				QueryFunc: func(ctx context.Context, query string, args ...interface{}) (database.Rows, error) {
+					rows := mocks.NewMockRows(len(available))
+					rows.ScanFunc = func(row int, dest ...interface{}) error {
+						*dest[2].(*uuid.UUID) = entityID
+						*dest[3].(*string) = "post"
+						*dest[4].(*string) = available[row]
+						*dest[9].(*bool) = available[row] == "de"
+						return nil
+					}
+					return rows, nil
+				},
+				ExecFunc: func(ctx context.Context, query string, args ...interface{}) (database.Result, error) {
+					flagged = strings.HasPrefix(query, "UPDATE translations SET auto_translated")
+					return nil, nil
+				},
+			}
+			config := DefaultConfig()
+			config.SupportedLocales = []string{"en", "fr", "de"}
+			config.TranslateOnMiss = true
+			config.TranslateOnMissTimeout = 10 * time.Millisecond
+			config.RateLimit = RateLimitConfig{Requests: 1, Window: time.Minute}
+			config.RateLimitStore = NewMemoryRateLimitStore()
+			userID := uuid.New()
+			if tt.exhausted {
+				_, _, _ = config.RateLimitStore.Take(context.Background(), "user:"+userID.String(), 1, 1, time.Minute)
+			}
+			app, resource := setupTestApp(db, &config)
+			translator := tt.translator(&available)
+			resource.translator = &translator
+			app.Use(func(c fiber.Ctx) error {
+				if !tt.anonymous {
+					authcontext.SetUserID(c, userID.String())
+				}
+				return c.Next()
+			})
+			app.Get("/translations/resolve", resource.Resolve)
+
+			resp, err := app.Test(httptest.NewRequest(fiber.MethodGet,
+				"/translations/resolve?translatable=post&translatable_id="+entityID.String()+"&locale=de"+tt.query, nil))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var body TranslatableResponseDTO
+			assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+			assert.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+			assert.Equal(t, tt.served, body.Locale)
+			assert.Equal(t, tt.auto, body.AutoTranslated)
+			assert.Equal(t, tt.auto, resp.Header.Get(HeaderTranslationSource) == TranslationSourceMachine)
+			assert.Equal(t, tt.flagged, flagged)
+		})
+	}
+}
+
+func TestTranslatableResource_GetAll_PaginationMeta(t *testing.T) {
+	config := DefaultConfig()
+	var listed string
+	db := &mocks.MockDatabase{
+		QueryFunc: func(ctx context.Context, query string, args ...interface{}) (database.Rows, error) {
+			listed = query
+			return mocks.NewMockRows(0), nil
+		},
+		QueryRowFunc: func(ctx context.Context, query string, args ...interface{}) database.Row {
+			return &mocks.MockRow{ScanFunc: func(dest ...interface{}) error {
+				*dest[0].(*int) = 0
+				return nil
+			}}
+		},
+	}
+	app, resource := setupTestApp(db, &config)
+	app.Get("/translations", resource.GetAll)
+
+	tests := []struct {
+		target   string
+		expected paginationMeta
+	}{
+		{target: "/translations", expected: paginationMeta{AppliedLimit: 20}},
+		{target: "/translations?limit=500&page=2&offset=7", expected: paginationMeta{
+			AppliedLimit: 100, AppliedOffset: 100, RequestedLimit: intPtr(500),
+		}},
+		{target: "/translations?limit=0", expected: paginationMeta{AppliedLimit: 20, RequestedLimit: intPtr(0)}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.target, func(t *testing.T) {
+			resp, err := app.Test(httptest.NewRequest(fiber.MethodGet, tt.target, nil))
+			if err != nil {
+				t.Fatal(err)
+			}
+			body, _ := io.ReadAll(resp.Body)
+
+			var meta paginationMeta
+			assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+			assert.NoError(t, json.Unmarshal(body, &meta))
+			assert.Equal(t, tt.expected, meta)
+			assert.Contains(t, listed, fmt.Sprintf("LIMIT %d", meta.AppliedLimit))
+			assert.Less(t, strings.Index(string(body), "hydra:view"), strings.Index(string(body), "applied_limit"))
+		})
+	}
+}
+
+func TestTranslatableResource_GetAll_HydraView(t *testing.T) {
+	config := DefaultConfig()
+	db := &mocks.MockDatabase{
+		QueryRowFunc: func(ctx context.Context, query string, args ...interface{}) database.Row {
+			return &mocks.MockRow{ScanFunc: func(dest ...interface{}) error {
+				*dest[0].(*int) = 45
+				return nil
+			}}
+		},
+	}
+	app, resource := setupTestApp(db, &config)
+	app.Get("/translations", resource.GetAll)
+
+	tests := []struct {
+		name     string
+		target   string
+		expected pagination.HydraView
+	}{
+		{
+			name:   "middle page",
+			target: "/translations?locale=fr&translatable=post&limit=10&page=2",
+			expected: pagination.HydraView{
+				ID:       "/translations?limit=10&locale=fr&page=2&translatable=post",
+				Type:     "hydra:PartialCollectionView",
+				First:    "/translations?limit=10&locale=fr&translatable=post",
+				Last:     strPtr("/translations?limit=10&locale=fr&page=5&translatable=post"),
+				Previous: strPtr("/translations?limit=10&locale=fr&translatable=post"),
+				Next:     strPtr("/translations?limit=10&locale=fr&page=3&translatable=post"),
+			},
+		},
+		{
+			name:   "last page",
+			target: "/translations?locale=fr&limit=10&page=5",
+			expected: pagination.HydraView{
+				ID:       "/translations?limit=10&locale=fr&page=5",
+				Type:     "hydra:PartialCollectionView",
+				First:    "/translations?limit=10&locale=fr",
+				Last:     strPtr("/translations?limit=10&locale=fr&page=5"),
+				Previous: strPtr("/translations?limit=10&locale=fr&page=4"),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := app.Test(httptest.NewRequest(fiber.MethodGet, tt.target, nil))
+			if err != nil {
+				t.Fatal(err)
+			}
+			body, _ := io.ReadAll(resp.Body)
+
+			var collection struct {
+				View pagination.HydraView `json:"hydra:view"`
+			}
+			assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+			assert.NoError(t, json.Unmarshal(body, &collection))
+			assert.Equal(t, tt.expected, collection.View)
+		})
+	}
+}
+
+func strPtr(v string) *string {
+	return &v
+}
+
+func intPtr(v int) *int {
+	return &v
+}
+
+func TestTranslatableResource_Update_Locale(t *testing.T) {
+	id := uuid.New()
+	tests := []struct {
+		name   string
+		locale string
+		status int
+	}{
+		{name: "locale change is persisted", locale: "fr", status: fiber.StatusOK},
+		{name: "unsupported locale", locale: "it", status: fiber.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var updateSQL string
+			var updateArgs []interface{}
+			db := &mocks.MockDatabase{
+				QueryRowFunc: func(ctx context.Context, query string, args ...interface{}) database.Row {
+					return &mocks.MockRow{ScanFunc: func(dest ...interface{}) error {
+						if len(dest) == 1 {
+							return sql.ErrNoRows
+						}
+						*dest[0].(*uuid.UUID) = id
+						*dest[3].(*string) = "post"
+						*dest[4].(*string) = "en"
+						return nil
+					}}
+				},
+				ExecFunc: func(ctx context.Context, query string, args ...interface{}) (database.Result, error) {
+					updateSQL = query
+					updateArgs = args
+					return mocks.NewMockResult(1), nil
+				},
+			}
+			config := DefaultConfig()
+			app, resource := setupTestApp(db, &config)
+			app.Put("/translations/:id", resource.Update)
+
+			req := httptest.NewRequest(fiber.MethodPut, "/translations/"+id.String(), strings.NewReader(`{"locale":"`+tt.locale+`","content":"Bonjour"}`))
+			req.Header.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+			resp, err := app.Test(req)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			assert.Equal(t, tt.status, resp.StatusCode)
+			if tt.status != fiber.StatusOK {
+				assert.Empty(t, updateSQL)
+				return
+			}
+			assert.Contains(t, updateSQL, "locale = ")
+			assert.Contains(t, updateArgs, tt.locale)
+		})
+	}
+}
+
+func TestTranslatableResource_Delete_SoftDelete(t *testing.T) {
+	tests := []struct {
+		name       string
+		softDelete bool
+		dialect    database.Dialect
+		expected   string
+	}{
+		{name: "hard delete", expected: "DELETE FROM translations WHERE id = $1"},
+		{name: "soft delete", softDelete: true, expected: "UPDATE translations SET deleted_at = $1, updated_by = $2 WHERE (id = $3 AND deleted_at IS NULL)"},
+		{name: "hard delete with quoted identifiers", dialect: &mocks.QuotingDialect{}, expected: `DELETE FROM "translations" WHERE "id" = $1`},
+		{name: "soft delete with quoted identifiers", softDelete: true, dialect: &mocks.QuotingDialect{},
+			expected: `UPDATE "translations" SET "deleted_at" = $1, "updated_by" = $2 WHERE ("id" = $3 AND "deleted_at" IS NULL)`},
+	}
+
+	userID := uuid.New()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var execSQL string
+			var execArgs []interface{}
+			var events []string
+			db := &mocks.MockDatabase{
+				QueryRowFunc: func(ctx context.Context, query string, args ...interface{}) database.Row {
+					return &mocks.MockRow{ScanFunc: func(dest ...interface{}) error { return nil }}
+				},
+				ExecFunc: func(ctx context.Context, query string, args ...interface{}) (database.Result, error) {
+					execSQL, execArgs = query, args
+					return mocks.NewMockResult(1), nil
+				},
+				SQLDialect: tt.dialect,
+			}
+			config := DefaultConfig()
+			config.SoftDelete = tt.softDelete
+			config.EventHandler = func(ctx context.Context, event TranslationEvent) {
+				events = append(events, event.Type)
+			}
+			app, resource := setupTestApp(db, &config)
+			app.Use(func(c fiber.Ctx) error {
+				authcontext.SetUserID(c, userID.String())
+				return c.Next()
+			})
+			app.Delete("/translations/:id", resource.Delete)
+
+			resp, err := app.Test(httptest.NewRequest(fiber.MethodDelete, "/translations/"+uuid.NewString(), nil))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			assert.Equal(t, fiber.StatusNoContent, resp.StatusCode)
+			assert.Equal(t, tt.expected, execSQL)
+			if tt.softDelete {
+				assert.Equal(t, &userID, execArgs[1])
+			}
+			assert.Equal(t, []string{EventDeleted}, events)
+		})
+	}
+}
+
+func TestTranslatableResource_Restore(t *testing.T) {
+	owner := uuid.New()
+	tests := []struct {
+		name    string
+		deleted bool
+		status  int
+	}{
+		{name: "restores deleted translation", deleted: true, status: fiber.StatusOK},
+		{name: "not deleted", status: fiber.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var restoreSQL string
+			db := &mocks.MockDatabase{
+				QueryRowFunc: func(ctx context.Context, query string, args ...interface{}) database.Row {
+					if strings.HasSuffix(query, "deleted_at IS NOT NULL") && !tt.deleted {
+						return &mocks.MockRow{}
+					}
+					return &mocks.MockRow{ScanFunc: func(dest ...interface{}) error {
+						*dest[1].(**uuid.UUID) = &owner
+						*dest[5].(*string) = "Bonjour"
+						return nil
+					}}
+				},
+				ExecFunc: func(ctx context.Context, query string, args ...interface{}) (database.Result, error) {
+					restoreSQL = query
+					return mocks.NewMockResult(1), nil
+				},
+			}
+			config := DefaultConfig()
+			config.SoftDelete = true
+			app, resource := setupTestApp(db, &config)
+			app.Post("/translations/:id/restore", resource.Restore)
+
+			resp, err := app.Test(httptest.NewRequest(fiber.MethodPost, "/translations/"+uuid.NewString()+"/restore", nil))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			assert.Equal(t, tt.status, resp.StatusCode)
+			if tt.deleted {
+				assert.Equal(t, "UPDATE translations SET deleted_at = NULL, updated_at = $1 WHERE id = $2 AND deleted_at IS NOT NULL", restoreSQL)
+			} else {
+				assert.Empty(t, restoreSQL)
+			}
+		})
+	}
+}
+
+func TestTranslatableResource_Trash(t *testing.T) {
+	id, deletedBy := uuid.New(), uuid.New()
+	deletedAt := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name        string
+		query       string
+		hideContent bool
+		status      int
+		columns     string
+		content     *string
+	}{
+		{name: "lists deleted translations", status: fiber.StatusOK, columns: "id, translatable, locale, deleted_at, updated_by, content", content: new("Bonjour")},
+		{name: "hides content", hideContent: true, status: fiber.StatusOK, columns: "id, translatable, locale, deleted_at, updated_by"},
+		{name: "filters by type", query: "?translatable=post", status: fiber.StatusOK, columns: "id, translatable, locale, deleted_at, updated_by, content", content: new("Bonjour")},
+		{name: "unknown type", query: "?translatable=page", status: fiber.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var listSQL string
+			db := &mocks.MockDatabase{
+				QueryRowFunc: func(ctx context.Context, query string, args ...interface{}) database.Row {
+					return &mocks.MockRow{ScanFunc: func(dest ...interface{}) error {
+						*dest[0].(*int) = 1
+						return nil
+					}}
+				},
+				QueryFunc: func(ctx context.Context, query string, args ...interface{}) (database.Rows, error) {
+					listSQL = query
+					rows := mocks.NewMockRows(1)
+					rows.ScanFunc = func(row int, dest ...interface{}) error {
+						*dest[0].(*uuid.UUID) = id
+						*dest[1].(*string) = "post"
+						*dest[2].(*string) = "fr"
+						*dest[3].(*time.Time) = deletedAt
+						*dest[4].(**uuid.UUID) = &deletedBy
+						if len(dest) > 5 {
+							*dest[5].(**string) = new("Bonjour")
+						}
+						return nil
+					}
+					return rows, nil
+				},
+			}
+			config := DefaultConfig()
+			config.AllowedTypes = []string{"post"}
+			config.SoftDelete = true
+			config.TrashHidesContent = tt.hideContent
+			config.ResponseFormat = ResponseFormatPlain
+			app, resource := setupTestApp(db, &config)
+			app.Get("/translations/trash", resource.Trash)
+
+			resp, err := app.Test(httptest.NewRequest(fiber.MethodGet, "/translations/trash"+tt.query, nil))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			assert.Equal(t, tt.status, resp.StatusCode)
+			if tt.status != fiber.StatusOK {
+				assert.Empty(t, listSQL)
+				return
+			}
+			assert.True(t, strings.HasPrefix(listSQL, "SELECT "+tt.columns+" FROM translations WHERE deleted_at IS NOT NULL"), listSQL)
+			assert.Equal(t, tt.query != "", strings.Contains(listSQL, "AND translatable = $1"))
+
+			var body struct {
+				Data []map[string]any `json:"data"`
+			}
+			if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+				t.Fatal(err)
+			}
+			if assert.Len(t, body.Data, 1) {
+				item := body.Data[0]
+				assert.Equal(t, id.String(), item["id"])
+				assert.Equal(t, "post", item["translatable"])
+				assert.Equal(t, "fr", item["locale"])
+				assert.Equal(t, "2026-10-01T12:00:00Z", item["deleted_at"])
+				assert.Equal(t, deletedBy.String(), item["updated_by"])
+				content, ok := item["content"]
+				assert.Equal(t, tt.content != nil, ok)
+				if tt.content != nil {
+					assert.Equal(t, *tt.content, content)
+				}
+			}
+		})
+	}
+}
+
+func TestTranslatableResource_Create_OverSoftDeleted(t *testing.T) {
+	entityID, storedID := uuid.New(), uuid.New()
+	tests := []struct {
+		name    string
+		deleted bool
+		status  int
+	}{
+		{name: "restores the deleted translation", deleted: true, status: fiber.StatusCreated},
+		{name: "live translation", status: fiber.StatusConflict},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stored := func(ctx context.Context, query string, args ...interface{}) (database.Rows, error) {
+				rows := mocks.NewMockRows(1)
+				rows.ScanFunc = func(row int, dest ...interface{}) error {
+					*dest[0].(*uuid.UUID) = storedID
+					*dest[3].(*string) = "post"
+					*dest[4].(*string) = "fr"
+					*dest[5].(*string) = "Bonjour"
+					if tt.deleted {
+						deletedAt := time.Now().Add(-time.Hour)
+						*dest[14].(**time.Time) = &deletedAt
+					}
+					return nil
+				}
+				return rows, nil
+			}
+			var writes []string
+			tx := &mocks.MockTx{
+				QueryFunc: stored,
+				ExecFunc: func(ctx context.Context, query string, args ...interface{}) (database.Result, error) {
+					writes = append(writes, query)
+					return mocks.NewMockResult(1), nil
+				},
+			}
+			db := &mocks.MockDatabase{
+				QueryFunc: stored,
+				ExecFunc: func(ctx context.Context, query string, args ...interface{}) (database.Result, error) {
+					writes = append(writes, query)
+					return nil, errors.New(`ERROR: duplicate key value (SQLSTATE 23505)`)
+				},
+				BeginFunc: func(ctx context.Context) (database.Tx, error) { return tx, nil },
+			}
+			config := DefaultConfig()
+			config.SoftDelete = true
+			config.AllowedTypes = []string{"post"}
+			app, resource := setupTestApp(db, &config)
+			app.Post("/translations", resource.Create)
+
+			req := httptest.NewRequest(fiber.MethodPost, "/translations", strings.NewReader(`{"translatable":"post","translatableId":"`+entityID.String()+`","locale":"fr","content":"Salut"}`))
+			req.Header.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+			resp, err := app.Test(req)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			assert.Equal(t, tt.status, resp.StatusCode)
+			if !tt.deleted {
+				return
+			}
+			assert.Len(t, writes, 1)
+			assert.True(t, strings.HasPrefix(writes[0], "UPDATE translations SET "), "the deleted row is taken over, not inserted: %s", writes[0])
+			assert.Equal(t, "/translations/"+storedID.String(), resp.Header.Get(fiber.HeaderLocation))
+		})
+	}
+}
+
+func TestTranslatableResource_Completeness(t *testing.T) {
+	entityID := uuid.New()
+	var queries []string
+	db := &mocks.MockDatabase{
+		QueryFunc: func(ctx context.Context, query string, args ...interface{}) (database.Rows, error) {
+			queries = append(queries, query)
+			assert.Equal(t, []interface{}{"post", entityID}, args[:2])
+			rows := mocks.NewMockRows(2)
+			rows.ScanFunc = func(row int, dest ...interface{}) error {
+				*dest[0].(*string) = []string{"fr", "en"}[row]
+				return nil
+			}
+			return rows, nil
+		},
+	}
+	config := DefaultConfig()
+	config.AllowedTypes = []string{"post"}
+	config.SupportedLocales = []string{"en", "fr", "es", "de"}
+	app, resource := setupTestApp(db, &config)
+	app.Get("/translations/:translatable_id/completeness", resource.Completeness)
+
+	resp, err := app.Test(httptest.NewRequest(fiber.MethodGet, "/translations/"+entityID.String()+"/completeness?translatable=post", nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+	if assert.Len(t, queries, 1) {
+		assert.Contains(t, queries[0], "GROUP BY locale")
+	}
+	var report CompletenessReport
+	assert.NoError(t, json.NewDecoder(resp.Body).Decode(&report))
+	assert.Equal(t, []LocaleInfo{
+		{Locale: "en", IsDefault: true, Direction: DirectionLTR, DisplayName: "English"},
+		{Locale: "fr", Direction: DirectionLTR, DisplayName: "français"},
+	}, report.Translated)
+	assert.Equal(t, []LocaleInfo{
+		{Locale: "es", Direction: DirectionLTR, DisplayName: "español"},
+		{Locale: "de", Direction: DirectionLTR, DisplayName: "Deutsch"},
+	}, report.Missing)
+	assert.Equal(t, 4, report.Total)
+	assert.Equal(t, 50.0, report.Percentage)
+
+	resp, err = app.Test(httptest.NewRequest(fiber.MethodGet, "/translations/"+entityID.String()+"/completeness?translatable=page", nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+}
+
+func TestTranslatableResource_EntityLocales(t *testing.T) {
+	entityID := uuid.New()
+	tests := []struct {
+		name         string
+		translatable string
+		stored       map[string]string
+		body         string
+	}{
+		{
+			name: "plain", translatable: "comment",
+			stored: map[string]string{"de": "Hallo", "fr": "Bonjour", "en": "Hello"},
+			body:   `{"en":"Hello","fr":"Bonjour","de":"Hallo"}`,
+		},
+		{
+			name: "fields", translatable: "post",
+			stored: map[string]string{"fr": `{"title":"Bonjour"}`, "en": `{"title":"Hello"}`},
+			body:   `{"en":{"title":"Hello"},"fr":{"title":"Bonjour"}}`,
+		},
+		{name: "none", translatable: "comment", body: `{}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			locales := slices.Sorted(maps.Keys(tt.stored))
+			var queries []string
+			db := &mocks.MockDatabase{
+				QueryFunc: func(ctx context.Context, query string, args ...interface{}) (database.Rows, error) {
+					queries = append(queries, query)
+					assert.Contains(t, args, entityID)
+					assert.Contains(t, args, tt.translatable)
+					rows := mocks.NewMockRows(len(locales))
+					rows.ScanFunc = func(row int, dest ...interface{}) error {
+						*dest[2].(*uuid.UUID) = entityID
+						*dest[3].(*string) = tt.translatable
+						*dest[4].(*string) = locales[row]
+						*dest[5].(*string) = tt.stored[locales[row]]
+						return nil
+					}
+					return rows, nil
+				},
+			}
+			config := fieldsConfig()
+			app, resource := setupTestApp(db, &config)
+			app.Get("/translations/:translatable_id/locales", resource.EntityLocales)
+
+			resp, err := app.Test(httptest.NewRequest(fiber.MethodGet, "/translations/"+entityID.String()+"/locales?translatable="+tt.translatable, nil))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+			if assert.Len(t, queries, 1) {
+				assert.Contains(t, queries[0], "translatable_id = ")
+				assert.Contains(t, queries[0], "deleted_at IS NULL")
+			}
+			var body struct {
+				TranslatableID uuid.UUID       `json:"translatable_id"`
+				Default        string          `json:"default"`
+				Translations   json.RawMessage `json:"translations"`
+			}
+			assert.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+			assert.Equal(t, entityID, body.TranslatableID)
+			assert.Equal(t, "en", body.Default)
+			assert.Equal(t, tt.body, string(body.Translations))
+		})
+	}
+}
+
+func TestTranslatableResource_EntityLocales_TypeNotAllowed(t *testing.T) {
+	config := DefaultConfig()
+	app, resource := setupTestApp(&mocks.MockDatabase{}, &config)
+	app.Get("/translations/:translatable_id/locales", resource.EntityLocales)
+
+	resp, err := app.Test(httptest.NewRequest(fiber.MethodGet, "/translations/"+uuid.NewString()+"/locales?translatable=page", nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+}
+
+func TestTranslatableResource_Create_Location(t *testing.T) {
+	tests := []struct {
+		name   string
+		accept string
+		prefix string
+	}{
+		{name: "json-ld", prefix: "/translations"},
+		{name: "json", accept: fiber.MIMEApplicationJSON, prefix: "/translations"},
+		{name: "custom prefix", accept: fiber.MIMEApplicationJSON, prefix: "/i18n/translations"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := &mocks.MockDatabase{
+				QueryRowFunc: func(ctx context.Context, query string, args ...interface{}) database.Row {
+					return &mocks.MockRow{ScanFunc: func(dest ...interface{}) error { return sql.ErrNoRows }}
+				},
+				ExecFunc: func(ctx context.Context, query string, args ...interface{}) (database.Result, error) {
+					return mocks.NewMockResult(1), nil
+				},
+			}
+			config := DefaultConfig()
+			config.RoutePrefix = tt.prefix
+			app := fiber.New()
+			RegisterTranslatableRoutes(app, db, &config, nil, nil)
+
+			body := `{"translatableId":"` + uuid.NewString() + `","translatable":"post","locale":"fr","content":"Bonjour"}`
+			req := httptest.NewRequest(fiber.MethodPost, tt.prefix, strings.NewReader(body))
+			req.Header.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+			if tt.accept != "" {
+				req.Header.Set(fiber.HeaderAccept, tt.accept)
+			}
+			resp, err := app.Test(req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			assert.Equal(t, fiber.StatusCreated, resp.StatusCode)
+
+			var created map[string]any
+			assert.NoError(t, json.NewDecoder(resp.Body).Decode(&created))
+			location := resp.Header.Get(fiber.HeaderLocation)
+			assert.Equal(t, tt.prefix+"/"+created["id"].(string), location)
+			assert.Equal(t, location, created["@id"])
+			assert.Equal(t, "Bonjour", created["content"])
+		})
+	}
+}
+
+func TestTranslatableResource_HeadByID(t *testing.T) {
+	id := uuid.New()
+	config := DefaultConfig()
+	app := fiber.New()
+	RegisterTranslatableRoutes(app, etagTestDatabase(id, time.Now().UTC(), new([]string)), &config, nil, nil)
+
+	get, err := app.Test(httptest.NewRequest(fiber.MethodGet, "/translations/"+id.String(), nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp, err := app.Test(httptest.NewRequest(fiber.MethodHead, "/translations/"+id.String(), nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	body, _ := io.ReadAll(resp.Body)
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+	assert.Empty(t, body)
+	assert.NotEmpty(t, resp.Header.Get(fiber.HeaderETag))
+	assert.Equal(t, get.Header.Get(fiber.HeaderETag), resp.Header.Get(fiber.HeaderETag))
+	assert.Equal(t, get.Header.Get(fiber.HeaderContentType), resp.Header.Get(fiber.HeaderContentType))
+
+	missing := &mocks.MockDatabase{
+		QueryRowFunc: func(ctx context.Context, query string, args ...interface{}) database.Row {
+			return &mocks.MockRow{ScanFunc: func(dest ...interface{}) error { return sql.ErrNoRows }}
+		},
+	}
+	app = fiber.New()
+	RegisterTranslatableRoutes(app, missing, &config, nil, nil)
+	resp, err = app.Test(httptest.NewRequest(fiber.MethodHead, "/translations/"+uuid.NewString(), nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	body, _ = io.ReadAll(resp.Body)
+	assert.Equal(t, fiber.StatusNotFound, resp.StatusCode)
+	assert.Empty(t, body)
+}
+
+func TestTranslatableResource_Options(t *testing.T) {
+	tests := []struct {
+		name  string
+		path  string
+		allow string
+	}{
+		{name: "collection", path: "/translations", allow: "GET, HEAD, POST, PUT, OPTIONS"},
+		{name: "item", path: "/translations/" + uuid.NewString(), allow: "GET, HEAD, PUT, DELETE, PATCH, OPTIONS"},
+		{name: "count", path: "/translations/count", allow: "GET, HEAD, PUT, DELETE, PATCH, OPTIONS"},
+	}
+
+	config := DefaultConfig()
+	app := fiber.New()
+	RegisterTranslatableRoutes(app, &mocks.MockDatabase{}, &config, nil, nil)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := app.Test(httptest.NewRequest(fiber.MethodOptions, tt.path, nil))
+			if err != nil {
+				t.Fatal(err)
+			}
+			body, _ := io.ReadAll(resp.Body)
+			assert.Equal(t, fiber.StatusNoContent, resp.StatusCode)
+			assert.Empty(t, body)
+			assert.Equal(t, tt.allow, resp.Header.Get(fiber.HeaderAllow))
+		})
+	}
+}
+
+func TestTranslatableResource_Validate(t *testing.T) {
+	translatableID := uuid.NewString()
+	tests := []struct {
+		name    string
+		body    string
+		results []ValidationResultDTO
+	}{
+		{
+			name:    "script escaped",
+			body:    `{"translatableId":"` + translatableID + `","translatable":"post","locale":"fr","content":"Bonjour <script>alert(1)</script>"}`,
+			results: []ValidationResultDTO{{Valid: true, Locale: "fr", Content: "Bonjour &lt;script&gt;alert(1)&lt;/script&gt;"}},
+		},
+		{
+			name:    "unsupported locale",
+			body:    `{"translatableId":"` + translatableID + `","translatable":"post","locale":"it","content":"Ciao"}`,
+			results: []ValidationResultDTO{{Locale: "it", Errors: map[string]string{"locale": "locale is not supported"}}},
+		},
+		{
+			name: "batch",
+			body: `[{"translatableId":"` + translatableID + `","translatable":"post","locale":"fr","content":"Bonjour"},` +
+				`{"translatableId":"nope","translatable":"post","locale":"en","content":" "}]`,
+			results: []ValidationResultDTO{
+				{Valid: true, Locale: "fr", Content: "Bonjour"},
+				{Locale: "en", Errors: map[string]string{"translatableId": "translatable_id must be a valid UUID", "content": "content cannot be empty"}},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := &mocks.MockDatabase{
+				QueryFunc: func(ctx context.Context, query string, args ...interface{}) (database.Rows, error) {
+					t.Fatalf("unexpected query: %s", query)
+					return nil, nil
+				},
+				QueryRowFunc: func(ctx context.Context, query string, args ...interface{}) database.Row {
+					t.Fatalf("unexpected query: %s", query)
+					return nil
+				},
+				ExecFunc: func(ctx context.Context, query string, args ...interface{}) (database.Result, error) {
+					t.Fatalf("unexpected statement: %s", query)
+					return nil, nil
+				},
+			}
+			config := DefaultConfig()
+			app, resource := setupTestApp(db, &config)
+			app.Post("/translations/validate", resource.Validate)
+
+			req := httptest.NewRequest(fiber.MethodPost, "/translations/validate", strings.NewReader(tt.body))
+			req.Header.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+			resp, err := app.Test(req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+			if len(tt.results) == 1 {
+				var result ValidationResultDTO
+				assert.NoError(t, json.NewDecoder(resp.Body).Decode(&result))
+				assert.Equal(t, tt.results[0], result)
+				return
+			}
+			var results []ValidationResultDTO
+			assert.NoError(t, json.NewDecoder(resp.Body).Decode(&results))
+			assert.Equal(t, tt.results, results)
+		})
+	}
+}
+
+func TestTranslatableResource_GetByID_ContentLengthAndChecksum(t *testing.T) {
+	tests := []struct {
+		name       string
+		countRunes *bool
+		length     int
+	}{
+		{name: "characters", length: 10},
+		{name: "bytes", countRunes: new(false), length: len("Grüße <3 😀")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			id := uuid.New()
+			db := &mocks.MockDatabase{
+				QueryRowFunc: func(ctx context.Context, query string, args ...interface{}) database.Row {
+					return &mocks.MockRow{ScanFunc: func(dest ...interface{}) error {
+						*dest[0].(*uuid.UUID) = id
+						*dest[3].(*string) = "post"
+						*dest[4].(*string) = "de"
+						*dest[5].(*string) = "Grüße &lt;3 😀"
+						return nil
+					}}
+				},
+			}
+			config := DefaultConfig()
+			config.CountRunes = tt.countRunes
+			app, resource := setupTestApp(db, &config)
+			app.Get("/translations/:id", resource.GetByID)
+
+			first, _ := getTranslation(t, app, "/translations/"+id.String())
+			second, _ := getTranslation(t, app, "/translations/"+id.String())
+
+			assert.Equal(t, tt.length, first.ContentLength)
+			assert.Equal(t, ContentChecksum("Grüße &lt;3 😀"), first.Checksum)
+			assert.Equal(t, first.Checksum, second.Checksum)
+		})
+	}
+}
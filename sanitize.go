@@ -0,0 +1,122 @@
+package translatable
+
+import (
+	"html"
+	"net/url"
+	"slices"
+	"strings"
+
+	xhtml "golang.org/x/net/html"
+)
+
+// Sanitizer makes submitted text content safe to render as HTML.
+type Sanitizer interface {
+	Sanitize(content string) string
+}
+
+// SanitizerFunc adapts a function to Sanitizer.
+type SanitizerFunc func(content string) string
+
+func (f SanitizerFunc) Sanitize(content string) string {
+	return f(content)
+}
+
+var (
+	// escapeSanitizer renders all markup as text.
+	escapeSanitizer = SanitizerFunc(html.EscapeString)
+	// stripSanitizer removes all markup and keeps the text.
+	stripSanitizer = NewAllowlistSanitizer(nil)
+	// allowlistSanitizer keeps basic formatting and links.
+	allowlistSanitizer = NewAllowlistSanitizer(DefaultAllowedElements)
+)
+
+// DefaultAllowedElements is the allowlist of SanitizerModeAllowlist: elements
+// mapped to the attributes they may keep.
+var DefaultAllowedElements = map[string][]string{
+	"a": {"href", "title"}, "b": nil, "strong": nil, "i": nil, "em": nil, "u": nil, "s": nil,
+	"p": nil, "br": nil, "ul": nil, "ol": nil, "li": nil, "blockquote": nil, "code": nil, "pre": nil,
+	"h1": nil, "h2": nil, "h3": nil, "h4": nil, "h5": nil, "h6": nil, "span": nil,
+}
+
+// droppedElements are removed together with their content, whatever the
+// allowlist says.
+var droppedElements = map[string]bool{
+	"script": true, "style": true, "iframe": true, "object": true, "embed": true, "template": true, "noscript": true,
+}
+
+// urlAttributes only keep relative URLs and those of safeURLSchemes.
+var (
+	urlAttributes  = map[string]bool{"href": true, "src": true, "cite": true}
+	safeURLSchemes = map[string]bool{"http": true, "https": true, "mailto": true}
+)
+
+// NewAllowlistSanitizer returns a Sanitizer that keeps the given elements with
+// their listed attributes and removes every other tag, keeping its text.
+// Scripts and other active content are always removed with what they contain.
+func NewAllowlistSanitizer(elements map[string][]string) Sanitizer {
+	return SanitizerFunc(func(content string) string {
+		return sanitizeHTML(content, elements)
+	})
+}
+
+func sanitizeHTML(content string, elements map[string][]string) string {
+	z := xhtml.NewTokenizer(strings.NewReader(content))
+	var b strings.Builder
+	dropped := 0
+	for {
+		tokenType := z.Next()
+		switch tokenType {
+		case xhtml.ErrorToken:
+			return b.String()
+		case xhtml.TextToken:
+			if dropped == 0 {
+				b.WriteString(html.EscapeString(string(z.Text())))
+			}
+		case xhtml.StartTagToken, xhtml.SelfClosingTagToken:
+			token := z.Token()
+			if droppedElements[token.Data] {
+				if tokenType == xhtml.StartTagToken {
+					dropped++
+				}
+				continue
+			}
+			attributes, ok := elements[token.Data]
+			if dropped > 0 || !ok {
+				continue
+			}
+			b.WriteString("<" + token.Data)
+			for _, attr := range token.Attr {
+				if attr.Namespace != "" || !slices.Contains(attributes, attr.Key) {
+					continue
+				}
+				if urlAttributes[attr.Key] && !isSafeURL(attr.Val) {
+					continue
+				}
+				b.WriteString(" " + attr.Key + `="` + html.EscapeString(attr.Val) + `"`)
+			}
+			if tokenType == xhtml.SelfClosingTagToken {
+				b.WriteString(" /")
+			}
+			b.WriteString(">")
+		case xhtml.EndTagToken:
+			token := z.Token()
+			if droppedElements[token.Data] {
+				if dropped > 0 {
+					dropped--
+				}
+				continue
+			}
+			if _, ok := elements[token.Data]; ok && dropped == 0 {
+				b.WriteString("</" + token.Data + ">")
+			}
+		}
+	}
+}
+
+func isSafeURL(raw string) bool {
+	u, err := url.Parse(strings.TrimSpace(raw))
+	if err != nil {
+		return false
+	}
+	return u.Scheme == "" || safeURLSchemes[strings.ToLower(u.Scheme)]
+}
@@ -0,0 +1,86 @@
+package translatable
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSanitizers(t *testing.T) {
+	tests := []struct {
+		name      string
+		input     string
+		escape    string
+		strip     string
+		allowlist string
+	}{
+		{
+			name:      "script is removed",
+			input:     `Hi<script>alert("x")</script>!`,
+			escape:    `Hi&lt;script&gt;alert(&#34;x&#34;)&lt;/script&gt;!`,
+			strip:     `Hi!`,
+			allowlist: `Hi!`,
+		},
+		{
+			name:      "formatting and links survive the allowlist",
+			input:     `<p>Read <b>this</b> <a href="https://example.com/a?b=1&amp;c=2" onclick="steal()">link</a></p>`,
+			escape:    `&lt;p&gt;Read &lt;b&gt;this&lt;/b&gt; &lt;a href=&#34;https://example.com/a?b=1&amp;amp;c=2&#34; onclick=&#34;steal()&#34;&gt;link&lt;/a&gt;&lt;/p&gt;`,
+			strip:     `Read this link`,
+			allowlist: `<p>Read <b>this</b> <a href="https://example.com/a?b=1&amp;c=2">link</a></p>`,
+		},
+		{
+			name:      "unsafe link scheme is dropped",
+			input:     `<a href="javascript:alert(1)" title="t">x</a><img src=x onerror=alert(1)>`,
+			escape:    `&lt;a href=&#34;javascript:alert(1)&#34; title=&#34;t&#34;&gt;x&lt;/a&gt;&lt;img src=x onerror=alert(1)&gt;`,
+			strip:     `x`,
+			allowlist: `<a title="t">x</a>`,
+		},
+		{
+			name:      "text stays escaped",
+			input:     `1 < 2 & <style>p{}</style><i>3 > 2</i>`,
+			escape:    `1 &lt; 2 &amp; &lt;style&gt;p{}&lt;/style&gt;&lt;i&gt;3 &gt; 2&lt;/i&gt;`,
+			strip:     `1 &lt; 2 &amp; 3 &gt; 2`,
+			allowlist: `1 &lt; 2 &amp; <i>3 &gt; 2</i>`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.escape, escapeSanitizer.Sanitize(tt.input))
+			assert.Equal(t, tt.strip, stripSanitizer.Sanitize(tt.input))
+			assert.Equal(t, tt.allowlist, allowlistSanitizer.Sanitize(tt.input))
+		})
+	}
+}
+
+func TestTranslatableHooks_PrepareContent_SanitizeMode(t *testing.T) {
+	config := DefaultConfig()
+	config.AllowedTypes = []string{"post", "article"}
+	config.TypeSanitizeModes = map[string]string{"article": SanitizerModeAllowlist}
+	assert.NoError(t, config.Validate())
+	h := NewTranslatableHooks(nil, &config)
+
+	post, err := h.prepareContent("post", "<b>Hi</b><script>x()</script>")
+	assert.NoError(t, err)
+	assert.Equal(t, "&lt;b&gt;Hi&lt;/b&gt;&lt;script&gt;x()&lt;/script&gt;", post)
+
+	article, err := h.prepareContent("article", "<b>Hi</b><script>x()</script>")
+	assert.NoError(t, err)
+	assert.Equal(t, "<b>Hi</b>", article)
+
+	config.Sanitizer = SanitizerFunc(func(content string) string { return "custom" })
+	post, _ = h.prepareContent("post", "<b>Hi</b>")
+	article, _ = h.prepareContent("article", "<b>Hi</b>")
+	assert.Equal(t, "custom", post)
+	assert.Equal(t, "<b>Hi</b>", article)
+}
+
+func TestConfig_Validate_SanitizeMode(t *testing.T) {
+	config := DefaultConfig()
+	config.SanitizeMode = "bluemonday"
+	assert.EqualError(t, config.Validate(), "sanitize_mode must be one of escape, strip, allowlist")
+
+	config = DefaultConfig()
+	config.TypeSanitizeModes = map[string]string{"page": SanitizerModeStrip}
+	assert.EqualError(t, config.Validate(), "type_sanitize_modes references unknown type: page")
+}
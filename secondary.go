@@ -0,0 +1,40 @@
+package translatable
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+// SecondaryWriter mirrors translations to an external store such as a search
+// index or cache. It is called after the primary write has succeeded; errors
+// are logged and never fail the request, so the mirror is eventually consistent.
+type SecondaryWriter interface {
+	Upsert(ctx context.Context, t *Translatable) error
+	Delete(ctx context.Context, id uuid.UUID) error
+}
+
+// NoopSecondaryWriter is a SecondaryWriter that discards every write. Embed it
+// to implement only the operations a store cares about.
+type NoopSecondaryWriter struct{}
+
+func (NoopSecondaryWriter) Upsert(ctx context.Context, t *Translatable) error { return nil }
+func (NoopSecondaryWriter) Delete(ctx context.Context, id uuid.UUID) error    { return nil }
+
+func mirrorUpsert(ctx context.Context, w SecondaryWriter, t *Translatable) {
+	if w == nil {
+		return
+	}
+	if err := w.Upsert(ctx, t); err != nil {
+		requestLogger(ctx).Warn("secondary store upsert failed", "id", t.ID, "error", err)
+	}
+}
+
+func mirrorDelete(ctx context.Context, w SecondaryWriter, id uuid.UUID) {
+	if w == nil {
+		return
+	}
+	if err := w.Delete(ctx, id); err != nil {
+		requestLogger(ctx).Warn("secondary store delete failed", "id", id, "error", err)
+	}
+}
@@ -0,0 +1,54 @@
+package translatable
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/nicolasbonnici/gorest/hooks"
+	"github.com/stretchr/testify/assert"
+)
+
+type recordingWriter struct {
+	upserted []uuid.UUID
+	deleted  []uuid.UUID
+	err      error
+}
+
+func (w *recordingWriter) Upsert(ctx context.Context, t *Translatable) error {
+	w.upserted = append(w.upserted, t.ID)
+	return w.err
+}
+
+func (w *recordingWriter) Delete(ctx context.Context, id uuid.UUID) error {
+	w.deleted = append(w.deleted, id)
+	return w.err
+}
+
+func TestSecondaryWriter_MirrorsWrites(t *testing.T) {
+	writer := &recordingWriter{}
+	h := newTranslatableCRUDHooks(&Config{SecondaryWriter: writer})
+	model := &Translatable{ID: uuid.New()}
+
+	assert.NoError(t, h.SerializeOne(context.Background(), hooks.OperationCreate, model))
+	assert.NoError(t, h.SerializeOne(context.Background(), hooks.OperationUpdate, model))
+	assert.NoError(t, h.SerializeOne(context.Background(), hooks.OperationGetByID, model))
+	assert.Equal(t, []uuid.UUID{model.ID, model.ID}, writer.upserted)
+
+	ctx := withPendingDelete(context.Background(), model.ID)
+	assert.NoError(t, h.AfterQuery(ctx, hooks.OperationDelete, "", nil, nil, errors.New("db down")))
+	assert.Empty(t, writer.deleted)
+	assert.NoError(t, h.AfterQuery(ctx, hooks.OperationDelete, "", nil, nil, nil))
+	assert.Equal(t, []uuid.UUID{model.ID}, writer.deleted)
+}
+
+func TestSecondaryWriter_FailuresAreNotFatal(t *testing.T) {
+	writer := &recordingWriter{err: errors.New("mirror unavailable")}
+	h := newTranslatableCRUDHooks(&Config{SecondaryWriter: writer})
+
+	err := h.SerializeOne(context.Background(), hooks.OperationCreate, &Translatable{ID: uuid.New()})
+
+	assert.NoError(t, err)
+	assert.Len(t, writer.upserted, 1)
+}
@@ -1,30 +1,66 @@
 package translatable
 
 import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"math"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
 	"github.com/nicolasbonnici/gorest/database"
+	"github.com/nicolasbonnici/gorest/filter"
+	"github.com/nicolasbonnici/gorest/hooks"
+	"github.com/nicolasbonnici/gorest/query"
+)
+
+var (
+	ErrTranslationNotFound = errors.New("translation not found")
+	ErrInvalidFilter       = errors.New("invalid filter")
+	ErrSameEntity          = errors.New("source and target entity must differ")
+	ErrSameLocale          = errors.New("source and target locale must differ")
+	ErrTranslationExists   = errors.New("translation already exists")
+	ErrForbidden           = errors.New("translation belongs to another user")
+	ErrUnauthenticated     = errors.New("authentication required")
 )
 
 type TranslatableService struct {
-	db     database.Database
-	config *Config
+	db        database.Database
+	config    *Config
+	hooks     *TranslatableHooks
+	crudHooks *translatableCRUDHooks
 }
 
 func NewTranslatableService(db database.Database, config *Config) *TranslatableService {
+	db = withQueryTimeout(config.metrics.instrument(db), config)
 	return &TranslatableService{
-		db:     db,
-		config: config,
+		db:        db,
+		config:    config,
+		hooks:     NewTranslatableHooks(db, config),
+		crudHooks: newTranslatableCRUDHooks(config),
 	}
 }
 
 func (s *TranslatableService) GetLocales() LocalesResponse {
-	locales := make([]LocaleInfo, 0, len(s.config.SupportedLocales))
-	for _, locale := range s.config.SupportedLocales {
-		locales = append(locales, LocaleInfo{
-			Locale:    locale,
-			IsDefault: locale == s.config.DefaultLocale,
-		})
+	return s.GetLocalesPage(len(s.config.SupportedLocales), 0)
+}
+
+// GetLocalesPage returns at most limit configured locales starting at offset,
+// along with the total number of supported locales.
+func (s *TranslatableService) GetLocalesPage(limit, offset int) LocalesResponse {
+	all := s.config.SupportedLocales
+	start := min(max(offset, 0), len(all))
+	end := min(start+max(limit, 0), len(all))
+
+	locales := make([]LocaleInfo, 0, end-start)
+	for _, locale := range all[start:end] {
+		locales = append(locales, s.config.localeInfo(locale))
 	}
-	return LocalesResponse{Default: s.config.DefaultLocale, Locales: locales}
+	return LocalesResponse{Default: s.config.DefaultLocale, Locales: locales, Total: len(all)}
 }
 
 func (s *TranslatableService) DefaultLocale() string {
@@ -40,3 +76,1566 @@ func (s *TranslatableService) TargetLocales() []string {
 	}
 	return targets
 }
+
+// Completeness reports which supported locales the entity has a live
+// translation in, reading its locales with a single grouped query.
+func (s *TranslatableService) Completeness(ctx context.Context, translatable string, translatableID uuid.UUID) (*CompletenessReport, error) {
+	stored, err := s.LiveLocales(ctx, translatable, translatableID)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &CompletenessReport{Translated: []LocaleInfo{}, Missing: []LocaleInfo{}, Total: len(s.config.SupportedLocales)}
+	for _, locale := range s.config.SupportedLocales {
+		info := s.config.localeInfo(locale)
+		if stored[locale] {
+			report.Translated = append(report.Translated, info)
+		} else {
+			report.Missing = append(report.Missing, info)
+		}
+	}
+	if report.Total > 0 {
+		report.Percentage = math.Round(float64(len(report.Translated))*1000/float64(report.Total)) / 10
+	}
+	return report, nil
+}
+
+// LiveLocales returns the locales the entity has a live translation in, of
+// any type when translatable is empty.
+func (s *TranslatableService) LiveLocales(ctx context.Context, translatable string, translatableID uuid.UUID) (map[string]bool, error) {
+	d := s.db.Dialect()
+	sql := "SELECT locale FROM " + s.config.table() + " WHERE "
+	var args []interface{}
+	if translatable != "" {
+		args = append(args, translatable)
+		sql += "translatable = " + d.Placeholder(len(args)) + " AND "
+	}
+	args = append(args, translatableID, time.Now())
+	sql += "translatable_id = " + d.Placeholder(len(args)-1) +
+		" AND deleted_at IS NULL" +
+		" AND (expires_at IS NULL OR expires_at > " + d.Placeholder(len(args)) + ")"
+	tenant, args := s.config.tenantCondition(ctx, d, "tenant_id", args)
+	rows, err := s.db.Query(ctx, sql+tenant+" GROUP BY locale", args...)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = rows.Close() }()
+
+	locales := make(map[string]bool)
+	for rows.Next() {
+		var locale string
+		if err := rows.Scan(&locale); err != nil {
+			return nil, err
+		}
+		locales[locale] = true
+	}
+	return locales, rows.Err()
+}
+
+func (s *TranslatableService) GetByID(ctx context.Context, id uuid.UUID) (*Translatable, error) {
+	var t Translatable
+	d := s.db.Dialect()
+	tenant, args := s.config.tenantCondition(ctx, d, "tenant_id", []any{id, time.Now()})
+	query := "SELECT " + translatableColumns + " FROM " + s.config.table() + " WHERE id = " + d.Placeholder(1) +
+		" AND deleted_at IS NULL AND (expires_at IS NULL OR expires_at > " + d.Placeholder(2) + ")" + tenant
+	err := s.db.QueryRow(ctx, query, args...).Scan(t.scanFields()...)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrTranslationNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
+
+// GetByIDs fetches several translations in a single query and returns them in
+// the order of ids. Ids without a visible translation are silently skipped.
+func (s *TranslatableService) GetByIDs(ctx context.Context, ids []uuid.UUID) ([]Translatable, error) {
+	values := make([]any, len(ids))
+	for i, id := range ids {
+		values[i] = id
+	}
+
+	builder := query.New(s.db.Dialect()).
+		Select(strings.Split(translatableColumns, ", ")...).
+		From(s.config.table()).
+		Where(query.In("id", values...))
+	builder, _ = s.crudHooks.ModifySelectQuery(ctx, hooks.OperationGetAll, builder)
+
+	sql, args, err := builder.Build()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := s.db.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = rows.Close() }()
+
+	found := make(map[uuid.UUID]Translatable, len(ids))
+	for rows.Next() {
+		var t Translatable
+		if err := rows.Scan(t.scanFields()...); err != nil {
+			return nil, err
+		}
+		found[t.ID] = t
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	translations := make([]Translatable, 0, len(found))
+	for _, id := range ids {
+		if t, ok := found[id]; ok {
+			translations = append(translations, t)
+		}
+	}
+	if err := s.crudHooks.SerializeMany(ctx, hooks.OperationGetAll, &translations); err != nil {
+		return nil, err
+	}
+	return translations, nil
+}
+
+// Publish snapshots the current content of a translation as its live version.
+// Subsequent edits only change the working content until the next publish.
+func (s *TranslatableService) Publish(ctx context.Context, id uuid.UUID) (*Translatable, error) {
+	d := s.db.Dialect()
+	sql := "UPDATE " + s.config.table() + " SET status = " + d.Placeholder(1) +
+		", published_content = content, published_at = " + d.Placeholder(2) +
+		", reviewed_by = NULL, reviewed_at = NULL WHERE id = " + d.Placeholder(3) + " AND deleted_at IS NULL"
+	return s.transition(ctx, id, s.config.statusesBefore(StatusPublished), sql, []any{StatusPublished, time.Now(), id})
+}
+
+// Approve publishes a translation under review, recording reviewer as the one
+// who signed it off.
+func (s *TranslatableService) Approve(ctx context.Context, id uuid.UUID, reviewer *uuid.UUID) (*Translatable, error) {
+	d := s.db.Dialect()
+	sql := "UPDATE " + s.config.table() + " SET status = " + d.Placeholder(1) +
+		", published_content = content, published_at = " + d.Placeholder(2) +
+		", reviewed_by = " + d.Placeholder(3) + ", reviewed_at = " + d.Placeholder(2) +
+		" WHERE id = " + d.Placeholder(4) + " AND deleted_at IS NULL"
+	return s.transition(ctx, id, []string{StatusReview}, sql, []any{StatusPublished, time.Now(), reviewer, id})
+}
+
+// Unpublish takes the live version of a published translation down, moving it
+// back to draft.
+func (s *TranslatableService) Unpublish(ctx context.Context, id uuid.UUID) (*Translatable, error) {
+	d := s.db.Dialect()
+	sql := "UPDATE " + s.config.table() + " SET status = " + d.Placeholder(1) +
+		", published_content = NULL, published_at = NULL, reviewed_by = NULL, reviewed_at = NULL" +
+		" WHERE id = " + d.Placeholder(2) + " AND deleted_at IS NULL"
+	return s.transition(ctx, id, s.config.statusesBefore(StatusDraft), sql, []any{StatusDraft, id})
+}
+
+// SubmitForReview moves a draft translation to review.
+func (s *TranslatableService) SubmitForReview(ctx context.Context, id uuid.UUID) (*Translatable, error) {
+	d := s.db.Dialect()
+	sql := "UPDATE " + s.config.table() + " SET status = " + d.Placeholder(1) +
+		" WHERE id = " + d.Placeholder(2) + " AND deleted_at IS NULL"
+	return s.transition(ctx, id, s.config.statusesBefore(StatusReview), sql, []any{StatusReview, id})
+}
+
+// transition runs sql, the update moving translation id to another status,
+// only if the translation is in one of the statuses from, so concurrent
+// transitions cannot skip a step. It returns ErrInvalidTransition when the
+// translation exists in another status.
+func (s *TranslatableService) transition(ctx context.Context, id uuid.UUID, from []string, sql string, args []any) (*Translatable, error) {
+	d := s.db.Dialect()
+	placeholders := make([]string, len(from))
+	for i, status := range from {
+		args = append(args, status)
+		placeholders[i] = d.Placeholder(len(args))
+	}
+	sql += " AND status IN (" + strings.Join(placeholders, ", ") + ")"
+	tenant, args := s.config.tenantCondition(ctx, d, "tenant_id", args)
+	result, err := s.db.Exec(ctx, sql+tenant, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	if affected, err := result.RowsAffected(); err == nil && affected == 0 {
+		if _, err := s.GetByID(ctx, id); err != nil {
+			return nil, err
+		}
+		return nil, ErrInvalidTransition
+	}
+
+	moved, err := s.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	s.config.invalidateReads(ctx, moved)
+	mirrorUpsert(ctx, s.config.SecondaryWriter, moved)
+	return moved, nil
+}
+
+// Resolve returns the translation of an entity in the first available locale of
+// chain, falling back to the default locale as dictated by the configured
+// FallbackStrategy. It returns ErrTranslationNotFound when no candidate exists.
+//
+// An empty translatable matches the entity regardless of its type.
+func (s *TranslatableService) Resolve(ctx context.Context, translatable string, translatableID uuid.UUID, chain []string) (*Translatable, error) {
+	return s.getByEntityAndLocale(ctx, translatable, translatableID, s.config.fallbackLocales(chain))
+}
+
+// GetByNaturalKey returns the readable translation of an entity in locale,
+// the row the unique index on the three keys points to, without falling back
+// to other locales. It returns ErrTranslationNotFound when there is none.
+// Found translations go through the read cache.
+func (s *TranslatableService) GetByNaturalKey(ctx context.Context, translatableID uuid.UUID, translatable, locale string) (*Translatable, error) {
+	key := s.config.naturalCacheKey(ctx, translatableID, translatable, locale)
+	if t, _, ok := s.config.cachedRead(ctx, key); ok {
+		return t, nil
+	}
+
+	t, err := s.getByEntityAndLocale(ctx, translatable, translatableID, []string{locale})
+	if err != nil {
+		return nil, err
+	}
+	s.config.cacheRead(ctx, key, t, "")
+	return t, nil
+}
+
+// EntityTranslations returns the readable translations of an entity in every
+// locale with a single query, in SupportedLocales order. Locales no longer
+// supported come last.
+func (s *TranslatableService) EntityTranslations(ctx context.Context, translatable string, translatableID uuid.UUID) ([]Translatable, error) {
+	builder := query.New(s.db.Dialect()).
+		Select(strings.Split(translatableColumns, ", ")...).
+		From(s.config.table()).
+		Where(query.Eq("translatable_id", translatableID)).
+		Where(query.Eq("translatable", translatable))
+	builder, _ = s.crudHooks.ModifySelectQuery(ctx, hooks.OperationGetAll, builder)
+
+	sql, args, err := builder.Build()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := s.db.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = rows.Close() }()
+
+	var translations []Translatable
+	for rows.Next() {
+		var t Translatable
+		if err := rows.Scan(t.scanFields()...); err != nil {
+			return nil, err
+		}
+		if err := s.crudHooks.SerializeOne(ctx, hooks.OperationGetAll, &t); err != nil {
+			return nil, err
+		}
+		translations = append(translations, t)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	rank := func(locale string) int {
+		if i := slices.Index(s.config.SupportedLocales, locale); i >= 0 {
+			return i
+		}
+		return len(s.config.SupportedLocales)
+	}
+	slices.SortStableFunc(translations, func(a, b Translatable) int {
+		if order := rank(a.Locale) - rank(b.Locale); order != 0 {
+			return order
+		}
+		return strings.Compare(a.Locale, b.Locale)
+	})
+	return translations, nil
+}
+
+// TranslateMissing has translator produce the entity's translations, then
+// flags and returns the one in locale. It returns ErrTranslationNotFound when
+// the translator did not produce that locale.
+func (s *TranslatableService) TranslateMissing(ctx context.Context, translator Translator, translatable string, translatableID uuid.UUID, locale string, userID *uuid.UUID) (*Translatable, error) {
+	result, err := translator.Translate(ctx, translatable, translatableID.String(), userID)
+	if err != nil {
+		return nil, err
+	}
+	if !slices.Contains(result.Translated, locale) {
+		return nil, ErrTranslationNotFound
+	}
+
+	d := s.db.Dialect()
+	tenant, args := s.config.tenantCondition(ctx, d, "tenant_id", []any{true, translatable, translatableID, locale})
+	sql := "UPDATE " + s.config.table() + " SET auto_translated = " + d.Placeholder(1) +
+		" WHERE translatable = " + d.Placeholder(2) +
+		" AND translatable_id = " + d.Placeholder(3) +
+		" AND locale = " + d.Placeholder(4) + tenant
+	if _, err := s.db.Exec(ctx, sql, args...); err != nil {
+		return nil, err
+	}
+
+	translated, err := s.getByEntityAndLocale(ctx, translatable, translatableID, []string{locale})
+	if err != nil {
+		return nil, err
+	}
+	mirrorUpsert(ctx, s.config.SecondaryWriter, translated)
+	emitCreated(ctx, s.config, translated)
+	return translated, nil
+}
+
+// getByEntityAndLocale loads the entity's translations in candidates with a
+// single query and returns the one whose locale comes first in candidates.
+func (s *TranslatableService) getByEntityAndLocale(ctx context.Context, translatable string, translatableID uuid.UUID, candidates []string) (*Translatable, error) {
+	if len(candidates) == 0 {
+		return nil, ErrTranslationNotFound
+	}
+
+	locales := make([]any, len(candidates))
+	for i, locale := range candidates {
+		locales[i] = locale
+	}
+
+	builder := query.New(s.db.Dialect()).
+		Select(strings.Split(translatableColumns, ", ")...).
+		From(s.config.table()).
+		Where(query.Eq("translatable_id", translatableID)).
+		Where(query.In("locale", locales...))
+	if translatable != "" {
+		builder = builder.Where(query.Eq("translatable", translatable))
+	}
+	builder, _ = s.crudHooks.ModifySelectQuery(ctx, hooks.OperationGetByID, builder)
+
+	sql, args, err := builder.Build()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := s.db.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = rows.Close() }()
+
+	found := make(map[string]Translatable, len(candidates))
+	for rows.Next() {
+		var t Translatable
+		if err := rows.Scan(t.scanFields()...); err != nil {
+			return nil, err
+		}
+		found[t.Locale] = t
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	for _, locale := range candidates {
+		if t, ok := found[locale]; ok {
+			if err := s.crudHooks.SerializeOne(ctx, hooks.OperationGetByID, &t); err != nil {
+				return nil, err
+			}
+			return &t, nil
+		}
+	}
+	return nil, ErrTranslationNotFound
+}
+
+// CreateMachineTranslation stores t, a machine translation that has been
+// through the create validations, as a new translation, BeforeCreate hook
+// included. A soft-deleted or expired translation still holding its entity,
+// type and locale is overwritten as by Recreate. It returns
+// ErrTranslationExists when a live one does, which the unique key also
+// enforces against concurrent writes.
+func (s *TranslatableService) CreateMachineTranslation(ctx context.Context, t *Translatable) (*Translatable, error) {
+	t.AutoTranslated = true
+	err := s.insertTranslatable(ctx, s.db, t)
+	if isUniqueViolation(err) {
+		return s.Recreate(ctx, t, t.UserID)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	mirrorUpsert(ctx, s.config.SecondaryWriter, t)
+	emitCreated(ctx, s.config, t)
+	return t, nil
+}
+
+// machineTranslation builds the locale translation of source's entity from
+// content returned by a translation provider, validated like submitted content.
+func (s *TranslatableService) machineTranslation(ctx context.Context, source *Translatable, locale, content string, userID *uuid.UUID) (*Translatable, error) {
+	prepared, err := s.hooks.prepareContent(source.Translatable, content)
+	if err != nil {
+		return nil, err
+	}
+	sourceChecksum, err := s.hooks.sourceChecksum(ctx, source)
+	if err != nil {
+		return nil, err
+	}
+
+	t := Translatable{
+		ID:             uuid.New(),
+		UserID:         userID,
+		TranslatableID: source.TranslatableID,
+		Translatable:   source.Translatable,
+		Locale:         locale,
+		Content:        prepared,
+		ContentRaw:     s.hooks.rawContent(source.Translatable, content),
+		AutoTranslated: true,
+		SourceChecksum: sourceChecksum,
+		ReceivedAt:     s.hooks.trackReceivedAt(ctx),
+		CreatedAt:      time.Now(),
+	}
+	if ttl, ok := s.config.TypeTTLs[t.Translatable]; ok {
+		expiresAt := t.CreatedAt.Add(ttl)
+		t.ExpiresAt = &expiresAt
+	}
+	return &t, nil
+}
+
+// Upsert creates t, or updates the content of the translation already stored
+// under its natural key (translatable_id, translatable, locale). It reports
+// whether a new row was created. Existing translations owned by another user
+// than t.UserID are left untouched and the error of Config.OwnershipPolicy is
+// returned. A soft-deleted translation under the same key is restored and
+// reported as created. The stored translation is read, locked, checked and
+// written in one transaction, so a concurrent write cannot slip between the
+// ownership check and the write, and the translation returned is the row as
+// stored. t has been through the create validations, but not through a
+// lifecycle hook: Upsert runs BeforeCreate or BeforeUpdate once it knows which
+// write it makes.
+func (s *TranslatableService) Upsert(ctx context.Context, t *Translatable) (*Translatable, bool, error) {
+	var existing, upserted *Translatable
+	err := s.WithTx(ctx, func(tx database.Tx) error {
+		var err error
+		existing, err = s.getByNaturalKey(ctx, tx, t.Translatable, t.TranslatableID, t.Locale)
+		if err != nil && !errors.Is(err, ErrTranslationNotFound) {
+			return err
+		}
+		if existing != nil {
+			if err := s.config.checkOwnership(ctx, t.UserID, existing.UserID); err != nil {
+				return err
+			}
+		}
+
+		t.Version = 1
+		t.TenantID = s.config.tenantID(ctx)
+		t.Status = s.config.DefaultStatus
+		before := s.config.Hooks.BeforeUpdate
+		if existing == nil || existing.DeletedAt != nil {
+			before = s.config.Hooks.BeforeCreate
+		}
+		if err := runBeforeHook(ctx, before, t); err != nil {
+			return err
+		}
+		args := t.columnValues()
+		placeholders := make([]string, len(args))
+		for i := range args {
+			placeholders[i] = s.db.Dialect().Placeholder(i + 1)
+		}
+		now := time.Now()
+		args = append(args, now)
+		updatedAt := s.db.Dialect().Placeholder(len(args))
+
+		sql := "INSERT INTO " + s.config.table() + " (" + translatableColumns + ") VALUES (" + strings.Join(placeholders, ", ") + ")"
+		if s.db.DriverName() == "mysql" {
+			sql += " ON DUPLICATE KEY UPDATE content = VALUES(content), content_raw = VALUES(content_raw)," +
+				" source_checksum = VALUES(source_checksum), auto_translated = VALUES(auto_translated)," +
+				" expires_at = VALUES(expires_at), deleted_at = NULL, version = version + 1, updated_at = " + updatedAt
+		} else {
+			sql += " ON CONFLICT (translatable_id, translatable, locale, tenant_id) DO UPDATE SET content = excluded.content," +
+				" content_raw = excluded.content_raw, source_checksum = excluded.source_checksum," +
+				" auto_translated = excluded.auto_translated, expires_at = excluded.expires_at, deleted_at = NULL," +
+				" version = " + s.config.table() + ".version + 1, updated_at = " + updatedAt
+		}
+		if _, err := tx.Exec(ctx, sql, args...); err != nil {
+			return err
+		}
+
+		upserted, err = s.getByNaturalKey(ctx, tx, t.Translatable, t.TranslatableID, t.Locale)
+		return err
+	})
+	if err != nil {
+		return nil, false, err
+	}
+
+	created := existing == nil || existing.DeletedAt != nil
+	mirrorUpsert(ctx, s.config.SecondaryWriter, upserted)
+	if created {
+		emitCreated(ctx, s.config, upserted)
+	} else {
+		emitUpdated(ctx, s.config, existing, upserted)
+	}
+	return upserted, created, nil
+}
+
+// Restore clears the deletion mark of a soft-deleted translation. It returns
+// ErrTranslationNotFound when the translation is not soft-deleted and the
+// error of Config.OwnershipPolicy when userID may not change it.
+func (s *TranslatableService) Restore(ctx context.Context, id uuid.UUID, userID *uuid.UUID) (*Translatable, error) {
+	d := s.db.Dialect()
+	var deleted Translatable
+	tenant, args := s.config.tenantCondition(ctx, d, "tenant_id", []any{id})
+	query := "SELECT " + translatableColumns + " FROM " + s.config.table() + " WHERE id = " + d.Placeholder(1) + " AND deleted_at IS NOT NULL" + tenant
+	err := s.db.QueryRow(ctx, query, args...).Scan(deleted.scanFields()...)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrTranslationNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := s.config.checkOwnership(ctx, userID, deleted.UserID); err != nil {
+		return nil, err
+	}
+
+	tenant, args = s.config.tenantCondition(ctx, d, "tenant_id", []any{time.Now(), id})
+	query = "UPDATE " + s.config.table() + " SET deleted_at = NULL, updated_at = " + d.Placeholder(1) +
+		" WHERE id = " + d.Placeholder(2) + " AND deleted_at IS NOT NULL" + tenant
+	result, err := s.db.Exec(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	if affected, err := result.RowsAffected(); err == nil && affected == 0 {
+		return nil, ErrTranslationNotFound
+	}
+
+	restored, err := s.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	mirrorUpsert(ctx, s.config.SecondaryWriter, restored)
+	emitRestored(ctx, s.config, restored)
+	return restored, nil
+}
+
+// Trash returns one page of the soft-deleted translations, most recently
+// deleted first, along with the total number of them. An empty translatable
+// covers every type. Content is not read with Config.TrashHidesContent.
+func (s *TranslatableService) Trash(ctx context.Context, translatable string, limit, offset int) ([]TrashedTranslation, int, error) {
+	d := s.db.Dialect()
+	from := " FROM " + s.config.table() + " WHERE deleted_at IS NOT NULL"
+	var args []any
+	if translatable != "" {
+		from += " AND translatable = " + d.Placeholder(1)
+		args = append(args, translatable)
+	}
+	tenant, args := s.config.tenantCondition(ctx, d, "tenant_id", args)
+	from += tenant
+
+	var total int
+	if err := s.db.QueryRow(ctx, "SELECT COUNT(*)"+from, args...).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+
+	columns := "id, translatable, locale, deleted_at, updated_by"
+	if !s.config.TrashHidesContent {
+		columns += ", content"
+	}
+	sql := "SELECT " + columns + from + fmt.Sprintf(" ORDER BY deleted_at DESC, id LIMIT %d OFFSET %d", limit, offset)
+	rows, err := s.db.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer func() { _ = rows.Close() }()
+
+	trashed := make([]TrashedTranslation, 0, limit)
+	for rows.Next() {
+		var t TrashedTranslation
+		dest := []any{&t.ID, &t.Translatable, &t.Locale, &t.DeletedAt, &t.UpdatedBy}
+		if !s.config.TrashHidesContent {
+			dest = append(dest, &t.Content)
+		}
+		if err := rows.Scan(dest...); err != nil {
+			return nil, 0, err
+		}
+		trashed = append(trashed, t)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, 0, err
+	}
+	return trashed, total, nil
+}
+
+// Recreate writes t, a translation being created, over the soft-deleted or
+// expired translation still holding its entity, type and locale, which comes
+// back with t's content as if created anew. It returns ErrTranslationExists
+// when that translation is live by the time it is written, and the error of
+// Config.OwnershipPolicy when userID may not change it. t has been through
+// CreateHook, lifecycle hook included.
+func (s *TranslatableService) Recreate(ctx context.Context, t *Translatable, userID *uuid.UUID) (*Translatable, error) {
+	now := time.Now()
+	err := s.WithTx(ctx, func(tx database.Tx) error {
+		existing, err := s.getByNaturalKey(ctx, tx, t.Translatable, t.TranslatableID, t.Locale)
+		if err != nil {
+			return err
+		}
+		if existing.isLive(now) {
+			return ErrTranslationExists
+		}
+		if err := s.config.checkOwnership(ctx, userID, existing.UserID); err != nil {
+			return err
+		}
+		t.takeOver(existing, now)
+		return s.writeOverwrite(ctx, tx, existing, t, now)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	mirrorUpsert(ctx, s.config.SecondaryWriter, t)
+	emitCreated(ctx, s.config, t)
+	return t, nil
+}
+
+// getByNaturalKey returns the stored translation of an entity in locale through
+// q, including soft-deleted and expired ones, which still hold the key. When q
+// is a transaction the row stays locked until it ends.
+func (s *TranslatableService) getByNaturalKey(ctx context.Context, q rowsQuerier, translatable string, translatableID uuid.UUID, locale string) (*Translatable, error) {
+	d := s.db.Dialect()
+	tenant, args := s.config.tenantCondition(ctx, d, "tenant_id", []any{translatable, translatableID, locale})
+	sql := "SELECT " + translatableColumns + " FROM " + s.config.table() + " WHERE translatable = " + d.Placeholder(1) +
+		" AND translatable_id = " + d.Placeholder(2) +
+		" AND locale = " + d.Placeholder(3) + tenant
+	if _, ok := q.(database.Tx); ok {
+		sql += s.forUpdate()
+	}
+	rows, err := q.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = rows.Close() }()
+
+	if !rows.Next() {
+		if err := rows.Err(); err != nil {
+			return nil, err
+		}
+		return nil, ErrTranslationNotFound
+	}
+	var t Translatable
+	if err := rows.Scan(t.scanFields()...); err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
+
+// Count returns the number of translations matching the same query-string
+// filters accepted by the collection endpoint.
+func (s *TranslatableService) Count(ctx context.Context, params url.Values) (int, error) {
+	return s.count(ctx, s.db, params)
+}
+
+// Exists reports whether the entity has a readable translation in locale,
+// asking the database for a single boolean rather than fetching the row.
+func (s *TranslatableService) Exists(ctx context.Context, translatable string, translatableID uuid.UUID, locale string) (bool, error) {
+	params := url.Values{
+		"translatable":    {translatable},
+		"translatable_id": {translatableID.String()},
+		"locale":          {locale},
+	}
+	builder, err := s.filteredSelect(ctx, params, "id")
+	if err != nil {
+		return false, err
+	}
+
+	sql, args, err := builder.Limit(1).Build()
+	if err != nil {
+		return false, err
+	}
+
+	var exists bool
+	if err := s.db.QueryRow(ctx, "SELECT EXISTS ("+sql+")", args...).Scan(&exists); err != nil {
+		return false, err
+	}
+	return exists, nil
+}
+
+// ListInSnapshot returns one page of translations matching the collection
+// filters and ordering, read through tx so that every page sees the same data,
+// along with the total number of matches.
+func (s *TranslatableService) ListInSnapshot(ctx context.Context, tx database.Tx, params url.Values, limit, offset int) ([]Translatable, int, error) {
+	total, err := s.count(ctx, tx, params)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	builder, err := s.orderedSelect(ctx, params)
+	if err != nil {
+		return nil, 0, err
+	}
+	builder = builder.Limit(limit).Offset(offset)
+
+	sql, args, err := builder.Build()
+	if err != nil {
+		return nil, 0, err
+	}
+
+	rows, err := tx.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer func() { _ = rows.Close() }()
+
+	translations := make([]Translatable, 0, limit)
+	for rows.Next() {
+		var t Translatable
+		if err := rows.Scan(t.scanFields()...); err != nil {
+			return nil, 0, err
+		}
+		translations = append(translations, t)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, 0, err
+	}
+
+	if err := s.crudHooks.SerializeMany(ctx, hooks.OperationGetAll, &translations); err != nil {
+		return nil, 0, err
+	}
+	return translations, total, nil
+}
+
+// Export returns the rows of every translation matching the collection filters
+// and ordering, for the caller to stream and close. Rows are not serialized.
+func (s *TranslatableService) Export(ctx context.Context, params url.Values) (database.Rows, error) {
+	builder, err := s.orderedSelect(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+
+	sql, args, err := builder.Build()
+	if err != nil {
+		return nil, err
+	}
+	return s.db.Query(ctx, sql, args...)
+}
+
+// sourceTranslations returns the live source-locale translations of the given
+// entities, keyed by entity. Entities without one are left out.
+func (s *TranslatableService) sourceTranslations(ctx context.Context, keys []entityKey) (map[entityKey]Translatable, error) {
+	d := s.db.Dialect()
+	wanted := make(map[entityKey]bool, len(keys))
+	args := []any{s.config.DefaultLocale}
+	placeholders := make([]string, 0, len(keys))
+	for _, key := range keys {
+		if wanted[key] {
+			continue
+		}
+		wanted[key] = true
+		args = append(args, key.TranslatableID)
+		placeholders = append(placeholders, d.Placeholder(len(args)))
+	}
+
+	args = append(args, time.Now())
+	expires := d.Placeholder(len(args))
+	tenant, args := s.config.tenantCondition(ctx, d, "tenant_id", args)
+	sql := "SELECT " + translatableColumns + " FROM " + s.config.table() + " WHERE locale = " + d.Placeholder(1) +
+		" AND translatable_id IN (" + strings.Join(placeholders, ", ") + ")" +
+		" AND deleted_at IS NULL AND (expires_at IS NULL OR expires_at > " + expires + ")" + tenant
+	rows, err := s.db.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = rows.Close() }()
+
+	sources := make(map[entityKey]Translatable, len(wanted))
+	for rows.Next() {
+		var t Translatable
+		if err := rows.Scan(t.scanFields()...); err != nil {
+			return nil, err
+		}
+		key := entityKey{Translatable: t.Translatable, TranslatableID: t.TranslatableID}
+		if wanted[key] {
+			sources[key] = t
+		}
+	}
+	return sources, rows.Err()
+}
+
+// entitiesWithSource returns the entities whose live source-locale content is
+// exactly content, the way a PO msgid refers back to them.
+func (s *TranslatableService) entitiesWithSource(ctx context.Context, content string) ([]entityKey, error) {
+	d := s.db.Dialect()
+	tenant, args := s.config.tenantCondition(ctx, d, "tenant_id", []any{s.config.DefaultLocale, content, time.Now()})
+	sql := "SELECT translatable, translatable_id FROM " + s.config.table() + " WHERE locale = " + d.Placeholder(1) +
+		" AND content = " + d.Placeholder(2) +
+		" AND deleted_at IS NULL AND (expires_at IS NULL OR expires_at > " + d.Placeholder(3) + ")" + tenant + " ORDER BY translatable, translatable_id"
+	rows, err := s.db.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = rows.Close() }()
+
+	var keys []entityKey
+	for rows.Next() {
+		var key entityKey
+		if err := rows.Scan(&key.Translatable, &key.TranslatableID); err != nil {
+			return nil, err
+		}
+		keys = append(keys, key)
+	}
+	return keys, rows.Err()
+}
+
+// orderedSelect selects every column of the translations matching the
+// collection filters, in the requested order with id as the tie-breaker.
+func (s *TranslatableService) orderedSelect(ctx context.Context, params url.Values) (*query.SelectBuilder, error) {
+	builder, err := s.filteredSelect(ctx, params, strings.Split(translatableColumns, ", ")...)
+	if err != nil {
+		return nil, err
+	}
+
+	sort, err := s.config.sortClause(params)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidFilter, err)
+	}
+	if sort != nil {
+		builder = builder.OrderBy(sort.Column, sort.Direction)
+	}
+
+	ordering := filter.NewOrderSetWithMapping(translatableFieldMap)
+	if err := ordering.ParseFromQuery(params); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidFilter, err)
+	}
+	for _, clause := range ordering.OrderClauses() {
+		builder = builder.OrderBy(clause.Column, clause.Direction)
+	}
+	return builder.OrderBy("id", query.ASC), nil
+}
+
+type rowQuerier interface {
+	QueryRow(ctx context.Context, query string, args ...interface{}) database.Row
+}
+
+type rowsQuerier interface {
+	Query(ctx context.Context, query string, args ...interface{}) (database.Rows, error)
+}
+
+type execer interface {
+	Exec(ctx context.Context, query string, args ...interface{}) (database.Result, error)
+}
+
+func (s *TranslatableService) count(ctx context.Context, q rowQuerier, params url.Values) (int, error) {
+	builder, err := s.filteredSelect(ctx, params, "COUNT(*)")
+	if err != nil {
+		return 0, err
+	}
+
+	sql, args, err := builder.Build()
+	if err != nil {
+		return 0, err
+	}
+
+	var count int
+	if err := q.QueryRow(ctx, sql, args...).Scan(&count); err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+// filteredSelect builds a SELECT over translations restricted by the
+// query-string filters and the read rules of the CRUD hooks.
+func (s *TranslatableService) filteredSelect(ctx context.Context, params url.Values, columns ...string) (*query.SelectBuilder, error) {
+	filters := filter.NewFilterSetWithMapping(translatableFieldMap, s.db.Dialect())
+	if err := filters.ParseFromQuery(params); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidFilter, err)
+	}
+	ranges, err := dateRangeConditions(params)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidFilter, err)
+	}
+
+	builder := query.New(s.db.Dialect()).Select(columns...).From(s.config.table())
+	builder, _ = s.crudHooks.ModifySelectQuery(ctx, hooks.OperationGetAll, builder)
+	for _, condition := range append(filters.Conditions(), ranges...) {
+		builder = builder.Where(condition)
+	}
+	return builder, nil
+}
+
+// Stale returns one page of translations whose source checksum no longer
+// matches their default-locale sibling, i.e. the source content changed after
+// they were last written, along with the total number of them. An empty
+// translatable covers every type.
+func (s *TranslatableService) Stale(ctx context.Context, translatable string, limit, offset int) ([]Translatable, int, error) {
+	return s.listAgainstSource(ctx, "t.source_checksum <> src.source_checksum", translatable, limit, offset)
+}
+
+// Untranslated returns one page of translations whose content is identical to
+// that of their default-locale sibling, often placeholders that were never
+// actually translated, along with the total number of them. An empty
+// translatable covers every type.
+func (s *TranslatableService) Untranslated(ctx context.Context, translatable string, limit, offset int) ([]Translatable, int, error) {
+	return s.listAgainstSource(ctx, "t.content = src.content", translatable, limit, offset)
+}
+
+// listAgainstSource returns one page of the live translations, outside the
+// default locale, that meet condition against their default-locale sibling
+// src, along with the total number of them.
+func (s *TranslatableService) listAgainstSource(ctx context.Context, condition, translatable string, limit, offset int) ([]Translatable, int, error) {
+	d := s.db.Dialect()
+	from := " FROM " + s.config.table() + " t JOIN " + s.config.table() + " src ON src.translatable = t.translatable" +
+		" AND src.translatable_id = t.translatable_id AND src.locale = " + d.Placeholder(1) +
+		" WHERE t.locale <> " + d.Placeholder(2) +
+		" AND " + condition +
+		" AND t.deleted_at IS NULL AND src.deleted_at IS NULL" +
+		" AND (t.expires_at IS NULL OR t.expires_at > " + d.Placeholder(3) + ")"
+	args := []any{s.config.DefaultLocale, s.config.DefaultLocale, time.Now()}
+	if translatable != "" {
+		from += " AND t.translatable = " + d.Placeholder(4)
+		args = append(args, translatable)
+	}
+	tenant, args := s.config.tenantCondition(ctx, d, "t.tenant_id", args)
+	from += tenant
+	if s.config.TenantScoped {
+		from += " AND src.tenant_id = t.tenant_id"
+	}
+
+	var total int
+	if err := s.db.QueryRow(ctx, "SELECT COUNT(*)"+from, args...).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+
+	columns := strings.Split(translatableColumns, ", ")
+	for i, column := range columns {
+		columns[i] = "t." + column
+	}
+	sql := "SELECT " + strings.Join(columns, ", ") + from +
+		fmt.Sprintf(" ORDER BY t.translatable, t.translatable_id, t.locale LIMIT %d OFFSET %d", limit, offset)
+
+	rows, err := s.db.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer func() { _ = rows.Close() }()
+
+	translations := make([]Translatable, 0, limit)
+	for rows.Next() {
+		var t Translatable
+		if err := rows.Scan(t.scanFields()...); err != nil {
+			return nil, 0, err
+		}
+		translations = append(translations, t)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, 0, err
+	}
+
+	if err := s.crudHooks.SerializeMany(ctx, hooks.OperationGetAll, &translations); err != nil {
+		return nil, 0, err
+	}
+	return translations, total, nil
+}
+
+// NormalizeTypes rewrites stored type names that only differ from an allowed
+// type by case to their canonical spelling, and returns the number of updated
+// rows. Reads already present canonical names, so it can run at any time.
+func (s *TranslatableService) NormalizeTypes(ctx context.Context) (int64, error) {
+	rows, err := s.db.Query(ctx, "SELECT DISTINCT translatable FROM "+s.config.table())
+	if err != nil {
+		return 0, err
+	}
+
+	var stale []string
+	for rows.Next() {
+		var stored string
+		if err := rows.Scan(&stored); err != nil {
+			_ = rows.Close()
+			return 0, err
+		}
+		if canonical, ok := s.config.CanonicalType(stored); ok && canonical != stored {
+			stale = append(stale, stored)
+		}
+	}
+	_ = rows.Close()
+	if err := rows.Err(); err != nil {
+		return 0, err
+	}
+
+	d := s.db.Dialect()
+	sql := "UPDATE " + s.config.table() + " SET translatable = " + d.Placeholder(1) + " WHERE translatable = " + d.Placeholder(2)
+	var updated int64
+	for _, stored := range stale {
+		canonical, _ := s.config.CanonicalType(stored)
+		result, err := s.db.Exec(ctx, sql, canonical, stored)
+		if err != nil {
+			return updated, err
+		}
+		affected, err := result.RowsAffected()
+		if err != nil {
+			return updated, err
+		}
+		updated += affected
+	}
+	return updated, nil
+}
+
+// PurgeExpired hard-deletes translations whose TTL has elapsed and returns the
+// number of removed rows. It is meant to be called periodically by the host app.
+// Each purged translation is deleted from Config.SecondaryWriter and announced
+// like any other delete.
+func (s *TranslatableService) PurgeExpired(ctx context.Context) (int64, error) {
+	d := s.db.Dialect()
+	var purged []Translatable
+	err := s.WithTx(ctx, func(tx database.Tx) error {
+		sql := "SELECT " + translatableColumns + " FROM " + s.config.table() +
+			" WHERE expires_at IS NOT NULL AND expires_at <= " + d.Placeholder(1) + s.forUpdate()
+		rows, err := tx.Query(ctx, sql, time.Now())
+		if err != nil {
+			return err
+		}
+		defer func() { _ = rows.Close() }()
+
+		var ids []any
+		var placeholders []string
+		for rows.Next() {
+			var t Translatable
+			if err := rows.Scan(t.scanFields()...); err != nil {
+				return err
+			}
+			purged = append(purged, t)
+			ids = append(ids, t.ID)
+			placeholders = append(placeholders, d.Placeholder(len(ids)))
+		}
+		if err := rows.Err(); err != nil || len(ids) == 0 {
+			return err
+		}
+
+		_, err = tx.Exec(ctx, "DELETE FROM "+s.config.table()+" WHERE id IN ("+strings.Join(placeholders, ", ")+")", ids...)
+		return err
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	for i := range purged {
+		mirrorDelete(ctx, s.config.SecondaryWriter, purged[i].ID)
+		emitDeleted(ctx, s.config, &purged[i])
+	}
+	return int64(len(purged)), nil
+}
+
+// forUpdate returns the clause locking the rows a SELECT reads until the end
+// of its transaction. SQLite has none, and needs none: it runs one write
+// transaction at a time.
+func (s *TranslatableService) forUpdate() string {
+	if s.db.DriverName() == "sqlite" {
+		return ""
+	}
+	return " FOR UPDATE"
+}
+
+// WithTx runs fn in a transaction, committed when fn returns nil and rolled
+// back otherwise, including when fn panics. The transaction runs at the
+// database's default isolation level (read committed on Postgres, repeatable
+// read on MySQL), so fn should lock or re-read rows it needs to stay unchanged
+// rather than rely on what it read before the write.
+func (s *TranslatableService) WithTx(ctx context.Context, fn func(tx database.Tx) error) error {
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	if err := fn(tx); err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
+
+// errImportRejected rolls an import back once row errors were reported.
+var errImportRejected = errors.New("import rejected")
+
+// CloneEntity copies every locale of the source entity onto the target entity
+// within a single transaction. Locales the target already has are left as is.
+// The copies get new ids and keep their content and publish state; ownership
+// goes to userID when set.
+func (s *TranslatableService) CloneEntity(ctx context.Context, translatable string, from, to uuid.UUID, userID *uuid.UUID) ([]Translatable, error) {
+	if from == to {
+		return nil, ErrSameEntity
+	}
+
+	var created []Translatable
+	err := s.WithTx(ctx, func(tx database.Tx) error {
+		existing, err := s.entityLocales(ctx, tx, translatable, to)
+		if err != nil {
+			return err
+		}
+
+		sources, err := s.entityTranslations(ctx, tx, translatable, from)
+		if err != nil {
+			return err
+		}
+
+		now := time.Now()
+		created = make([]Translatable, 0, len(sources))
+		for _, source := range sources {
+			if existing[source.Locale] {
+				continue
+			}
+
+			clone := source
+			clone.ID = uuid.New()
+			clone.TranslatableID = to
+			clone.UpdatedAt = nil
+			clone.CreatedAt = now
+			clone.ExpiresAt = nil
+			clone.ReceivedAt = s.hooks.trackReceivedAt(ctx)
+			if ttl, ok := s.config.TypeTTLs[translatable]; ok {
+				expiresAt := now.Add(ttl)
+				clone.ExpiresAt = &expiresAt
+			}
+			if userID != nil {
+				clone.UserID = userID
+			}
+
+			if err := s.insertTranslatable(ctx, tx, &clone); err != nil {
+				return err
+			}
+			created = append(created, clone)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range created {
+		mirrorUpsert(ctx, s.config.SecondaryWriter, &created[i])
+		emitCreated(ctx, s.config, &created[i])
+	}
+	return created, nil
+}
+
+// CopyLocale copies, verbatim, the from translations of every entity of a type
+// into the to locale within a single transaction. Entities already translated
+// in to are left as is unless overwrite is set, and even then only when the
+// translation belongs to userID, if set. The copies keep the source checksum
+// and are not published. It returns the number of translations written.
+func (s *TranslatableService) CopyLocale(ctx context.Context, translatable, from, to string, overwrite bool, userID *uuid.UUID) (int, error) {
+	if from == to {
+		return 0, ErrSameLocale
+	}
+
+	now := time.Now()
+	var created, updated, previous []Translatable
+	err := s.WithTx(ctx, func(tx database.Tx) error {
+		sources, err := s.localeRows(ctx, tx, translatable, from)
+		if err != nil {
+			return err
+		}
+		targets, err := s.localeRows(ctx, tx, translatable, to)
+		if err != nil {
+			return err
+		}
+
+		for id, source := range sources {
+			if !source.isLive(now) {
+				continue
+			}
+			existing, exists := targets[id]
+			live := exists && existing.isLive(now)
+			if live && (!overwrite || (userID != nil && existing.UserID != nil && *existing.UserID != *userID)) {
+				continue
+			}
+
+			copied := source
+			copied.ID = uuid.New()
+			copied.Locale = to
+			copied.PublishedContent = nil
+			copied.PublishedAt = nil
+			copied.Status = ""
+			copied.ReviewedBy = nil
+			copied.ReviewedAt = nil
+			copied.AutoTranslated = false
+			copied.UpdatedAt = nil
+			copied.CreatedAt = now
+			copied.ExpiresAt = nil
+			copied.ReceivedAt = s.hooks.trackReceivedAt(ctx)
+			if ttl, ok := s.config.TypeTTLs[translatable]; ok {
+				expiresAt := now.Add(ttl)
+				copied.ExpiresAt = &expiresAt
+			}
+			if userID != nil {
+				copied.UserID = userID
+			}
+
+			if !exists {
+				if err := s.insertTranslatable(ctx, tx, &copied); err != nil {
+					return err
+				}
+				created = append(created, copied)
+				continue
+			}
+			if err := s.overwriteTranslatable(ctx, tx, &existing, &copied, now); err != nil {
+				return err
+			}
+			if live {
+				updated = append(updated, copied)
+				previous = append(previous, existing)
+			} else {
+				created = append(created, copied)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	for i := range created {
+		mirrorUpsert(ctx, s.config.SecondaryWriter, &created[i])
+		emitCreated(ctx, s.config, &created[i])
+	}
+	for i := range updated {
+		mirrorUpsert(ctx, s.config.SecondaryWriter, &updated[i])
+		emitUpdated(ctx, s.config, &previous[i], &updated[i])
+	}
+	return len(created) + len(updated), nil
+}
+
+const (
+	ReplaceModeMerge   = "merge"
+	ReplaceModeReplace = "replace"
+)
+
+// ReplaceLocales writes translations, one per locale, as the translations of
+// an entity within a single transaction: locales the entity already has are
+// updated, missing ones are created and, in ReplaceModeReplace, locales absent
+// from translations are deleted. ReplaceModeMerge leaves them untouched. It
+// returns the resulting translations ordered by locale, or the error of
+// Config.OwnershipPolicy when userID may not change one of them.
+func (s *TranslatableService) ReplaceLocales(ctx context.Context, translatable string, translatableID uuid.UUID, translations []Translatable, mode string, userID *uuid.UUID) ([]Translatable, error) {
+	now := time.Now()
+
+	d := s.db.Dialect()
+	var rows map[string]Translatable
+	var created, updated, previous, deleted []Translatable
+	err := s.WithTx(ctx, func(tx database.Tx) error {
+		var err error
+		rows, err = s.entityRows(ctx, tx, translatable, translatableID)
+		if err != nil {
+			return err
+		}
+
+		for _, t := range translations {
+			existing, ok := rows[t.Locale]
+			if !ok {
+				if err := s.insertTranslatable(ctx, tx, &t); err != nil {
+					return err
+				}
+				created = append(created, t)
+				continue
+			}
+			if err := s.config.checkOwnership(ctx, userID, existing.UserID); err != nil {
+				return err
+			}
+
+			if err := s.overwriteTranslatable(ctx, tx, &existing, &t, now); err != nil {
+				return err
+			}
+			if existing.isLive(now) {
+				updated = append(updated, t)
+				previous = append(previous, existing)
+			} else {
+				created = append(created, t)
+			}
+		}
+
+		if mode != ReplaceModeReplace {
+			return nil
+		}
+		kept := make(map[string]bool, len(translations))
+		for _, t := range translations {
+			kept[t.Locale] = true
+		}
+		for locale, existing := range rows {
+			if kept[locale] || !existing.isLive(now) {
+				continue
+			}
+			if err := s.config.checkOwnership(ctx, userID, existing.UserID); err != nil {
+				return err
+			}
+			if err := runBeforeHook(ctx, s.config.Hooks.BeforeDelete, &existing); err != nil {
+				return err
+			}
+			sql := "DELETE FROM " + s.config.table() + " WHERE id = " + d.Placeholder(1)
+			args := []any{existing.ID}
+			if s.config.SoftDelete {
+				sql = "UPDATE " + s.config.table() + " SET deleted_at = " + d.Placeholder(1) + ", updated_by = " + d.Placeholder(2) + " WHERE id = " + d.Placeholder(3)
+				args = []any{now, userID, existing.ID}
+			}
+			tenant, args := s.config.tenantCondition(ctx, d, "tenant_id", args)
+			if _, err := tx.Exec(ctx, sql+tenant, args...); err != nil {
+				return err
+			}
+			deleted = append(deleted, existing)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range created {
+		mirrorUpsert(ctx, s.config.SecondaryWriter, &created[i])
+		emitCreated(ctx, s.config, &created[i])
+	}
+	for i := range updated {
+		mirrorUpsert(ctx, s.config.SecondaryWriter, &updated[i])
+		emitUpdated(ctx, s.config, &previous[i], &updated[i])
+	}
+	for i := range deleted {
+		mirrorDelete(ctx, s.config.SecondaryWriter, deleted[i].ID)
+		emitDeleted(ctx, s.config, &deleted[i])
+	}
+
+	touched := make(map[string]bool, len(translations)+len(deleted))
+	for _, t := range translations {
+		touched[t.Locale] = true
+	}
+	for _, t := range deleted {
+		touched[t.Locale] = true
+	}
+	result := slices.Concat(created, updated)
+	for locale, existing := range rows {
+		if !touched[locale] && existing.isLive(now) {
+			result = append(result, existing)
+		}
+	}
+	slices.SortFunc(result, func(a, b Translatable) int { return strings.Compare(a.Locale, b.Locale) })
+
+	if err := s.crudHooks.SerializeMany(ctx, hooks.OperationGetAll, &result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// Import writes translations, read from the given file lines, in a single
+// transaction. A translation whose entity, type and locale are already served
+// is skipped, overwritten or reported as a conflict depending on strategy, while
+// soft-deleted or expired ones are overwritten whatever the strategy. Any row
+// error, including conflicts and overwrites of other users' translations
+// denied by Config.OwnershipPolicy, deleted or not, rolls the whole import back
+// and is returned in the report.
+func (s *TranslatableService) Import(ctx context.Context, translations []Translatable, lines []int, strategy string, userID *uuid.UUID) (*ImportReport, error) {
+	report := &ImportReport{Errors: []ImportRowError{}}
+	now := time.Now()
+	var created, updated, previous []Translatable
+	err := s.WithTx(ctx, func(tx database.Tx) error {
+		for i, t := range translations {
+			existing, err := s.getByNaturalKey(ctx, tx, t.Translatable, t.TranslatableID, t.Locale)
+			if errors.Is(err, ErrTranslationNotFound) {
+				if err := s.insertTranslatable(ctx, tx, &t); err != nil {
+					return err
+				}
+				created = append(created, t)
+				continue
+			}
+			if err != nil {
+				return err
+			}
+
+			ownership := s.config.checkOwnership(ctx, userID, existing.UserID)
+			switch {
+			case !existing.isLive(now) && ownership != nil:
+				report.Errors = append(report.Errors, ImportRowError{Line: lines[i], Error: ownership.Error()})
+			case !existing.isLive(now):
+				if err := s.overwriteTranslatable(ctx, tx, existing, &t, now); err != nil {
+					return err
+				}
+				created = append(created, t)
+			case strategy == ImportSkipExisting:
+				report.Skipped++
+			case strategy == ImportFailOnConflict:
+				report.Errors = append(report.Errors, ImportRowError{Line: lines[i], Error: ErrTranslationExists.Error()})
+			case ownership != nil:
+				report.Errors = append(report.Errors, ImportRowError{Line: lines[i], Error: ownership.Error()})
+			default:
+				if err := s.overwriteTranslatable(ctx, tx, existing, &t, now); err != nil {
+					return err
+				}
+				updated = append(updated, t)
+				previous = append(previous, *existing)
+			}
+		}
+		if len(report.Errors) > 0 {
+			return errImportRejected
+		}
+		return nil
+	})
+	if errors.Is(err, errImportRejected) {
+		return report, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range created {
+		mirrorUpsert(ctx, s.config.SecondaryWriter, &created[i])
+		emitCreated(ctx, s.config, &created[i])
+	}
+	for i := range updated {
+		mirrorUpsert(ctx, s.config.SecondaryWriter, &updated[i])
+		emitUpdated(ctx, s.config, &previous[i], &updated[i])
+	}
+	report.Created = len(created)
+	report.Updated = len(updated)
+	return report, nil
+}
+
+// overwriteTranslatable replaces the content of existing with that of t, which
+// takes over the identity, ownership and publish state of existing. A
+// soft-deleted existing translation is restored. Overwriting a translation
+// that is no longer live counts as creating one for the lifecycle hooks.
+func (s *TranslatableService) overwriteTranslatable(ctx context.Context, tx execer, existing, t *Translatable, now time.Time) error {
+	t.takeOver(existing, now)
+	before := s.config.Hooks.BeforeUpdate
+	if !existing.isLive(now) {
+		before = s.config.Hooks.BeforeCreate
+	}
+	if err := runBeforeHook(ctx, before, t); err != nil {
+		return err
+	}
+	return s.writeOverwrite(ctx, tx, existing, t, now)
+}
+
+// takeOver gives t, about to overwrite existing, the identity, ownership and
+// publish state of existing.
+func (t *Translatable) takeOver(existing *Translatable, now time.Time) {
+	t.ID = existing.ID
+	t.UserID = existing.UserID
+	t.PublishedContent = existing.PublishedContent
+	t.PublishedAt = existing.PublishedAt
+	t.CreatedAt = existing.CreatedAt
+	t.ReceivedAt = existing.ReceivedAt
+	t.UpdatedAt = &now
+	t.Version = existing.Version + 1
+	t.TenantID = existing.TenantID
+	t.Status = existing.Status
+	t.ReviewedBy = existing.ReviewedBy
+	t.ReviewedAt = existing.ReviewedAt
+}
+
+// writeOverwrite stores the content of t over existing, restoring it when
+// soft-deleted.
+func (s *TranslatableService) writeOverwrite(ctx context.Context, tx execer, existing, t *Translatable, now time.Time) error {
+	d := s.db.Dialect()
+	sql := "UPDATE " + s.config.table() + " SET content = " + d.Placeholder(1) +
+		", content_raw = " + d.Placeholder(2) +
+		", source_checksum = " + d.Placeholder(3) +
+		", auto_translated = " + d.Placeholder(4) +
+		", expires_at = " + d.Placeholder(5) +
+		", deleted_at = NULL, version = version + 1, updated_at = " + d.Placeholder(6) +
+		" WHERE id = " + d.Placeholder(7)
+	tenant, args := s.config.tenantCondition(ctx, d, "tenant_id", []any{t.Content, t.ContentRaw, t.SourceChecksum, t.AutoTranslated, t.ExpiresAt, now, existing.ID})
+	_, err := tx.Exec(ctx, sql+tenant, args...)
+	return err
+}
+
+// entityRows returns every stored translation of an entity keyed by locale,
+// including soft-deleted and expired ones, which still hold their locale.
+func (s *TranslatableService) entityRows(ctx context.Context, tx rowsQuerier, translatable string, id uuid.UUID) (map[string]Translatable, error) {
+	d := s.db.Dialect()
+	tenant, args := s.config.tenantCondition(ctx, d, "tenant_id", []any{translatable, id})
+	sql := "SELECT " + translatableColumns + " FROM " + s.config.table() + " WHERE translatable = " + d.Placeholder(1) +
+		" AND translatable_id = " + d.Placeholder(2) + tenant
+	rows, err := tx.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = rows.Close() }()
+
+	translations := make(map[string]Translatable)
+	for rows.Next() {
+		var t Translatable
+		if err := rows.Scan(t.scanFields()...); err != nil {
+			return nil, err
+		}
+		translations[t.Locale] = t
+	}
+	return translations, rows.Err()
+}
+
+// localeRows returns every stored translation of a type in locale keyed by
+// entity, including soft-deleted and expired ones, which still hold their key.
+func (s *TranslatableService) localeRows(ctx context.Context, tx rowsQuerier, translatable, locale string) (map[uuid.UUID]Translatable, error) {
+	d := s.db.Dialect()
+	tenant, args := s.config.tenantCondition(ctx, d, "tenant_id", []any{translatable, locale})
+	sql := "SELECT " + translatableColumns + " FROM " + s.config.table() + " WHERE translatable = " + d.Placeholder(1) +
+		" AND locale = " + d.Placeholder(2) + tenant
+	rows, err := tx.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = rows.Close() }()
+
+	translations := make(map[uuid.UUID]Translatable)
+	for rows.Next() {
+		var t Translatable
+		if err := rows.Scan(t.scanFields()...); err != nil {
+			return nil, err
+		}
+		translations[t.TranslatableID] = t
+	}
+	return translations, rows.Err()
+}
+
+func (s *TranslatableService) entityTranslations(ctx context.Context, tx database.Tx, translatable string, id uuid.UUID) ([]Translatable, error) {
+	d := s.db.Dialect()
+	sql := "SELECT " + translatableColumns + " FROM " + s.config.table() + " WHERE translatable = " + d.Placeholder(1) +
+		" AND translatable_id = " + d.Placeholder(2) +
+		" AND deleted_at IS NULL" +
+		" AND (expires_at IS NULL OR expires_at > " + d.Placeholder(3) + ")"
+	tenant, args := s.config.tenantCondition(ctx, d, "tenant_id", []any{translatable, id, time.Now()})
+	rows, err := tx.Query(ctx, sql+tenant, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = rows.Close() }()
+
+	var translations []Translatable
+	for rows.Next() {
+		var t Translatable
+		if err := rows.Scan(t.scanFields()...); err != nil {
+			return nil, err
+		}
+		translations = append(translations, t)
+	}
+	return translations, rows.Err()
+}
+
+func (s *TranslatableService) entityLocales(ctx context.Context, tx database.Tx, translatable string, id uuid.UUID) (map[string]bool, error) {
+	d := s.db.Dialect()
+	tenant, args := s.config.tenantCondition(ctx, d, "tenant_id", []any{translatable, id})
+	sql := "SELECT locale FROM " + s.config.table() + " WHERE translatable = " + d.Placeholder(1) + " AND translatable_id = " + d.Placeholder(2) + tenant
+	rows, err := tx.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = rows.Close() }()
+
+	locales := make(map[string]bool)
+	for rows.Next() {
+		var locale string
+		if err := rows.Scan(&locale); err != nil {
+			return nil, err
+		}
+		locales[locale] = true
+	}
+	return locales, rows.Err()
+}
+
+func (s *TranslatableService) insertTranslatable(ctx context.Context, tx execer, t *Translatable) error {
+	t.Version = 1
+	t.TenantID = s.config.tenantID(ctx)
+	if t.Status == "" {
+		t.Status = s.config.DefaultStatus
+	}
+	if err := runBeforeHook(ctx, s.config.Hooks.BeforeCreate, t); err != nil {
+		return err
+	}
+	args := t.columnValues()
+	placeholders := make([]string, len(args))
+	for i := range args {
+		placeholders[i] = s.db.Dialect().Placeholder(i + 1)
+	}
+
+	sql := "INSERT INTO " + s.config.table() + " (" + translatableColumns + ") VALUES (" + strings.Join(placeholders, ", ") + ")"
+	_, err := tx.Exec(ctx, sql, args...)
+	return err
+}
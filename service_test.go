@@ -1,8 +1,16 @@
 package translatable
 
 import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/google/uuid"
+	"github.com/nicolasbonnici/gorest-translatable/mocks"
+	"github.com/nicolasbonnici/gorest/database"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -77,6 +85,68 @@ func TestTranslatableService_GetLocales(t *testing.T) {
 	}
 }
 
+func TestTranslatableService_GetLocales_Direction(t *testing.T) {
+	service := NewTranslatableService(nil, &Config{
+		SupportedLocales: []string{"en", "ar", "he", "fa-IR", "fr", "az-Arab", "ks-Deva", "x-custom", "en_legacy"},
+		DefaultLocale:    "en",
+	})
+
+	directions := make(map[string]string)
+	names := make(map[string]string)
+	for _, info := range service.GetLocales().Locales {
+		directions[info.Locale] = info.Direction
+		names[info.Locale] = info.DisplayName
+	}
+
+	assert.Equal(t, map[string]string{
+		"en":        DirectionLTR,
+		"ar":        DirectionRTL,
+		"he":        DirectionRTL,
+		"fa-IR":     DirectionRTL,
+		"fr":        DirectionLTR,
+		"az-Arab":   DirectionRTL,
+		"ks-Deva":   DirectionLTR,
+		"x-custom":  "",
+		"en_legacy": "",
+	}, directions)
+	assert.Equal(t, "English", names["en"])
+	assert.Equal(t, "français", names["fr"])
+	assert.Equal(t, "العربية", names["ar"])
+	assert.Empty(t, names["x-custom"])
+}
+
+func TestTranslatableService_GetLocalesPage(t *testing.T) {
+	service := NewTranslatableService(nil, &Config{
+		SupportedLocales: []string{"en", "fr", "es", "de"},
+		DefaultLocale:    "en",
+	})
+
+	tests := []struct {
+		name     string
+		limit    int
+		offset   int
+		expected []string
+	}{
+		{name: "first page", limit: 2, offset: 0, expected: []string{"en", "fr"}},
+		{name: "second page", limit: 2, offset: 2, expected: []string{"es", "de"}},
+		{name: "partial last page", limit: 3, offset: 3, expected: []string{"de"}},
+		{name: "offset past end", limit: 2, offset: 10, expected: []string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := service.GetLocalesPage(tt.limit, tt.offset)
+
+			locales := make([]string, 0, len(resp.Locales))
+			for _, info := range resp.Locales {
+				locales = append(locales, info.Locale)
+			}
+			assert.Equal(t, tt.expected, locales)
+			assert.Equal(t, 4, resp.Total)
+		})
+	}
+}
+
 func TestTranslatableService_DefaultLocale(t *testing.T) {
 	service := NewTranslatableService(nil, &Config{
 		SupportedLocales: []string{"en", "fr"},
@@ -116,3 +186,727 @@ func TestTranslatableService_TargetLocales(t *testing.T) {
 		})
 	}
 }
+
+func TestTranslatableService_Publish(t *testing.T) {
+	id := uuid.New()
+	var execSQL string
+	var execArgs []interface{}
+
+	db := &mocks.MockDatabase{
+		ExecFunc: func(ctx context.Context, query string, args ...interface{}) (database.Result, error) {
+			execSQL = query
+			execArgs = args
+			return mocks.NewMockResult(1), nil
+		},
+		QueryRowFunc: func(ctx context.Context, query string, args ...interface{}) database.Row {
+			return &mocks.MockRow{ScanFunc: func(dest ...interface{}) error {
+				*dest[0].(*uuid.UUID) = id
+				*dest[5].(*string) = "Bonjour"
+				snapshot := "Bonjour"
+				*dest[6].(**string) = &snapshot
+				now := time.Now()
+				*dest[7].(**time.Time) = &now
+				return nil
+			}}
+		},
+	}
+
+	service := NewTranslatableService(db, &Config{})
+	published, err := service.Publish(context.Background(), id)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "UPDATE translations SET status = $1, published_content = content, published_at = $2, reviewed_by = NULL, reviewed_at = NULL WHERE id = $3 AND deleted_at IS NULL AND status IN ($4, $5, $6)", execSQL)
+	assert.Equal(t, []interface{}{StatusPublished, id, StatusDraft, StatusReview, StatusPublished}, []interface{}{execArgs[0], execArgs[2], execArgs[3], execArgs[4], execArgs[5]})
+	assert.Equal(t, id, published.ID)
+	assert.NotNil(t, published.PublishedAt)
+	assert.Equal(t, "Bonjour", *published.PublishedContent)
+}
+
+func TestTranslatableService_Publish_NotFound(t *testing.T) {
+	db := &mocks.MockDatabase{
+		ExecFunc: func(ctx context.Context, query string, args ...interface{}) (database.Result, error) {
+			return mocks.NewMockResult(0), nil
+		},
+	}
+
+	service := NewTranslatableService(db, &Config{})
+	_, err := service.Publish(context.Background(), uuid.New())
+
+	assert.ErrorIs(t, err, ErrTranslationNotFound)
+}
+
+func TestTranslatableService_PurgeExpired(t *testing.T) {
+	ids := []uuid.UUID{uuid.New(), uuid.New(), uuid.New()}
+	var selectSQL, execSQL string
+	var execArgs []interface{}
+	tx := &mocks.MockTx{
+		QueryFunc: func(ctx context.Context, query string, args ...interface{}) (database.Rows, error) {
+			selectSQL = query
+			rows := mocks.NewMockRows(len(ids))
+			rows.ScanFunc = func(row int, dest ...interface{}) error {
+				*dest[0].(*uuid.UUID) = ids[row]
+				*dest[3].(*string) = "notification"
+				*dest[4].(*string) = "fr"
+				return nil
+			}
+			return rows, nil
+		},
+		ExecFunc: func(ctx context.Context, query string, args ...interface{}) (database.Result, error) {
+			execSQL, execArgs = query, args
+			return mocks.NewMockResult(3), nil
+		},
+	}
+	db := &mocks.MockDatabase{
+		BeginFunc: func(ctx context.Context) (database.Tx, error) { return tx, nil },
+	}
+	secondary := &recordingWriter{}
+	var events []string
+	config := Config{SecondaryWriter: secondary, EventHandler: func(ctx context.Context, event TranslationEvent) {
+		events = append(events, event.Type)
+	}}
+
+	service := NewTranslatableService(db, &config)
+	purged, err := service.PurgeExpired(context.Background())
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3), purged)
+	assert.True(t, tx.Committed)
+	assert.Equal(t, "SELECT "+translatableColumns+" FROM translations WHERE expires_at IS NOT NULL AND expires_at <= $1 FOR UPDATE", selectSQL)
+	assert.Equal(t, "DELETE FROM translations WHERE id IN ($1, $2, $3)", execSQL)
+	assert.Equal(t, []interface{}{ids[0], ids[1], ids[2]}, execArgs)
+	assert.Equal(t, ids, secondary.deleted)
+	assert.Equal(t, []string{EventDeleted, EventDeleted, EventDeleted}, events)
+}
+
+func TestTranslatableService_GetByID_HidesExpired(t *testing.T) {
+	var getSQL string
+	var getArgs []interface{}
+	db := &mocks.MockDatabase{
+		QueryRowFunc: func(ctx context.Context, query string, args ...interface{}) database.Row {
+			getSQL, getArgs = query, args
+			return &mocks.MockRow{ScanFunc: func(dest ...interface{}) error { return nil }}
+		},
+	}
+	id := uuid.New()
+
+	_, err := NewTranslatableService(db, &Config{}).GetByID(context.Background(), id)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "SELECT "+translatableColumns+" FROM translations WHERE id = $1 AND deleted_at IS NULL AND (expires_at IS NULL OR expires_at > $2)", getSQL)
+	assert.Equal(t, id, getArgs[0])
+	assert.WithinDuration(t, time.Now(), getArgs[1].(time.Time), time.Second)
+}
+
+func TestTranslatableService_GetByID_Errors(t *testing.T) {
+	errConnection := errors.New("connection reset")
+	tests := []struct {
+		name    string
+		scanErr error
+		wantErr error
+	}{
+		{name: "missing", scanErr: sql.ErrNoRows, wantErr: ErrTranslationNotFound},
+		{name: "database failure", scanErr: errConnection, wantErr: errConnection},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := &mocks.MockDatabase{
+				QueryRowFunc: func(ctx context.Context, query string, args ...interface{}) database.Row {
+					return &mocks.MockRow{ScanFunc: func(dest ...interface{}) error { return tt.scanErr }}
+				},
+			}
+
+			_, err := NewTranslatableService(db, &Config{}).GetByID(context.Background(), uuid.New())
+
+			assert.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestTranslatableService_WithTx(t *testing.T) {
+	errFailed := errors.New("failed")
+	tests := []struct {
+		name       string
+		fn         func(tx database.Tx) error
+		err        error
+		committed  bool
+		rolledBack bool
+	}{
+		{name: "commits on success", fn: func(tx database.Tx) error { return nil }, committed: true},
+		{name: "rolls back on error", fn: func(tx database.Tx) error { return errFailed }, err: errFailed, rolledBack: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tx := &mocks.MockTx{}
+			db := &mocks.MockDatabase{
+				BeginFunc: func(ctx context.Context) (database.Tx, error) { return tx, nil },
+			}
+
+			err := NewTranslatableService(db, &Config{}).WithTx(context.Background(), tt.fn)
+
+			assert.ErrorIs(t, err, tt.err)
+			assert.Equal(t, tt.committed, tx.Committed)
+			assert.Equal(t, tt.rolledBack, tx.RolledBack)
+		})
+	}
+}
+
+func TestTranslatableService_WithTx_RollsBackOnPanic(t *testing.T) {
+	tx := &mocks.MockTx{}
+	db := &mocks.MockDatabase{
+		BeginFunc: func(ctx context.Context) (database.Tx, error) { return tx, nil },
+	}
+
+	assert.Panics(t, func() {
+		_ = NewTranslatableService(db, &Config{}).WithTx(context.Background(), func(tx database.Tx) error {
+			panic("boom")
+		})
+	})
+	assert.True(t, tx.RolledBack)
+}
+
+func TestTranslatableService_WithTx_BeginError(t *testing.T) {
+	called := false
+	err := NewTranslatableService(&mocks.MockDatabase{}, &Config{}).WithTx(context.Background(), func(tx database.Tx) error {
+		called = true
+		return nil
+	})
+
+	assert.Error(t, err)
+	assert.False(t, called)
+}
+
+func TestTranslatableService_CloneEntity(t *testing.T) {
+	from, to := uuid.New(), uuid.New()
+	userID := uuid.New()
+	var inserted [][]interface{}
+
+	tx := &mocks.MockTx{
+		QueryFunc: func(ctx context.Context, query string, args ...interface{}) (database.Rows, error) {
+			if strings.HasPrefix(query, "SELECT locale") {
+				rows := mocks.NewMockRows(1)
+				rows.ScanFunc = func(row int, dest ...interface{}) error {
+					*dest[0].(*string) = "fr"
+					return nil
+				}
+				return rows, nil
+			}
+			rows := mocks.NewMockRows(2)
+			rows.ScanFunc = func(row int, dest ...interface{}) error {
+				*dest[0].(*uuid.UUID) = uuid.New()
+				*dest[2].(*uuid.UUID) = from
+				*dest[3].(*string) = "posts"
+				*dest[4].(*string) = []string{"fr", "de"}[row]
+				*dest[5].(*string) = []string{"Bonjour", "Hallo"}[row]
+				return nil
+			}
+			return rows, nil
+		},
+		ExecFunc: func(ctx context.Context, query string, args ...interface{}) (database.Result, error) {
+			inserted = append(inserted, args)
+			return mocks.NewMockResult(1), nil
+		},
+	}
+	db := &mocks.MockDatabase{
+		BeginFunc: func(ctx context.Context) (database.Tx, error) { return tx, nil },
+	}
+
+	service := NewTranslatableService(db, &Config{})
+	created, err := service.CloneEntity(context.Background(), "posts", from, to, &userID)
+
+	assert.NoError(t, err)
+	assert.True(t, tx.Committed)
+	assert.Len(t, created, 1)
+	assert.Len(t, inserted, 1)
+	assert.Equal(t, "de", created[0].Locale)
+	assert.Equal(t, "Hallo", created[0].Content)
+	assert.Equal(t, to, created[0].TranslatableID)
+	assert.Equal(t, &userID, created[0].UserID)
+}
+
+func TestTranslatableService_CloneEntity_RollsBackOnError(t *testing.T) {
+	tx := &mocks.MockTx{
+		QueryFunc: func(ctx context.Context, query string, args ...interface{}) (database.Rows, error) {
+			if strings.HasPrefix(query, "SELECT locale") {
+				return mocks.NewMockRows(0), nil
+			}
+			return mocks.NewMockRows(1), nil
+		},
+		ExecFunc: func(ctx context.Context, query string, args ...interface{}) (database.Result, error) {
+			return nil, errors.New("insert failed")
+		},
+	}
+	db := &mocks.MockDatabase{
+		BeginFunc: func(ctx context.Context) (database.Tx, error) { return tx, nil },
+	}
+
+	service := NewTranslatableService(db, &Config{})
+	_, err := service.CloneEntity(context.Background(), "posts", uuid.New(), uuid.New(), nil)
+
+	assert.Error(t, err)
+	assert.False(t, tx.Committed)
+	assert.True(t, tx.RolledBack)
+}
+
+func TestTranslatableService_CloneEntity_SameEntity(t *testing.T) {
+	id := uuid.New()
+	service := NewTranslatableService(&mocks.MockDatabase{}, &Config{})
+
+	_, err := service.CloneEntity(context.Background(), "posts", id, id, nil)
+
+	assert.ErrorIs(t, err, ErrSameEntity)
+}
+
+func TestTranslatableService_Resolve(t *testing.T) {
+	entityID := uuid.New()
+	available := []string{"fr", "en"}
+
+	tests := []struct {
+		name     string
+		strategy string
+		chain    []string
+		expected string
+		wantErr  error
+	}{
+		{name: "first chain match wins", strategy: FallbackChainThenDefault, chain: []string{"fr-CA", "fr"}, expected: "fr"},
+		{name: "chain then default", strategy: FallbackChainThenDefault, chain: []string{"de"}, expected: "en"},
+		{name: "chain only misses", strategy: FallbackChainOnly, chain: []string{"de"}, wantErr: ErrTranslationNotFound},
+		{name: "chain only matches", strategy: FallbackChainOnly, chain: []string{"de", "fr"}, expected: "fr"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var querySQL string
+			db := &mocks.MockDatabase{
+				QueryFunc: func(ctx context.Context, query string, args ...interface{}) (database.Rows, error) {
+					querySQL = query
+					rows := mocks.NewMockRows(len(available))
+					rows.ScanFunc = func(row int, dest ...interface{}) error {
+						*dest[2].(*uuid.UUID) = entityID
+						*dest[4].(*string) = available[row]
+						return nil
+					}
+					return rows, nil
+				},
+			}
+
+			service := NewTranslatableService(db, &Config{DefaultLocale: "en", FallbackStrategy: tt.strategy})
+			resolved, err := service.Resolve(context.Background(), "posts", entityID, tt.chain)
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, resolved.Locale)
+			assert.Contains(t, querySQL, "locale IN")
+		})
+	}
+}
+
+func TestTranslatableService_GetByIDs(t *testing.T) {
+	first, second := uuid.New(), uuid.New()
+	var querySQL string
+	var queryArgs []interface{}
+	db := &mocks.MockDatabase{
+		QueryFunc: func(ctx context.Context, query string, args ...interface{}) (database.Rows, error) {
+			querySQL = query
+			queryArgs = args
+			return mocks.NewMockRows(0), nil
+		},
+	}
+
+	service := NewTranslatableService(db, &Config{DefaultLocale: "en"})
+	translations, err := service.GetByIDs(context.Background(), []uuid.UUID{first, second})
+
+	assert.NoError(t, err)
+	assert.Empty(t, translations)
+	assert.Contains(t, querySQL, "id IN ($1, $2)")
+	assert.Equal(t, []interface{}{first, second}, queryArgs[:2])
+}
+
+func TestTranslatableService_NormalizeTypes(t *testing.T) {
+	stored := []string{"posts", "Posts", "POSTS", "legacy"}
+	var updates [][]interface{}
+
+	db := &mocks.MockDatabase{
+		QueryFunc: func(ctx context.Context, query string, args ...interface{}) (database.Rows, error) {
+			rows := mocks.NewMockRows(len(stored))
+			rows.ScanFunc = func(row int, dest ...interface{}) error {
+				*dest[0].(*string) = stored[row]
+				return nil
+			}
+			return rows, nil
+		},
+		ExecFunc: func(ctx context.Context, query string, args ...interface{}) (database.Result, error) {
+			assert.Equal(t, "UPDATE translations SET translatable = $1 WHERE translatable = $2", query)
+			updates = append(updates, args)
+			return mocks.NewMockResult(2), nil
+		},
+	}
+
+	service := NewTranslatableService(db, &Config{AllowedTypes: []string{"posts"}})
+	updated, err := service.NormalizeTypes(context.Background())
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(4), updated)
+	assert.Equal(t, [][]interface{}{{"posts", "Posts"}, {"posts", "POSTS"}}, updates)
+}
+
+func TestTranslatableService_Stale(t *testing.T) {
+	var countSQL, listSQL string
+	var listArgs []interface{}
+	db := &mocks.MockDatabase{
+		QueryRowFunc: func(ctx context.Context, query string, args ...interface{}) database.Row {
+			countSQL = query
+			return &mocks.MockRow{ScanFunc: func(dest ...interface{}) error {
+				*dest[0].(*int) = 1
+				return nil
+			}}
+		},
+		QueryFunc: func(ctx context.Context, query string, args ...interface{}) (database.Rows, error) {
+			listSQL = query
+			listArgs = args
+			rows := mocks.NewMockRows(1)
+			rows.ScanFunc = func(row int, dest ...interface{}) error {
+				*dest[3].(*string) = "post"
+				*dest[4].(*string) = "fr"
+				return nil
+			}
+			return rows, nil
+		},
+	}
+	config := DefaultConfig()
+	service := NewTranslatableService(db, &config)
+
+	stale, total, err := service.Stale(context.Background(), "post", 20, 40)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, total)
+	assert.Len(t, stale, 1)
+	assert.Equal(t, "fr", stale[0].Locale)
+	assert.True(t, strings.HasPrefix(countSQL, "SELECT COUNT(*) FROM translations t JOIN translations src"))
+	assert.Contains(t, listSQL, "t.source_checksum <> src.source_checksum")
+	assert.Contains(t, listSQL, "AND t.translatable = $4")
+	assert.True(t, strings.HasSuffix(listSQL, "LIMIT 20 OFFSET 40"))
+	assert.Equal(t, "en", listArgs[0])
+	assert.Equal(t, "post", listArgs[3])
+}
+
+func TestTranslatableService_Untranslated(t *testing.T) {
+	var listSQL string
+	var listArgs []interface{}
+	db := &mocks.MockDatabase{
+		QueryRowFunc: func(ctx context.Context, query string, args ...interface{}) database.Row {
+			return &mocks.MockRow{ScanFunc: func(dest ...interface{}) error {
+				*dest[0].(*int) = 1
+				return nil
+			}}
+		},
+		QueryFunc: func(ctx context.Context, query string, args ...interface{}) (database.Rows, error) {
+			listSQL = query
+			listArgs = args
+			rows := mocks.NewMockRows(1)
+			rows.ScanFunc = func(row int, dest ...interface{}) error {
+				*dest[3].(*string) = "post"
+				*dest[4].(*string) = "fr"
+				*dest[5].(*string) = "Hello"
+				return nil
+			}
+			return rows, nil
+		},
+	}
+	config := DefaultConfig()
+	service := NewTranslatableService(db, &config)
+
+	untranslated, total, err := service.Untranslated(context.Background(), "", 20, 0)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, total)
+	assert.Len(t, untranslated, 1)
+	assert.Equal(t, "Hello", untranslated[0].Content)
+	assert.Contains(t, listSQL, "src.translatable_id = t.translatable_id")
+	assert.Contains(t, listSQL, "t.content = src.content")
+	assert.NotContains(t, listSQL, "t.translatable = $4")
+	assert.Equal(t, []interface{}{"en", "en"}, listArgs[:2])
+}
+
+func TestTranslatableService_Upsert(t *testing.T) {
+	owner := uuid.New()
+	existingID := uuid.New()
+
+	tests := []struct {
+		name        string
+		existing    bool
+		userID      uuid.UUID
+		wantCreated bool
+		wantErr     error
+	}{
+		{name: "creates missing translation", wantCreated: true, userID: owner},
+		{name: "updates existing translation", existing: true, userID: owner},
+		{name: "rejects another owner", existing: true, userID: uuid.New(), wantErr: ErrForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var readSQL, execSQL string
+			var written []interface{}
+			tx := &mocks.MockTx{
+				QueryFunc: func(ctx context.Context, query string, args ...interface{}) (database.Rows, error) {
+					if written == nil {
+						readSQL = query
+					}
+					if !tt.existing && written == nil {
+						return mocks.NewMockRows(0), nil
+					}
+					rows := mocks.NewMockRows(1)
+					rows.ScanFunc = func(row int, dest ...interface{}) error {
+						*dest[0].(*uuid.UUID) = existingID
+						*dest[1].(**uuid.UUID) = &owner
+						*dest[16].(*int) = 3
+						*dest[18].(*string) = StatusPublished
+						if written != nil {
+							// The stored row: the id and status survive the
+							// write, the content is the one written.
+							if !tt.existing {
+								*dest[0].(*uuid.UUID) = written[0].(uuid.UUID)
+							}
+							*dest[5].(*string) = written[5].(string)
+							*dest[12].(**time.Time) = new(time.Now())
+							*dest[16].(*int) = 4
+						}
+						return nil
+					}
+					return rows, nil
+				},
+				ExecFunc: func(ctx context.Context, query string, args ...interface{}) (database.Result, error) {
+					execSQL, written = query, args
+					return mocks.NewMockResult(1), nil
+				},
+			}
+			db := &mocks.MockDatabase{
+				BeginFunc: func(ctx context.Context) (database.Tx, error) { return tx, nil },
+			}
+			config := DefaultConfig()
+			service := NewTranslatableService(db, &config)
+
+			userID := tt.userID
+			model := &Translatable{ID: uuid.New(), UserID: &userID, TranslatableID: uuid.New(), Translatable: "post", Locale: "fr", Content: "Bonjour"}
+			upserted, created, err := service.Upsert(context.Background(), model)
+
+			assert.True(t, strings.HasSuffix(readSQL, " FOR UPDATE"), "the stored translation stays locked until the write")
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.Empty(t, execSQL)
+				assert.True(t, tx.RolledBack)
+				return
+			}
+			assert.NoError(t, err)
+			assert.True(t, tx.Committed)
+			assert.Equal(t, tt.wantCreated, created)
+			assert.Contains(t, execSQL, "ON CONFLICT (translatable_id, translatable, locale, tenant_id) DO UPDATE SET content = excluded.content")
+			if tt.existing {
+				assert.Equal(t, existingID, upserted.ID)
+			} else {
+				assert.Equal(t, model.ID, upserted.ID)
+			}
+			assert.Equal(t, "Bonjour", upserted.Content)
+			assert.NotNil(t, upserted.UpdatedAt)
+			assert.Equal(t, 4, upserted.Version, "the response is the row as stored")
+			assert.Equal(t, StatusPublished, upserted.Status)
+		})
+	}
+}
+
+func TestTranslatableService_ReplaceLocales(t *testing.T) {
+	entityID := uuid.New()
+	tests := []struct {
+		name       string
+		mode       string
+		expected   []string
+		statements []string
+	}{
+		{
+			name:       "merge keeps absent locales",
+			mode:       ReplaceModeMerge,
+			expected:   []string{"de", "en", "es", "fr"},
+			statements: []string{"UPDATE", "INSERT"},
+		},
+		{
+			name:       "replace deletes absent locales",
+			mode:       ReplaceModeReplace,
+			expected:   []string{"es", "fr"},
+			statements: []string{"UPDATE", "INSERT", "DELETE", "DELETE"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var statements []string
+			tx := &mocks.MockTx{
+				QueryFunc: func(ctx context.Context, query string, args ...interface{}) (database.Rows, error) {
+					rows := mocks.NewMockRows(3)
+					rows.ScanFunc = func(row int, dest ...interface{}) error {
+						*dest[0].(*uuid.UUID) = uuid.New()
+						*dest[2].(*uuid.UUID) = entityID
+						*dest[3].(*string) = "post"
+						*dest[4].(*string) = []string{"en", "fr", "de"}[row]
+						*dest[5].(*string) = []string{"Hello", "Salut", "Hallo"}[row]
+						return nil
+					}
+					return rows, nil
+				},
+				ExecFunc: func(ctx context.Context, query string, args ...interface{}) (database.Result, error) {
+					statement, _, _ := strings.Cut(query, " ")
+					statements = append(statements, statement)
+					return mocks.NewMockResult(1), nil
+				},
+			}
+			db := &mocks.MockDatabase{
+				BeginFunc: func(ctx context.Context) (database.Tx, error) { return tx, nil },
+			}
+
+			service := NewTranslatableService(db, &Config{})
+			result, err := service.ReplaceLocales(context.Background(), "post", entityID, []Translatable{
+				{ID: uuid.New(), TranslatableID: entityID, Translatable: "post", Locale: "fr", Content: "Bonjour"},
+				{ID: uuid.New(), TranslatableID: entityID, Translatable: "post", Locale: "es", Content: "Hola"},
+			}, tt.mode, nil)
+
+			assert.NoError(t, err)
+			assert.True(t, tx.Committed)
+			locales := make([]string, len(result))
+			for i, translation := range result {
+				locales[i] = translation.Locale
+			}
+			assert.Equal(t, tt.expected, locales)
+			assert.ElementsMatch(t, tt.statements, statements)
+			for _, translation := range result {
+				if translation.Locale == "fr" {
+					assert.Equal(t, "Bonjour", translation.Content)
+				}
+			}
+		})
+	}
+}
+
+func TestTranslatableService_ReplaceLocales_RollsBackMidBatch(t *testing.T) {
+	var statements int
+	tx := &mocks.MockTx{
+		QueryFunc: func(ctx context.Context, query string, args ...interface{}) (database.Rows, error) {
+			return mocks.NewMockRows(0), nil
+		},
+		ExecFunc: func(ctx context.Context, query string, args ...interface{}) (database.Result, error) {
+			statements++
+			if statements == 2 {
+				return nil, errors.New("insert failed")
+			}
+			return mocks.NewMockResult(1), nil
+		},
+	}
+	db := &mocks.MockDatabase{
+		BeginFunc: func(ctx context.Context) (database.Tx, error) { return tx, nil },
+	}
+	var events []string
+	config := &Config{EventHandler: func(ctx context.Context, event TranslationEvent) { events = append(events, event.Type) }}
+
+	entityID := uuid.New()
+	_, err := NewTranslatableService(db, config).ReplaceLocales(context.Background(), "post", entityID, []Translatable{
+		{ID: uuid.New(), TranslatableID: entityID, Translatable: "post", Locale: "fr", Content: "Bonjour"},
+		{ID: uuid.New(), TranslatableID: entityID, Translatable: "post", Locale: "es", Content: "Hola"},
+		{ID: uuid.New(), TranslatableID: entityID, Translatable: "post", Locale: "de", Content: "Hallo"},
+	}, ReplaceModeMerge, nil)
+
+	assert.Error(t, err)
+	assert.Equal(t, 2, statements)
+	assert.False(t, tx.Committed)
+	assert.True(t, tx.RolledBack)
+	assert.Empty(t, events)
+}
+
+func TestTranslatableService_CopyLocale(t *testing.T) {
+	translated, deleted, missing, removed := uuid.New(), uuid.New(), uuid.New(), uuid.New()
+	deletedAt := time.Now().Add(-time.Hour)
+	stored := map[string][]Translatable{
+		"en": {
+			{TranslatableID: translated, Content: "Hello"},
+			{TranslatableID: deleted, Content: "Colour"},
+			{TranslatableID: missing, Content: "Goodbye"},
+			{TranslatableID: removed, Content: "Gone", DeletedAt: &deletedAt},
+		},
+		"en-GB": {
+			{TranslatableID: translated, Content: "Hiya"},
+			{TranslatableID: deleted, Content: "Color", DeletedAt: &deletedAt},
+		},
+	}
+
+	tests := []struct {
+		name       string
+		overwrite  bool
+		copied     int
+		statements []string
+	}{
+		{name: "missing locales only", copied: 2, statements: []string{"UPDATE", "INSERT"}},
+		{name: "overwrite", overwrite: true, copied: 3, statements: []string{"UPDATE", "UPDATE", "INSERT"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var statements []string
+			var contents []interface{}
+			tx := &mocks.MockTx{
+				QueryFunc: func(ctx context.Context, query string, args ...interface{}) (database.Rows, error) {
+					locale := args[1].(string)
+					rows := mocks.NewMockRows(len(stored[locale]))
+					rows.ScanFunc = func(row int, dest ...interface{}) error {
+						source := stored[locale][row]
+						*dest[0].(*uuid.UUID) = uuid.New()
+						*dest[2].(*uuid.UUID) = source.TranslatableID
+						*dest[3].(*string) = "post"
+						*dest[4].(*string) = locale
+						*dest[5].(*string) = source.Content
+						*dest[14].(**time.Time) = source.DeletedAt
+						return nil
+					}
+					return rows, nil
+				},
+				ExecFunc: func(ctx context.Context, query string, args ...interface{}) (database.Result, error) {
+					statement, _, _ := strings.Cut(query, " ")
+					statements = append(statements, statement)
+					if statement == "INSERT" {
+						assert.Contains(t, args, "en-GB")
+						contents = append(contents, args[5])
+					} else {
+						contents = append(contents, args[0])
+					}
+					return mocks.NewMockResult(1), nil
+				},
+			}
+			db := &mocks.MockDatabase{
+				BeginFunc: func(ctx context.Context) (database.Tx, error) { return tx, nil },
+			}
+
+			service := NewTranslatableService(db, &Config{})
+			copied, err := service.CopyLocale(context.Background(), "post", "en", "en-GB", tt.overwrite, nil)
+
+			assert.NoError(t, err)
+			assert.True(t, tx.Committed)
+			assert.Equal(t, tt.copied, copied)
+			assert.ElementsMatch(t, tt.statements, statements)
+			assert.NotContains(t, contents, "Gone")
+			if tt.overwrite {
+				assert.Contains(t, contents, "Hello")
+			} else {
+				assert.NotContains(t, contents, "Hello")
+			}
+		})
+	}
+}
+
+func TestTranslatableService_CopyLocale_SameLocale(t *testing.T) {
+	service := NewTranslatableService(&mocks.MockDatabase{}, &Config{})
+
+	_, err := service.CopyLocale(context.Background(), "post", "en", "en", false, nil)
+
+	assert.ErrorIs(t, err, ErrSameLocale)
+}
@@ -0,0 +1,132 @@
+package translatable
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/gofiber/fiber/v3"
+	"github.com/google/uuid"
+	"github.com/nicolasbonnici/gorest/database"
+)
+
+var (
+	ErrSnapshotNotFound = errors.New("snapshot not found or expired")
+	ErrTooManySnapshots = errors.New("too many open snapshots")
+)
+
+// snapshot is an open read transaction that paged queries can be pinned to.
+// Database transactions are not safe for concurrent use, so reads through the
+// same snapshot are serialized.
+type snapshot struct {
+	mu        sync.Mutex
+	tx        database.Tx
+	owner     snapshotOwner
+	expiresAt time.Time
+	timer     *time.Timer
+}
+
+// snapshotOwner is the tenant and user a snapshot was opened by, the only ones
+// its token is good for. Anonymous users have the nil user.
+type snapshotOwner struct {
+	tenant string
+	user   uuid.UUID
+}
+
+// requestSnapshotOwner returns the tenant and user of the request.
+func requestSnapshotOwner(c fiber.Ctx) snapshotOwner {
+	owner := snapshotOwner{tenant: getTenantIDFromContext(c.Context())}
+	if userID := getUserIDFromFiberContext(c); userID != nil {
+		owner.user = *userID
+	}
+	return owner
+}
+
+// snapshotRegistry keeps the open snapshots by token. Both the number of open
+// snapshots and their lifetime are bounded; expired ones are rolled back.
+type snapshotRegistry struct {
+	db  database.Database
+	max int
+	ttl time.Duration
+
+	mu        sync.Mutex
+	snapshots map[string]*snapshot
+}
+
+func newSnapshotRegistry(db database.Database, max int, ttl time.Duration) *snapshotRegistry {
+	return &snapshotRegistry{
+		db:        db,
+		max:       max,
+		ttl:       ttl,
+		snapshots: make(map[string]*snapshot),
+	}
+}
+
+// Open starts a repeatable-read transaction for owner and returns its token.
+// The transaction outlives the request that opened it, so it is not bound to
+// ctx.
+func (r *snapshotRegistry) Open(ctx context.Context, owner snapshotOwner) (string, time.Time, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if len(r.snapshots) >= r.max {
+		return "", time.Time{}, ErrTooManySnapshots
+	}
+
+	tx, err := r.db.Begin(context.Background())
+	if err != nil {
+		return "", time.Time{}, err
+	}
+
+	// MySQL already defaults to REPEATABLE READ and SQLite transactions are
+	// serializable; Postgres needs to be asked before the first query.
+	if r.db.DriverName() == "postgres" {
+		if _, err := tx.Exec(ctx, "SET TRANSACTION ISOLATION LEVEL REPEATABLE READ"); err != nil {
+			_ = tx.Rollback(context.Background())
+			return "", time.Time{}, err
+		}
+	}
+
+	token := uuid.NewString()
+	s := &snapshot{tx: tx, owner: owner, expiresAt: time.Now().Add(r.ttl)}
+	s.timer = time.AfterFunc(r.ttl, func() { _ = r.Close(token, owner) })
+	r.snapshots[token] = s
+
+	return token, s.expiresAt, nil
+}
+
+// Read runs fn within the snapshot identified by token. Snapshots of another
+// owner are not found.
+func (r *snapshotRegistry) Read(token string, owner snapshotOwner, fn func(tx database.Tx) error) error {
+	r.mu.Lock()
+	s, ok := r.snapshots[token]
+	r.mu.Unlock()
+	if !ok || s.owner != owner {
+		return ErrSnapshotNotFound
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return fn(s.tx)
+}
+
+// Close rolls back the snapshot and forgets its token. Snapshots of another
+// owner are not found, and stay open.
+func (r *snapshotRegistry) Close(token string, owner snapshotOwner) error {
+	r.mu.Lock()
+	s, ok := r.snapshots[token]
+	ok = ok && s.owner == owner
+	if ok {
+		delete(r.snapshots, token)
+	}
+	r.mu.Unlock()
+	if !ok {
+		return ErrSnapshotNotFound
+	}
+
+	s.timer.Stop()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.tx.Rollback(context.Background())
+}
@@ -0,0 +1,140 @@
+package translatable
+
+import (
+	"context"
+	"encoding/json"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v3"
+	"github.com/google/uuid"
+	"github.com/nicolasbonnici/gorest-translatable/mocks"
+	"github.com/nicolasbonnici/gorest/database"
+	"github.com/stretchr/testify/assert"
+)
+
+func newSnapshotDB(txs *[]*mocks.MockTx) *mocks.MockDatabase {
+	return &mocks.MockDatabase{
+		BeginFunc: func(ctx context.Context) (database.Tx, error) {
+			tx := &mocks.MockTx{}
+			*txs = append(*txs, tx)
+			return tx, nil
+		},
+	}
+}
+
+func TestSnapshotRegistry_Lifecycle(t *testing.T) {
+	var txs []*mocks.MockTx
+	registry := newSnapshotRegistry(newSnapshotDB(&txs), 1, time.Minute)
+
+	token, expiresAt, err := registry.Open(context.Background(), snapshotOwner{})
+	assert.NoError(t, err)
+	assert.NotEmpty(t, token)
+	assert.True(t, expiresAt.After(time.Now()))
+
+	_, _, err = registry.Open(context.Background(), snapshotOwner{})
+	assert.ErrorIs(t, err, ErrTooManySnapshots)
+
+	var used database.Tx
+	assert.NoError(t, registry.Read(token, snapshotOwner{}, func(tx database.Tx) error {
+		used = tx
+		return nil
+	}))
+	assert.Same(t, txs[0], used)
+
+	assert.NoError(t, registry.Close(token, snapshotOwner{}))
+	assert.True(t, txs[0].RolledBack)
+	assert.ErrorIs(t, registry.Read(token, snapshotOwner{}, func(database.Tx) error { return nil }), ErrSnapshotNotFound)
+	assert.ErrorIs(t, registry.Close(token, snapshotOwner{}), ErrSnapshotNotFound)
+}
+
+func TestSnapshotRegistry_Owner(t *testing.T) {
+	var txs []*mocks.MockTx
+	registry := newSnapshotRegistry(newSnapshotDB(&txs), 1, time.Minute)
+	owner := snapshotOwner{tenant: "A", user: uuid.New()}
+
+	token, _, err := registry.Open(context.Background(), owner)
+	assert.NoError(t, err)
+
+	read := func(database.Tx) error { return nil }
+	for _, other := range []snapshotOwner{{tenant: "B", user: owner.user}, {tenant: "A", user: uuid.New()}, {tenant: "A"}} {
+		assert.ErrorIs(t, registry.Read(token, other, read), ErrSnapshotNotFound)
+		assert.ErrorIs(t, registry.Close(token, other), ErrSnapshotNotFound)
+	}
+	assert.False(t, txs[0].RolledBack, "others cannot close the snapshot")
+	assert.NoError(t, registry.Read(token, owner, read))
+	assert.NoError(t, registry.Close(token, owner))
+}
+
+func TestSnapshotRegistry_Expires(t *testing.T) {
+	var txs []*mocks.MockTx
+	registry := newSnapshotRegistry(newSnapshotDB(&txs), 1, 10*time.Millisecond)
+
+	token, _, err := registry.Open(context.Background(), snapshotOwner{})
+	assert.NoError(t, err)
+
+	assert.Eventually(t, func() bool {
+		return registry.Read(token, snapshotOwner{}, func(database.Tx) error { return nil }) == ErrSnapshotNotFound
+	}, time.Second, 5*time.Millisecond)
+
+	_, _, err = registry.Open(context.Background(), snapshotOwner{})
+	assert.NoError(t, err, "an expired snapshot frees its slot")
+}
+
+func TestTranslatableResource_GetAllInSnapshot(t *testing.T) {
+	var txs []*mocks.MockTx
+	db := newSnapshotDB(&txs)
+	config := DefaultConfig()
+	app, resource := setupTestApp(db, &config)
+	resource.snapshots = newSnapshotRegistry(db, config.MaxSnapshots, config.SnapshotTTL)
+	app.Get("/translations/snapshot", resource.OpenSnapshot)
+	app.Get("/translations", resource.GetAll)
+
+	resp, err := app.Test(httptest.NewRequest(fiber.MethodGet, "/translations/snapshot", nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var opened SnapshotResponseDTO
+	assert.Equal(t, fiber.StatusCreated, resp.StatusCode)
+	assert.NoError(t, json.NewDecoder(resp.Body).Decode(&opened))
+
+	var selects []string
+	txs[0].QueryRowFunc = func(ctx context.Context, query string, args ...interface{}) database.Row {
+		return &mocks.MockRow{ScanFunc: func(dest ...interface{}) error {
+			*dest[0].(*int) = 1
+			return nil
+		}}
+	}
+	txs[0].QueryFunc = func(ctx context.Context, query string, args ...interface{}) (database.Rows, error) {
+		selects = append(selects, query)
+		rows := mocks.NewMockRows(1)
+		rows.ScanFunc = func(row int, dest ...interface{}) error {
+			*dest[0].(*uuid.UUID) = uuid.New()
+			*dest[4].(*string) = "fr"
+			return nil
+		}
+		return rows, nil
+	}
+
+	resp, err = app.Test(httptest.NewRequest(fiber.MethodGet, "/translations?snapshot="+opened.Token+"&locale=fr&limit=5", nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var body struct {
+		TotalItems int                       `json:"hydra:totalItems"`
+		Member     []TranslatableResponseDTO `json:"hydra:member"`
+	}
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+	assert.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+	assert.Equal(t, 1, body.TotalItems)
+	assert.Len(t, body.Member, 1)
+	assert.Len(t, selects, 1)
+	assert.Contains(t, selects[0], "ORDER BY id ASC LIMIT 5")
+
+	resp, err = app.Test(httptest.NewRequest(fiber.MethodGet, "/translations?snapshot=unknown", nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, fiber.StatusNotFound, resp.StatusCode)
+}
@@ -0,0 +1,55 @@
+package translatable
+
+import (
+	"net/url"
+	"slices"
+	"strings"
+
+	"github.com/nicolasbonnici/gorest/crud"
+	"github.com/nicolasbonnici/gorest/query"
+)
+
+const (
+	SortAsc  = "asc"
+	SortDesc = "desc"
+)
+
+var (
+	defaultSortableColumns = []string{"created_at", "updated_at", "published_at", "expires_at", "translatable", "locale"}
+	sortDirections         = []string{SortAsc, SortDesc}
+
+	// defaultSort is the collection order when neither ?sort= nor ?order[...]
+	// is given.
+	defaultSort = crud.OrderByClause{Column: "created_at", Direction: query.DESC}
+)
+
+// sortClause reads ?sort= and ?order= into an ORDER BY clause. sort must be one
+// of SortableColumns and is mapped to its column, so the value itself never
+// reaches the SQL; order is asc or desc and applies to created_at when sort is
+// not given. It returns nil when neither is set.
+func (c *Config) sortClause(params url.Values) (*crud.OrderByClause, error) {
+	field, direction := params.Get("sort"), strings.ToLower(params.Get("order"))
+	if field == "" && direction == "" {
+		return nil, nil
+	}
+
+	clause := defaultSort
+	if field != "" {
+		column, ok := translatableFieldMap[field]
+		if !ok || !slices.Contains(c.SortableColumns, field) {
+			return nil, &AllowedValuesError{Message: "sort is not supported", Allowed: c.SortableColumns}
+		}
+		clause = crud.OrderByClause{Column: column, Direction: query.ASC}
+	}
+
+	switch direction {
+	case "":
+	case SortAsc:
+		clause.Direction = query.ASC
+	case SortDesc:
+		clause.Direction = query.DESC
+	default:
+		return nil, &AllowedValuesError{Message: "order must be asc or desc", Allowed: sortDirections}
+	}
+	return &clause, nil
+}
@@ -0,0 +1,96 @@
+package translatable
+
+import (
+	"context"
+	"encoding/json"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/gofiber/fiber/v3"
+	"github.com/nicolasbonnici/gorest-translatable/mocks"
+	"github.com/nicolasbonnici/gorest/crud"
+	"github.com/nicolasbonnici/gorest/database"
+	"github.com/nicolasbonnici/gorest/query"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConfig_SortClause(t *testing.T) {
+	config := DefaultConfig()
+	tests := []struct {
+		name     string
+		query    string
+		expected *crud.OrderByClause
+		wantErr  string
+	}{
+		{name: "none", query: ""},
+		{name: "field and order", query: "sort=updated_at&order=asc", expected: &crud.OrderByClause{Column: "updated_at", Direction: query.ASC}},
+		{name: "field defaults to ascending", query: "sort=locale", expected: &crud.OrderByClause{Column: "locale", Direction: query.ASC}},
+		{name: "order alone applies to created_at", query: "order=ASC", expected: &crud.OrderByClause{Column: "created_at", Direction: query.ASC}},
+		{name: "injection", query: "sort=" + url.QueryEscape("content; DROP TABLE translations"), wantErr: "sort is not supported"},
+		{name: "field not sortable", query: "sort=content", wantErr: "sort is not supported"},
+		{name: "invalid order", query: "sort=updated_at&order=sideways", wantErr: "order must be asc or desc"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			params, _ := url.ParseQuery(tt.query)
+			clause, err := config.sortClause(params)
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, clause)
+		})
+	}
+}
+
+func TestTranslatableResource_GetAll_Sort(t *testing.T) {
+	var listSQL string
+	db := &mocks.MockDatabase{
+		QueryFunc: func(ctx context.Context, query string, args ...interface{}) (database.Rows, error) {
+			listSQL = query
+			return mocks.NewMockRows(0), nil
+		},
+		QueryRowFunc: func(ctx context.Context, query string, args ...interface{}) database.Row {
+			return &mocks.MockRow{ScanFunc: func(dest ...interface{}) error {
+				*dest[0].(*int) = 0
+				return nil
+			}}
+		},
+	}
+	config := DefaultConfig()
+	app, resource := setupTestApp(db, &config)
+	app.Get("/translations", resource.GetAll)
+
+	tests := []struct {
+		target  string
+		orderBy string
+	}{
+		{target: "/translations", orderBy: "ORDER BY created_at DESC"},
+		{target: "/translations?sort=updated_at&order=asc", orderBy: "ORDER BY updated_at ASC"},
+		{target: "/translations?sort=locale&order[created_at]=desc", orderBy: "ORDER BY locale ASC, created_at DESC"},
+	}
+	for _, tt := range tests {
+		listSQL = ""
+		resp, err := app.Test(httptest.NewRequest(fiber.MethodGet, tt.target, nil))
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.Equal(t, fiber.StatusOK, resp.StatusCode, tt.target)
+		assert.Contains(t, listSQL, tt.orderBy, tt.target)
+	}
+
+	listSQL = ""
+	resp, err := app.Test(httptest.NewRequest(fiber.MethodGet, "/translations?sort="+url.QueryEscape("content; DROP TABLE translations"), nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var body ProblemDetails
+	assert.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+	assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+	assert.Equal(t, "sort is not supported", body.Detail)
+	assert.Equal(t, config.SortableColumns, body.Allowed)
+	assert.Empty(t, listSQL)
+}
@@ -0,0 +1,80 @@
+package translatable
+
+import (
+	"errors"
+
+	"github.com/gofiber/fiber/v3"
+)
+
+// Editorial statuses of a translation. New translations start in
+// Config.DefaultStatus; POST /translations/:id/review, /publish and
+// /unpublish move them along.
+const (
+	StatusDraft     = "draft"
+	StatusReview    = "review"
+	StatusPublished = "published"
+)
+
+var ErrInvalidTransition = errors.New("translation status does not allow this transition")
+
+// statusesBefore lists the statuses a translation can move to status from:
+// drafts go to review, drafts and reviewed translations are published, unless
+// Config.RequireReview holds drafts back, and published translations are
+// unpublished back to draft. Publishing again refreshes the live snapshot.
+func (c *Config) statusesBefore(status string) []string {
+	switch status {
+	case StatusReview:
+		return []string{StatusDraft}
+	case StatusPublished:
+		if c.RequireReview {
+			return []string{StatusReview, StatusPublished}
+		}
+		return []string{StatusDraft, StatusReview, StatusPublished}
+	case StatusDraft:
+		return []string{StatusPublished}
+	}
+	return nil
+}
+
+func isStatus(status string) bool {
+	switch status {
+	case StatusDraft, StatusReview, StatusPublished:
+		return true
+	}
+	return false
+}
+
+// translationStatus returns the status of t, rows written before statuses
+// existed being drafts.
+func translationStatus(t *Translatable) string {
+	if t.Status == "" {
+		return StatusDraft
+	}
+	return t.Status
+}
+
+func errStatusNotAllowed() *AllowedValuesError {
+	return &AllowedValuesError{Message: "status is not valid", Code: CodeInvalidStatus, Allowed: []string{StatusDraft, StatusReview, StatusPublished}}
+}
+
+func errInvalidTransition(from, to string) *ProblemError {
+	err := newProblemError(fiber.StatusConflict, CodeInvalidTransition, "cannot move a translation from "+from+" to "+to)
+	err.Params = map[string]string{"from": from, "to": to}
+	return err
+}
+
+// applyDefaultStatus restricts a collection read without ?status= to
+// Config.DefaultStatusFilter, and rejects unknown statuses.
+func applyDefaultStatus(c fiber.Ctx, config *Config) *AllowedValuesError {
+	args := c.Request().URI().QueryArgs()
+	values := append(args.PeekMulti("status"), args.PeekMulti("status[]")...)
+	for _, value := range values {
+		if !isStatus(string(value)) {
+			return errStatusNotAllowed()
+		}
+	}
+	if len(values) == 0 && config.DefaultStatusFilter != "" {
+		args.Set("status", config.DefaultStatusFilter)
+	}
+	return nil
+}
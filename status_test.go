@@ -0,0 +1,224 @@
+package translatable
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http/httptest"
+	"slices"
+	"testing"
+
+	"github.com/gofiber/fiber/v3"
+	"github.com/google/uuid"
+	"github.com/nicolasbonnici/gorest-translatable/mocks"
+	authcontext "github.com/nicolasbonnici/gorest/auth/context"
+	"github.com/nicolasbonnici/gorest/database"
+	"github.com/stretchr/testify/assert"
+)
+
+// statusDatabase holds one translation in status and applies the guarded
+// transition updates to it.
+func statusDatabase(id uuid.UUID, status *string) *mocks.MockDatabase {
+	return &mocks.MockDatabase{
+		QueryRowFunc: func(ctx context.Context, query string, args ...interface{}) database.Row {
+			return &mocks.MockRow{ScanFunc: func(dest ...interface{}) error {
+				*dest[0].(*uuid.UUID) = id
+				*dest[18].(*string) = *status
+				return nil
+			}}
+		},
+		ExecFunc: func(ctx context.Context, query string, args ...interface{}) (database.Result, error) {
+			if !slices.Contains(args[slices.Index(args, any(id))+1:], any(*status)) {
+				return mocks.NewMockResult(0), nil
+			}
+			*status = args[0].(string)
+			return mocks.NewMockResult(1), nil
+		},
+	}
+}
+
+func TestStatusTransitions(t *testing.T) {
+	tests := []struct {
+		name          string
+		from          string
+		action        string
+		requireReview bool
+		code          int
+		to            string
+	}{
+		{name: "submit draft", from: StatusDraft, action: "review", code: fiber.StatusOK, to: StatusReview},
+		{name: "publish reviewed", from: StatusReview, action: "publish", code: fiber.StatusOK, to: StatusPublished},
+		{name: "publish draft", from: StatusDraft, action: "publish", code: fiber.StatusOK, to: StatusPublished},
+		{name: "publish draft requiring review", from: StatusDraft, action: "publish", requireReview: true, code: fiber.StatusConflict, to: StatusDraft},
+		{name: "publish reviewed requiring review", from: StatusReview, action: "publish", requireReview: true, code: fiber.StatusOK, to: StatusPublished},
+		{name: "republish", from: StatusPublished, action: "publish", code: fiber.StatusOK, to: StatusPublished},
+		{name: "unpublish", from: StatusPublished, action: "unpublish", code: fiber.StatusOK, to: StatusDraft},
+		{name: "unpublish draft", from: StatusDraft, action: "unpublish", code: fiber.StatusConflict, to: StatusDraft},
+		{name: "unpublish reviewed", from: StatusReview, action: "unpublish", code: fiber.StatusConflict, to: StatusReview},
+		{name: "submit published", from: StatusPublished, action: "review", code: fiber.StatusConflict, to: StatusPublished},
+		{name: "submit reviewed", from: StatusReview, action: "review", code: fiber.StatusConflict, to: StatusReview},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			id := uuid.New()
+			status := tt.from
+			config := DefaultConfig()
+			config.RequireReview = tt.requireReview
+			app, resource := setupTestApp(statusDatabase(id, &status), &config)
+			app.Post("/translations/:id/review", resource.SubmitForReview)
+			app.Post("/translations/:id/publish", resource.Publish)
+			app.Post("/translations/:id/unpublish", resource.Unpublish)
+
+			resp, err := app.Test(httptest.NewRequest(fiber.MethodPost, "/translations/"+id.String()+"/"+tt.action, nil))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			assert.Equal(t, tt.code, resp.StatusCode)
+			assert.Equal(t, tt.to, status)
+			if tt.code == fiber.StatusConflict {
+				var body ProblemDetails
+				assert.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+				assert.Equal(t, ProblemTypePrefix+CodeInvalidTransition, body.Type)
+				return
+			}
+			var body TranslatableResponseDTO
+			assert.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+			assert.Equal(t, tt.to, body.Status)
+		})
+	}
+}
+
+func TestStatusTransitions_NotFound(t *testing.T) {
+	config := DefaultConfig()
+	app, resource := setupTestApp(&mocks.MockDatabase{}, &config)
+	app.Post("/translations/:id/unpublish", resource.Unpublish)
+
+	resp, err := app.Test(httptest.NewRequest(fiber.MethodPost, "/translations/"+uuid.New().String()+"/unpublish", nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, fiber.StatusNotFound, resp.StatusCode)
+}
+
+func TestStatusFilter(t *testing.T) {
+	tests := []struct {
+		name          string
+		query         string
+		defaultFilter string
+		code          int
+		statuses      []interface{}
+	}{
+		{name: "no filter", code: fiber.StatusOK},
+		{name: "requested status", query: "?status=review", code: fiber.StatusOK, statuses: []interface{}{StatusReview}},
+		{name: "several statuses", query: "?status=draft,review", code: fiber.StatusOK, statuses: []interface{}{StatusDraft, StatusReview}},
+		{name: "default filter", defaultFilter: StatusPublished, code: fiber.StatusOK, statuses: []interface{}{StatusPublished}},
+		{name: "requested status overrides the default", query: "?status=draft", defaultFilter: StatusPublished, code: fiber.StatusOK, statuses: []interface{}{StatusDraft}},
+		{name: "unknown status", query: "?status=archived", code: fiber.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var queryArgs []interface{}
+			db := &mocks.MockDatabase{
+				QueryFunc: func(ctx context.Context, query string, args ...interface{}) (database.Rows, error) {
+					queryArgs = args
+					return mocks.NewMockRows(0), nil
+				},
+				QueryRowFunc: func(ctx context.Context, query string, args ...interface{}) database.Row {
+					return &mocks.MockRow{ScanFunc: func(dest ...interface{}) error {
+						*dest[0].(*int) = 0
+						return nil
+					}}
+				},
+			}
+			config := DefaultConfig()
+			config.DefaultStatusFilter = tt.defaultFilter
+			app, resource := setupTestApp(db, &config)
+			app.Get("/translations", resource.GetAll)
+
+			resp, err := app.Test(httptest.NewRequest(fiber.MethodGet, "/translations"+tt.query, nil))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			assert.Equal(t, tt.code, resp.StatusCode)
+			for _, status := range []interface{}{StatusDraft, StatusReview, StatusPublished} {
+				assert.Equal(t, slices.Contains(tt.statuses, status), slices.Contains(queryArgs, status), status)
+			}
+		})
+	}
+}
+
+func TestApprove(t *testing.T) {
+	owner, reviewer := uuid.New(), uuid.New()
+	tests := []struct {
+		name string
+		from string
+		code int
+		to   string
+	}{
+		{name: "under review", from: StatusReview, code: fiber.StatusOK, to: StatusPublished},
+		{name: "draft", from: StatusDraft, code: fiber.StatusConflict, to: StatusDraft},
+		{name: "published", from: StatusPublished, code: fiber.StatusConflict, to: StatusPublished},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			id := uuid.New()
+			status := tt.from
+			db := statusDatabase(id, &status)
+			scan := db.QueryRowFunc
+			db.QueryRowFunc = func(ctx context.Context, query string, args ...interface{}) database.Row {
+				row := scan(ctx, query, args...).(*mocks.MockRow)
+				return &mocks.MockRow{ScanFunc: func(dest ...interface{}) error {
+					*dest[1].(**uuid.UUID) = &owner
+					return row.ScanFunc(dest...)
+				}}
+			}
+			var approveArgs []interface{}
+			exec := db.ExecFunc
+			db.ExecFunc = func(ctx context.Context, query string, args ...interface{}) (database.Result, error) {
+				approveArgs = args
+				return exec(ctx, query, args...)
+			}
+			config := DefaultConfig()
+			app, resource := setupTestApp(db, &config)
+			app.Use(func(c fiber.Ctx) error {
+				authcontext.SetUserID(c, reviewer.String())
+				return c.Next()
+			})
+			app.Post("/translations/:id/approve", resource.Approve)
+
+			resp, err := app.Test(httptest.NewRequest(fiber.MethodPost, "/translations/"+id.String()+"/approve", nil))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			assert.Equal(t, tt.code, resp.StatusCode)
+			assert.Equal(t, tt.to, status)
+			assert.Equal(t, []interface{}{StatusReview}, approveArgs[len(approveArgs)-1:], "only translations under review are approved")
+			if tt.code == fiber.StatusOK {
+				assert.Contains(t, approveArgs, &reviewer)
+			}
+		})
+	}
+}
+
+func TestStatusTransitions_DatabaseError(t *testing.T) {
+	config := DefaultConfig()
+	db := &mocks.MockDatabase{
+		QueryRowFunc: func(ctx context.Context, query string, args ...interface{}) database.Row {
+			return &mocks.MockRow{ScanFunc: func(dest ...interface{}) error { return errors.New("connection reset") }}
+		},
+	}
+	app, resource := setupTestApp(db, &config)
+	app.Post("/translations/:id/publish", resource.Publish)
+
+	resp, err := app.Test(httptest.NewRequest(fiber.MethodPost, "/translations/"+uuid.New().String()+"/publish", nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, fiber.StatusInternalServerError, resp.StatusCode)
+}
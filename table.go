@@ -0,0 +1,60 @@
+package translatable
+
+import (
+	"errors"
+	"regexp"
+	"sync/atomic"
+
+	"github.com/nicolasbonnici/gorest-translatable/migrations"
+)
+
+const (
+	// DefaultTableName is the table the migrations create unless told otherwise.
+	DefaultTableName   = migrations.DefaultTableName
+	DefaultRoutePrefix = "/translations"
+)
+
+var (
+	tableNamePattern   = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]{0,62}$`)
+	routePrefixPattern = regexp.MustCompile(`^(/[A-Za-z0-9_.~-]+)+$`)
+)
+
+// validateNaming checks TableName and RoutePrefix. The table name is written
+// into queries as is, so it is held to a plain SQL identifier.
+func (c *Config) validateNaming() error {
+	if !tableNamePattern.MatchString(c.TableName) {
+		return errors.New("table_name must be a SQL identifier of letters, digits and underscores")
+	}
+	if !routePrefixPattern.MatchString(c.RoutePrefix) {
+		return errors.New("route_prefix must be an absolute path without a trailing slash")
+	}
+	return nil
+}
+
+// table returns the translations table, DefaultTableName when unset.
+func (c *Config) table() string {
+	if c.TableName == "" {
+		return DefaultTableName
+	}
+	return c.TableName
+}
+
+// routePrefix returns the translation routes prefix, DefaultRoutePrefix when
+// unset.
+func (c *Config) routePrefix() string {
+	if c.RoutePrefix == "" {
+		return DefaultRoutePrefix
+	}
+	return c.RoutePrefix
+}
+
+// crudTable is the table Translatable.TableName reports. The CRUD layer builds
+// its statements from the zero Translatable, which cannot carry a Config, so
+// newTranslatableProcessor sets it from Config.TableName: the name applies to
+// the whole process.
+var crudTable atomic.Pointer[string]
+
+func useTable(config *Config) {
+	table := config.table()
+	crudTable.Store(&table)
+}
@@ -0,0 +1,109 @@
+package translatable
+
+import (
+	"context"
+	"database/sql"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gofiber/fiber/v3"
+	"github.com/google/uuid"
+	"github.com/nicolasbonnici/gorest-translatable/mocks"
+	"github.com/nicolasbonnici/gorest/database"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConfig_ValidateNaming(t *testing.T) {
+	tests := []struct {
+		name        string
+		tableName   string
+		routePrefix string
+		wantErr     string
+	}{
+		{name: "defaults"},
+		{name: "custom", tableName: "i18n_entries", routePrefix: "/i18n/translations"},
+		{name: "injection", tableName: "translations; DROP TABLE users", wantErr: "table_name must be a SQL identifier of letters, digits and underscores"},
+		{name: "qualified table", tableName: "public.translations", wantErr: "table_name must be a SQL identifier of letters, digits and underscores"},
+		{name: "leading digit", tableName: "1translations", wantErr: "table_name must be a SQL identifier of letters, digits and underscores"},
+		{name: "relative prefix", routePrefix: "i18n", wantErr: "route_prefix must be an absolute path without a trailing slash"},
+		{name: "trailing slash", routePrefix: "/i18n/", wantErr: "route_prefix must be an absolute path without a trailing slash"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := DefaultConfig()
+			config.TableName = tt.tableName
+			config.RoutePrefix = tt.routePrefix
+			err := config.Validate()
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			if tt.tableName == "" {
+				assert.Equal(t, DefaultTableName, config.TableName)
+				assert.Equal(t, DefaultRoutePrefix, config.RoutePrefix)
+			}
+		})
+	}
+}
+
+func TestRegisterTranslatableRoutes_CustomNaming(t *testing.T) {
+	var queries []string
+	db := &mocks.MockDatabase{
+		QueryRowFunc: func(ctx context.Context, query string, args ...interface{}) database.Row {
+			queries = append(queries, query)
+			return &mocks.MockRow{ScanFunc: func(dest ...interface{}) error { return sql.ErrNoRows }}
+		},
+		ExecFunc: func(ctx context.Context, query string, args ...interface{}) (database.Result, error) {
+			queries = append(queries, query)
+			return mocks.NewMockResult(1), nil
+		},
+	}
+	config := DefaultConfig()
+	config.TableName = "i18n_entries"
+	config.RoutePrefix = "/i18n/translations"
+	app := fiber.New()
+	RegisterTranslatableRoutes(app, db, &config, nil, nil)
+
+	resp, err := app.Test(httptest.NewRequest(fiber.MethodGet, "/translations/"+uuid.NewString(), nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, fiber.StatusNotFound, resp.StatusCode)
+	assert.Empty(t, queries)
+
+	body := `{"translatableId":"` + uuid.NewString() + `","translatable":"post","locale":"fr","content":"Bonjour"}`
+	req := httptest.NewRequest(fiber.MethodPost, "/i18n/translations", strings.NewReader(body))
+	req.Header.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+	resp, err = app.Test(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, fiber.StatusCreated, resp.StatusCode)
+
+	resp, err = app.Test(httptest.NewRequest(fiber.MethodGet, "/i18n/translations/"+uuid.NewString(), nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, fiber.StatusNotFound, resp.StatusCode)
+
+	assert.NotEmpty(t, queries)
+	for _, query := range queries {
+		assert.Contains(t, query, " i18n_entries")
+		assert.NotRegexp(t, `\btranslations\b`, query)
+	}
+}
+
+func TestTranslatable_TableName(t *testing.T) {
+	t.Cleanup(func() { crudTable.Store(nil) })
+
+	config := DefaultConfig()
+	config.TableName = "i18n_entries"
+	newTranslatableProcessor(&mocks.MockDatabase{}, &config)
+	assert.Equal(t, "i18n_entries", Translatable{}.TableName())
+
+	newTranslatableProcessor(&mocks.MockDatabase{}, &Config{})
+	assert.Equal(t, DefaultTableName, Translatable{}.TableName())
+}
@@ -0,0 +1,60 @@
+package translatable
+
+import (
+	"context"
+
+	"github.com/gofiber/fiber/v3"
+	"github.com/nicolasbonnici/gorest/database"
+)
+
+const (
+	// TenantIDLocal is the fiber.Ctx local the host application sets to the
+	// tenant of the request, as gorest's processor.TenantIDEnricher reads it.
+	TenantIDLocal = "tenant_id"
+
+	tenantIDKey contextKey = "translatable_tenant_id"
+)
+
+// tenantMiddleware threads the tenant set by the host application through the
+// request context for Config.TenantScoped, and rejects requests without one:
+// an unscoped request would otherwise read or write outside any tenant.
+func tenantMiddleware(c fiber.Ctx) error {
+	tenantID, _ := c.Locals(TenantIDLocal).(string)
+	if tenantID == "" {
+		return sendError(c, fiber.StatusForbidden, "Tenant is required")
+	}
+	c.SetContext(WithTenantID(c.Context(), tenantID))
+	return c.Next()
+}
+
+// WithTenantID scopes the service calls made with ctx to tenantID, for host
+// applications calling TranslatableService outside a request.
+func WithTenantID(ctx context.Context, tenantID string) context.Context {
+	return context.WithValue(ctx, tenantIDKey, tenantID)
+}
+
+func getTenantIDFromContext(ctx context.Context) string {
+	tenantID, _ := ctx.Value(tenantIDKey).(string)
+	return tenantID
+}
+
+// tenantID returns the tenant to stamp on translations written with ctx, the
+// empty tenant when translations are not tenant scoped.
+func (c *Config) tenantID(ctx context.Context) *string {
+	tenantID := ""
+	if c.TenantScoped {
+		tenantID = getTenantIDFromContext(ctx)
+	}
+	return &tenantID
+}
+
+// tenantCondition returns the condition restricting column to the tenant of
+// ctx, with the tenant appended to args as its placeholder value. Without
+// Config.TenantScoped it returns an empty condition and args unchanged.
+func (c *Config) tenantCondition(ctx context.Context, d database.Dialect, column string, args []any) (string, []any) {
+	if !c.TenantScoped {
+		return "", args
+	}
+	args = append(args, getTenantIDFromContext(ctx))
+	return " AND " + column + " = " + d.Placeholder(len(args)), args
+}
@@ -0,0 +1,224 @@
+package translatable
+
+import (
+	"context"
+	"database/sql"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/gofiber/fiber/v3"
+	"github.com/google/uuid"
+	"github.com/nicolasbonnici/gorest-translatable/mocks"
+	"github.com/nicolasbonnici/gorest/database"
+	"github.com/stretchr/testify/assert"
+)
+
+// tenantDatabase holds a single translation owned by tenant "B" and only
+// finds it when a query is restricted to that tenant.
+func tenantDatabase(id uuid.UUID, writes *[]string) *mocks.MockDatabase {
+	owned := func(query string, args []interface{}) bool {
+		return strings.Contains(query, "tenant_id = ") && slices.Contains(args, interface{}("B"))
+	}
+	return &mocks.MockDatabase{
+		QueryRowFunc: func(ctx context.Context, query string, args ...interface{}) database.Row {
+			return &mocks.MockRow{ScanFunc: func(dest ...interface{}) error {
+				if len(dest) == 1 || !owned(query, args) {
+					return sql.ErrNoRows
+				}
+				*dest[0].(*uuid.UUID) = id
+				*dest[3].(*string) = "post"
+				*dest[4].(*string) = "fr"
+				*dest[5].(*string) = "Bonjour"
+				*dest[16].(*int) = 1
+				tenantID := "B"
+				*dest[17].(**string) = &tenantID
+				return nil
+			}}
+		},
+		ExecFunc: func(ctx context.Context, query string, args ...interface{}) (database.Result, error) {
+			*writes = append(*writes, query)
+			if !owned(query, args) {
+				return mocks.NewMockResult(0), nil
+			}
+			return mocks.NewMockResult(1), nil
+		},
+	}
+}
+
+func setupTenantApp(db database.Database, tenantID string) *fiber.App {
+	config := DefaultConfig()
+	config.TenantScoped = true
+	app, resource := setupTestApp(db, &config)
+	app.Use(func(c fiber.Ctx) error {
+		if tenantID != "" {
+			c.Locals(TenantIDLocal, tenantID)
+		}
+		return c.Next()
+	}, tenantMiddleware)
+	app.Get("/translations/:id", resource.GetByID)
+	app.Put("/translations/:id", resource.Update)
+	app.Delete("/translations/:id", resource.Delete)
+	return app
+}
+
+func TestTenantIsolation(t *testing.T) {
+	id := uuid.New()
+	tests := []struct {
+		name   string
+		tenant string
+		method string
+		body   string
+		status int
+		writes int
+	}{
+		{name: "owner reads", tenant: "B", method: fiber.MethodGet, status: fiber.StatusOK},
+		{name: "other tenant reads", tenant: "A", method: fiber.MethodGet, status: fiber.StatusNotFound},
+		{name: "owner updates", tenant: "B", method: fiber.MethodPut, body: `{"locale":"fr","content":"Salut"}`, status: fiber.StatusOK, writes: 1},
+		{name: "other tenant updates", tenant: "A", method: fiber.MethodPut, body: `{"locale":"fr","content":"Salut"}`, status: fiber.StatusNotFound},
+		{name: "owner deletes", tenant: "B", method: fiber.MethodDelete, status: fiber.StatusNoContent, writes: 1},
+		{name: "other tenant deletes", tenant: "A", method: fiber.MethodDelete, status: fiber.StatusNotFound},
+		{name: "no tenant", method: fiber.MethodGet, status: fiber.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var writes []string
+			app := setupTenantApp(tenantDatabase(id, &writes), tt.tenant)
+
+			req := httptest.NewRequest(tt.method, "/translations/"+id.String(), strings.NewReader(tt.body))
+			req.Header.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+			resp, err := app.Test(req)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			assert.Equal(t, tt.status, resp.StatusCode)
+			assert.Len(t, writes, tt.writes)
+			for _, sql := range writes {
+				assert.Contains(t, sql, "tenant_id = ")
+			}
+		})
+	}
+}
+
+func TestTenantIsolation_CreateStampsTenant(t *testing.T) {
+	var insertArgs []interface{}
+	db := &mocks.MockDatabase{
+		QueryRowFunc: func(ctx context.Context, query string, args ...interface{}) database.Row {
+			if strings.HasPrefix(query, "INSERT") {
+				insertArgs = args
+			}
+			return &mocks.MockRow{ScanFunc: func(dest ...interface{}) error {
+				if strings.HasPrefix(query, "INSERT") {
+					return nil
+				}
+				return sql.ErrNoRows
+			}}
+		},
+		ExecFunc: func(ctx context.Context, query string, args ...interface{}) (database.Result, error) {
+			if strings.HasPrefix(query, "INSERT") {
+				insertArgs = args
+			}
+			return mocks.NewMockResult(1), nil
+		},
+	}
+	config := DefaultConfig()
+	config.TenantScoped = true
+	app, resource := setupTestApp(db, &config)
+	app.Use(func(c fiber.Ctx) error {
+		c.Locals(TenantIDLocal, "A")
+		return c.Next()
+	}, tenantMiddleware)
+	app.Post("/translations", resource.Create)
+
+	body := `{"translatableId":"` + uuid.NewString() + `","translatable":"post","locale":"fr","content":"Bonjour"}`
+	req := httptest.NewRequest(fiber.MethodPost, "/translations", strings.NewReader(body))
+	req.Header.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+	resp, err := app.Test(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, fiber.StatusCreated, resp.StatusCode)
+	tenantID := "A"
+	assert.Contains(t, insertArgs, &tenantID)
+}
+
+func TestTenantCondition(t *testing.T) {
+	config := DefaultConfig()
+	d := (&mocks.MockDatabase{}).Dialect()
+	ctx := WithTenantID(context.Background(), "A")
+
+	condition, args := config.tenantCondition(ctx, d, "tenant_id", []any{1})
+	assert.Empty(t, condition)
+	assert.Equal(t, []any{1}, args)
+	assert.Equal(t, "", *config.tenantID(ctx))
+
+	config.TenantScoped = true
+	condition, args = config.tenantCondition(ctx, d, "tenant_id", []any{1})
+	assert.Equal(t, " AND tenant_id = $2", condition)
+	assert.Equal(t, []any{1, "A"}, args)
+}
+
+func TestTranslatableService_Upsert_OtherTenant(t *testing.T) {
+	var upsertSQL string
+	var upsertArgs []interface{}
+	tx := &mocks.MockTx{
+		QueryFunc: func(ctx context.Context, query string, args ...interface{}) (database.Rows, error) {
+			if upsertSQL == "" {
+				return mocks.NewMockRows(0), nil
+			}
+			rows := mocks.NewMockRows(1)
+			rows.ScanFunc = func(row int, dest ...interface{}) error {
+				*dest[17].(**string) = new(args[len(args)-1].(string))
+				return nil
+			}
+			return rows, nil
+		},
+		ExecFunc: func(ctx context.Context, query string, args ...interface{}) (database.Result, error) {
+			upsertSQL, upsertArgs = query, args
+			return mocks.NewMockResult(1), nil
+		},
+	}
+	db := &mocks.MockDatabase{
+		BeginFunc: func(ctx context.Context) (database.Tx, error) { return tx, nil },
+	}
+	config := DefaultConfig()
+	config.TenantScoped = true
+	service := NewTranslatableService(db, &config)
+
+	ctx := WithTenantID(context.Background(), "A")
+	upserted, created, err := service.Upsert(ctx, &Translatable{TranslatableID: uuid.New(), Translatable: "post", Locale: "fr", Content: "Bonjour"})
+
+	assert.NoError(t, err)
+	assert.True(t, created, "the same key in another tenant is another translation")
+	assert.Equal(t, "A", *upserted.TenantID)
+	assert.Contains(t, upsertArgs, new("A"))
+	assert.Contains(t, upsertSQL, "ON CONFLICT (translatable_id, translatable, locale, tenant_id)")
+	assert.NotContains(t, upsertSQL, "WHERE")
+}
+
+func TestTranslatableService_Restore_Tenant(t *testing.T) {
+	var restoreSQL string
+	var restoreArgs []interface{}
+	db := &mocks.MockDatabase{
+		QueryRowFunc: func(ctx context.Context, query string, args ...interface{}) database.Row {
+			return &mocks.MockRow{ScanFunc: func(dest ...interface{}) error { return nil }}
+		},
+		ExecFunc: func(ctx context.Context, query string, args ...interface{}) (database.Result, error) {
+			restoreSQL, restoreArgs = query, args
+			return mocks.NewMockResult(1), nil
+		},
+	}
+	config := DefaultConfig()
+	config.TenantScoped = true
+	id := uuid.New()
+
+	_, err := NewTranslatableService(db, &config).Restore(WithTenantID(context.Background(), "A"), id, nil)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "UPDATE translations SET deleted_at = NULL, updated_at = $1 WHERE id = $2 AND deleted_at IS NOT NULL AND tenant_id = $3", restoreSQL)
+	assert.Equal(t, []interface{}{id, "A"}, restoreArgs[1:])
+}
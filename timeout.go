@@ -0,0 +1,144 @@
+package translatable
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/gofiber/fiber/v3"
+	"github.com/nicolasbonnici/gorest/database"
+)
+
+// errQueryTimeout answers requests whose statements ran past
+// Config.QueryTimeout.
+var errQueryTimeout = fiber.NewError(fiber.StatusGatewayTimeout, "database query timed out")
+
+// errDatabase reports a failed database operation as a 500 with message, as a
+// 504 when a statement ran past its deadline, or with the status a lifecycle
+// hook rejected the write with.
+func errDatabase(err error, message string) error {
+	if errors.Is(err, context.DeadlineExceeded) {
+		return errQueryTimeout
+	}
+	var rejected *hookRejectedError
+	if errors.As(err, &rejected) {
+		return rejected.err
+	}
+	return fiber.NewError(fiber.StatusInternalServerError, message)
+}
+
+// withQueryTimeout bounds every statement run through db by
+// Config.QueryTimeout, or returns db itself when no timeout is set.
+func withQueryTimeout(db database.Database, config *Config) database.Database {
+	if db == nil || config.QueryTimeout <= 0 {
+		return db
+	}
+	return timeoutDatabase{Database: db, timeout: config.QueryTimeout}
+}
+
+// timeoutDatabase runs each statement under its own deadline, derived from the
+// caller's context so cancelled requests still stop their queries. The
+// deadline of Query covers reading the rows, up to Close, and that of
+// QueryRow lasts until the Scan.
+type timeoutDatabase struct {
+	database.Database
+	timeout time.Duration
+}
+
+func (d timeoutDatabase) Query(ctx context.Context, query string, args ...interface{}) (database.Rows, error) {
+	return timeoutQuery(ctx, d.timeout, d.Database.Query, query, args)
+}
+
+func (d timeoutDatabase) QueryRow(ctx context.Context, query string, args ...interface{}) database.Row {
+	ctx, cancel := context.WithTimeout(ctx, d.timeout)
+	return timeoutRow{Row: d.Database.QueryRow(ctx, query, args...), cancel: cancel}
+}
+
+func (d timeoutDatabase) Exec(ctx context.Context, query string, args ...interface{}) (database.Result, error) {
+	ctx, cancel := context.WithTimeout(ctx, d.timeout)
+	defer cancel()
+	return d.Database.Exec(ctx, query, args...)
+}
+
+func (d timeoutDatabase) Begin(ctx context.Context) (database.Tx, error) {
+	tx, err := d.Database.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return timeoutTx{Tx: tx, timeout: d.timeout}, nil
+}
+
+// timeoutTx bounds the statements of a transaction like timeoutDatabase.
+// Commit and Rollback keep the caller's context, so a transaction is always
+// ended.
+type timeoutTx struct {
+	database.Tx
+	timeout time.Duration
+}
+
+func (t timeoutTx) Query(ctx context.Context, query string, args ...interface{}) (database.Rows, error) {
+	return timeoutQuery(ctx, t.timeout, t.Tx.Query, query, args)
+}
+
+func (t timeoutTx) QueryRow(ctx context.Context, query string, args ...interface{}) database.Row {
+	ctx, cancel := context.WithTimeout(ctx, t.timeout)
+	return timeoutRow{Row: t.Tx.QueryRow(ctx, query, args...), cancel: cancel}
+}
+
+func (t timeoutTx) Exec(ctx context.Context, query string, args ...interface{}) (database.Result, error) {
+	ctx, cancel := context.WithTimeout(ctx, t.timeout)
+	defer cancel()
+	return t.Tx.Exec(ctx, query, args...)
+}
+
+type queryFunc func(ctx context.Context, query string, args ...interface{}) (database.Rows, error)
+
+func timeoutQuery(ctx context.Context, timeout time.Duration, query queryFunc, sql string, args []interface{}) (database.Rows, error) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	rows, err := query(ctx, sql, args...)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	return &timeoutRows{Rows: rows, ctx: ctx, cancel: cancel}, nil
+}
+
+// timeoutRows stops the iteration once the deadline of its query passed, even
+// when the driver keeps returning buffered rows, and reports the deadline
+// from Err.
+type timeoutRows struct {
+	database.Rows
+	ctx    context.Context
+	cancel context.CancelFunc
+	closed bool
+}
+
+func (r *timeoutRows) Next() bool {
+	if r.ctx.Err() != nil {
+		return false
+	}
+	return r.Rows.Next()
+}
+
+func (r *timeoutRows) Err() error {
+	if err := r.Rows.Err(); err != nil || r.closed {
+		return err
+	}
+	return r.ctx.Err()
+}
+
+func (r *timeoutRows) Close() error {
+	r.closed = true
+	defer r.cancel()
+	return r.Rows.Close()
+}
+
+type timeoutRow struct {
+	database.Row
+	cancel context.CancelFunc
+}
+
+func (r timeoutRow) Scan(dest ...interface{}) error {
+	defer r.cancel()
+	return r.Row.Scan(dest...)
+}
@@ -0,0 +1,103 @@
+package translatable
+
+import (
+	"context"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v3"
+	"github.com/nicolasbonnici/gorest-translatable/mocks"
+	"github.com/nicolasbonnici/gorest/database"
+	"github.com/stretchr/testify/assert"
+)
+
+// hungDatabase never answers, until the context of the statement is done.
+func hungDatabase() *mocks.MockDatabase {
+	return &mocks.MockDatabase{
+		QueryFunc: func(ctx context.Context, query string, args ...interface{}) (database.Rows, error) {
+			<-ctx.Done()
+			return nil, ctx.Err()
+		},
+		QueryRowFunc: func(ctx context.Context, query string, args ...interface{}) database.Row {
+			return &mocks.MockRow{ScanFunc: func(dest ...interface{}) error {
+				<-ctx.Done()
+				return ctx.Err()
+			}}
+		},
+	}
+}
+
+func TestQueryTimeout(t *testing.T) {
+	tests := []struct {
+		name  string
+		route func(app *fiber.App, resource *TranslatableResource)
+		path  string
+	}{
+		{
+			name:  "collection",
+			route: func(app *fiber.App, resource *TranslatableResource) { app.Get("/translations", resource.GetAll) },
+			path:  "/translations",
+		},
+		{
+			name:  "count",
+			route: func(app *fiber.App, resource *TranslatableResource) { app.Get("/translations/count", resource.Count) },
+			path:  "/translations/count",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := DefaultConfig()
+			config.QueryTimeout = 20 * time.Millisecond
+			app, resource := setupTestApp(hungDatabase(), &config)
+			app.Use(requestIDMiddleware)
+			tt.route(app, resource)
+
+			start := time.Now()
+			resp, err := app.Test(httptest.NewRequest(fiber.MethodGet, tt.path, nil))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			assert.Equal(t, fiber.StatusGatewayTimeout, resp.StatusCode)
+			assert.Less(t, time.Since(start), 500*time.Millisecond)
+		})
+	}
+}
+
+func TestTimeoutRows_StopsAtDeadline(t *testing.T) {
+	scanned := 0
+	db := &mocks.MockDatabase{
+		QueryFunc: func(ctx context.Context, query string, args ...interface{}) (database.Rows, error) {
+			rows := mocks.NewMockRows(1000000)
+			rows.ScanFunc = func(row int, dest ...interface{}) error {
+				scanned++
+				time.Sleep(time.Millisecond)
+				return nil
+			}
+			return rows, nil
+		},
+	}
+	config := DefaultConfig()
+	config.QueryTimeout = 20 * time.Millisecond
+
+	rows, err := withQueryTimeout(db, &config).Query(context.Background(), "SELECT id FROM translations")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for rows.Next() {
+		_ = rows.Scan()
+	}
+
+	assert.ErrorIs(t, rows.Err(), context.DeadlineExceeded)
+	assert.Less(t, scanned, 1000000)
+	assert.NoError(t, rows.Close())
+	assert.NoError(t, rows.Err())
+}
+
+func TestWithQueryTimeout_Disabled(t *testing.T) {
+	db := &mocks.MockDatabase{}
+	config := DefaultConfig()
+	assert.Same(t, db, withQueryTimeout(db, &config))
+}
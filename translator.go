@@ -2,10 +2,20 @@ package translatable
 
 import (
 	"context"
+	"errors"
+	"html"
+	"net/url"
 
 	"github.com/google/uuid"
 )
 
+// HeaderTranslationSource is set to TranslationSourceMachine on reads serving
+// a machine-translated translation.
+const (
+	HeaderTranslationSource  = "X-Translation-Source"
+	TranslationSourceMachine = "machine"
+)
+
 // TranslationResult holds the per-locale outcome of a translate request.
 type TranslationResult struct {
 	Translated []string `json:"translated"`
@@ -17,3 +27,41 @@ type TranslationResult struct {
 type Translator interface {
 	Translate(ctx context.Context, resourceType, resourceID string, userID *uuid.UUID) (*TranslationResult, error)
 }
+
+// TextTranslator machine-translates a single text between two locales. It
+// backs POST /translations/:id/translate; DeepLTranslator implements it.
+type TextTranslator interface {
+	Translate(ctx context.Context, text, sourceLocale, targetLocale string) (string, error)
+}
+
+// machineTranslatable reports whether the content of a type is a single text a
+// TextTranslator can take: neither JSON nor the fields of a multi-field type.
+func (c *Config) machineTranslatable(typeName string) bool {
+	return c.contentFormat(typeName) == ContentFormatText && !c.IsMultiField(typeName)
+}
+
+// providerText is the content of t as sent to a TextTranslator. Escaped text is
+// unescaped back to what was submitted; the other sanitizers store markup, which
+// is sent as is.
+func (c *Config) providerText(t *Translatable) string {
+	if c.sanitizeMode(t.Translatable) == SanitizerModeEscape {
+		return html.UnescapeString(t.Content)
+	}
+	return t.Content
+}
+
+var (
+	ErrInsecureProviderEndpoint = errors.New("translation provider endpoint must use https")
+	ErrProviderUnavailable      = errors.New("translation provider unavailable")
+)
+
+// ValidateProviderEndpoint rejects machine-translation endpoints that would send
+// content over an insecure or unconfigured channel. Translator adapters should
+// call it before issuing any request.
+func ValidateProviderEndpoint(endpoint string) error {
+	u, err := url.Parse(endpoint)
+	if err != nil || u.Scheme != "https" || u.Host == "" {
+		return ErrInsecureProviderEndpoint
+	}
+	return nil
+}
@@ -2,14 +2,20 @@ package translatable
 
 import (
 	"context"
+	"database/sql"
 	"encoding/json"
 	"errors"
 	"io"
 	"net/http/httptest"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/gofiber/fiber/v3"
 	"github.com/google/uuid"
+	"github.com/nicolasbonnici/gorest-translatable/mocks"
+	"github.com/nicolasbonnici/gorest/database"
+	"github.com/stretchr/testify/assert"
 )
 
 type mockTranslator struct {
@@ -21,9 +27,21 @@ func (m *mockTranslator) Translate(_ context.Context, _, _ string, _ *uuid.UUID)
 	return m.result, m.err
 }
 
+type blockingTranslator struct{}
+
+func (blockingTranslator) Translate(ctx context.Context, _, _ string, _ *uuid.UUID) (*TranslationResult, error) {
+	<-ctx.Done()
+	return nil, ctx.Err()
+}
+
 func newTranslateApp(t Translator) *fiber.App {
+	config := DefaultConfig()
+	return newTranslateAppWithConfig(t, &config)
+}
+
+func newTranslateAppWithConfig(t Translator, config *Config) *fiber.App {
 	app := fiber.New()
-	app.Post("/translations/:type/:id/translate", (&TranslatableResource{translator: &t}).Translate)
+	app.Post("/translations/:type/:id/translate", (&TranslatableResource{translator: &t, config: config}).Translate)
 	return app
 }
 
@@ -85,3 +103,215 @@ func TestTranslate_Success(t *testing.T) {
 		t.Fatalf("unexpected result: %+v", got)
 	}
 }
+
+func TestTranslate_Timeout(t *testing.T) {
+	config := DefaultConfig()
+	config.TranslatorTimeout = 10 * time.Millisecond
+	app := newTranslateAppWithConfig(blockingTranslator{}, &config)
+
+	req := httptest.NewRequest("POST", "/translations/post/abc/translate", nil)
+	resp, err := app.Test(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.StatusCode != fiber.StatusGatewayTimeout {
+		t.Fatalf("expected 504, got %d", resp.StatusCode)
+	}
+}
+
+func TestTranslate_ErrorDoesNotLeakProviderMessage(t *testing.T) {
+	app := newTranslateApp(&mockTranslator{err: errors.New("cannot translate: secret draft")})
+
+	req := httptest.NewRequest("POST", "/translations/post/abc/translate", nil)
+	resp, err := app.Test(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	body, _ := io.ReadAll(resp.Body)
+	if strings.Contains(string(body), "secret draft") {
+		t.Fatalf("response leaked provider error: %s", body)
+	}
+}
+
+func TestValidateProviderEndpoint(t *testing.T) {
+	tests := []struct {
+		endpoint string
+		wantErr  bool
+	}{
+		{endpoint: "https://api.deepl.com/v2/translate", wantErr: false},
+		{endpoint: "http://api.deepl.com/v2/translate", wantErr: true},
+		{endpoint: "https://", wantErr: true},
+		{endpoint: "", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.endpoint, func(t *testing.T) {
+			err := ValidateProviderEndpoint(tt.endpoint)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateProviderEndpoint(%q) error = %v, wantErr %v", tt.endpoint, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+type textTranslatorFunc func(ctx context.Context, text, sourceLocale, targetLocale string) (string, error)
+
+func (f textTranslatorFunc) Translate(ctx context.Context, text, sourceLocale, targetLocale string) (string, error) {
+	return f(ctx, text, sourceLocale, targetLocale)
+}
+
+func TestTranslateTo(t *testing.T) {
+	sourceID := uuid.New()
+	entityID := uuid.New()
+
+	translate := textTranslatorFunc(func(context.Context, string, string, string) (string, error) { return "Tom & Jerry FR", nil })
+	tests := []struct {
+		name       string
+		target     string
+		translator TextTranslator
+		configure  func(*Config)
+		exists     bool
+		status     int
+	}{
+		{
+			name:   "stores the machine translation",
+			target: "fr",
+			translator: textTranslatorFunc(func(_ context.Context, text, source, target string) (string, error) {
+				if text != "Tom & Jerry" || source != "en" || target != "fr" {
+					return "", errors.New("unexpected input")
+				}
+				return "Tom & Jerry FR", nil
+			}),
+			status: fiber.StatusCreated,
+		},
+		{
+			name:       "create validations apply",
+			target:     "fr",
+			translator: translate,
+			configure:  func(config *Config) { config.DefaultLocaleFirst = true },
+			status:     fiber.StatusConflict,
+		},
+		{
+			name:       "before hook rejects",
+			target:     "fr",
+			translator: translate,
+			configure: func(config *Config) {
+				config.Hooks.BeforeCreate = func(context.Context, *Translatable) error { return errors.New("reserved") }
+			},
+			status: fiber.StatusUnprocessableEntity,
+		},
+		{name: "target already translated", target: "fr", translator: translate, exists: true, status: fiber.StatusConflict},
+		{
+			name:   "stored markup is sent as is",
+			target: "fr",
+			translator: textTranslatorFunc(func(_ context.Context, text, _, _ string) (string, error) {
+				if text != "Tom &amp; Jerry" {
+					return "", errors.New("unexpected input")
+				}
+				return "Tom &amp; Jerry FR", nil
+			}),
+			configure: func(config *Config) { config.TypeSanitizeModes = map[string]string{"post": SanitizerModeStrip} },
+			status:    fiber.StatusCreated,
+		},
+		{
+			name:       "json type",
+			target:     "fr",
+			translator: textTranslatorFunc(nil),
+			configure:  func(config *Config) { config.TypeValidators = map[string]string{"post": ValidatorJSON} },
+			status:     fiber.StatusUnprocessableEntity,
+		},
+		{
+			name:       "multi-field type",
+			target:     "fr",
+			translator: textTranslatorFunc(nil),
+			configure:  func(config *Config) { config.FieldKeys = map[string][]string{"post": {"title"}} },
+			status:     fiber.StatusUnprocessableEntity,
+		},
+		{name: "unsupported target", target: "it", status: fiber.StatusBadRequest},
+		{name: "same locale as source", target: "en", translator: textTranslatorFunc(nil), status: fiber.StatusBadRequest},
+		{name: "not configured", target: "fr", status: fiber.StatusServiceUnavailable},
+		{
+			name:   "provider failure",
+			target: "fr",
+			translator: textTranslatorFunc(func(context.Context, string, string, string) (string, error) {
+				return "", ErrProviderUnavailable
+			}),
+			status: fiber.StatusBadGateway,
+		},
+		{
+			name:   "provider timeout",
+			target: "fr",
+			translator: textTranslatorFunc(func(ctx context.Context, _, _, _ string) (string, error) {
+				<-ctx.Done()
+				return "", ctx.Err()
+			}),
+			status: fiber.StatusGatewayTimeout,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var inserted []interface{}
+			tx := &mocks.MockTx{
+				QueryFunc: func(ctx context.Context, query string, args ...interface{}) (database.Rows, error) {
+					rows := mocks.NewMockRows(1)
+					rows.ScanFunc = func(row int, dest ...interface{}) error {
+						*dest[4].(*string) = "fr"
+						return nil
+					}
+					return rows, nil
+				},
+			}
+			db := &mocks.MockDatabase{
+				BeginFunc: func(ctx context.Context) (database.Tx, error) { return tx, nil },
+				QueryRowFunc: func(ctx context.Context, query string, args ...interface{}) database.Row {
+					return &mocks.MockRow{ScanFunc: func(dest ...interface{}) error {
+						if len(dest) == 1 {
+							return sql.ErrNoRows
+						}
+						*dest[0].(*uuid.UUID) = sourceID
+						*dest[2].(*uuid.UUID) = entityID
+						*dest[3].(*string) = "post"
+						*dest[4].(*string) = "en"
+						*dest[5].(*string) = "Tom &amp; Jerry"
+						return nil
+					}}
+				},
+				ExecFunc: func(ctx context.Context, query string, args ...interface{}) (database.Result, error) {
+					if tt.exists {
+						return nil, errors.New("duplicate key value violates unique constraint (SQLSTATE 23505)")
+					}
+					inserted = args
+					return mocks.NewMockResult(1), nil
+				},
+			}
+			config := DefaultConfig()
+			config.TranslatorTimeout = 10 * time.Millisecond
+			config.TextTranslator = tt.translator
+			if tt.configure != nil {
+				tt.configure(&config)
+			}
+			app, resource := setupTestApp(db, &config)
+			app.Post("/translations/:id/translate", resource.TranslateTo)
+
+			resp, err := app.Test(httptest.NewRequest(fiber.MethodPost, "/translations/"+sourceID.String()+"/translate?target="+tt.target, nil))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			assert.Equal(t, tt.status, resp.StatusCode)
+			if tt.status != fiber.StatusCreated {
+				assert.Nil(t, inserted)
+				return
+			}
+			var body TranslatableResponseDTO
+			assert.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+			assert.Equal(t, "fr", body.Locale)
+			assert.Equal(t, entityID, body.TranslatableID)
+			assert.Equal(t, "Tom &amp; Jerry FR", body.Content)
+			assert.True(t, body.AutoTranslated)
+			assert.Equal(t, "fr", inserted[4])
+		})
+	}
+}
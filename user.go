@@ -0,0 +1,93 @@
+package translatable
+
+import (
+	"context"
+	"strings"
+
+	"github.com/gofiber/fiber/v3"
+	"github.com/google/uuid"
+	"github.com/nicolasbonnici/gorest/database"
+)
+
+// UserSummary is the author of a translation, served under ?include=user.
+type UserSummary struct {
+	ID    uuid.UUID `json:"id"`
+	Email string    `json:"email"`
+	Name  string    `json:"name"`
+}
+
+const includeUserKey contextKey = "translatable_include_user"
+
+// includesUser reports whether the request asks for ?include=user.
+func includesUser(c fiber.Ctx) bool {
+	for _, include := range strings.Split(c.Query("include"), ",") {
+		if strings.TrimSpace(include) == "user" {
+			return true
+		}
+	}
+	return false
+}
+
+// applyInclude records ?include=user on the request context.
+func applyInclude(c fiber.Ctx) {
+	if includesUser(c) {
+		c.SetContext(context.WithValue(c.Context(), includeUserKey, true))
+	}
+}
+
+func includeUserFromContext(ctx context.Context) bool {
+	include, _ := ctx.Value(includeUserKey).(bool)
+	return include
+}
+
+// attachUsers sets User on the models that have one, reading the users table
+// user_id references in one query. Translations whose user was deleted, and
+// user_id set to NULL, are served without it. A failing query is logged and
+// leaves the models unchanged.
+func attachUsers(ctx context.Context, db database.Database, models []Translatable) {
+	var ids []any
+	seen := make(map[uuid.UUID]bool)
+	for _, model := range models {
+		if model.UserID != nil && !seen[*model.UserID] {
+			seen[*model.UserID] = true
+			ids = append(ids, *model.UserID)
+		}
+	}
+	if len(ids) == 0 {
+		return
+	}
+
+	d := db.Dialect()
+	placeholders := make([]string, len(ids))
+	for i := range ids {
+		placeholders[i] = d.Placeholder(i + 1)
+	}
+	rows, err := db.Query(ctx, "SELECT id, email, firstname, lastname FROM users WHERE id IN ("+strings.Join(placeholders, ", ")+")", ids...)
+	if err != nil {
+		requestLogger(ctx).Warn("user lookup failed", "error", err)
+		return
+	}
+	defer func() { _ = rows.Close() }()
+
+	users := make(map[uuid.UUID]*UserSummary, len(ids))
+	for rows.Next() {
+		var user UserSummary
+		var firstname, lastname string
+		if err := rows.Scan(&user.ID, &user.Email, &firstname, &lastname); err != nil {
+			requestLogger(ctx).Warn("user lookup failed", "error", err)
+			return
+		}
+		user.Name = strings.TrimSpace(firstname + " " + lastname)
+		users[user.ID] = &user
+	}
+	if err := rows.Err(); err != nil {
+		requestLogger(ctx).Warn("user lookup failed", "error", err)
+		return
+	}
+
+	for i := range models {
+		if models[i].UserID != nil {
+			models[i].User = users[*models[i].UserID]
+		}
+	}
+}
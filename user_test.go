@@ -0,0 +1,126 @@
+package translatable
+
+import (
+	"context"
+	"encoding/json"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gofiber/fiber/v3"
+	"github.com/google/uuid"
+	"github.com/nicolasbonnici/gorest-translatable/mocks"
+	"github.com/nicolasbonnici/gorest/database"
+	"github.com/stretchr/testify/assert"
+)
+
+// userDatabase serves translations written by author, and author from the
+// users table, recording the ids each user lookup asks for.
+func userDatabase(author *uuid.UUID, lookups *[][]interface{}) *mocks.MockDatabase {
+	scan := func(dest ...interface{}) {
+		*dest[0].(*uuid.UUID) = uuid.New()
+		*dest[1].(**uuid.UUID) = author
+		*dest[3].(*string) = "post"
+		*dest[4].(*string) = "fr"
+		*dest[5].(*string) = "Bonjour"
+	}
+	return &mocks.MockDatabase{
+		QueryRowFunc: func(ctx context.Context, query string, args ...interface{}) database.Row {
+			return &mocks.MockRow{ScanFunc: func(dest ...interface{}) error {
+				if strings.HasPrefix(query, "SELECT COUNT(") {
+					*dest[0].(*int) = 2
+					return nil
+				}
+				scan(dest...)
+				return nil
+			}}
+		},
+		QueryFunc: func(ctx context.Context, query string, args ...interface{}) (database.Rows, error) {
+			if !strings.HasPrefix(query, "SELECT id, email, firstname, lastname FROM users") {
+				rows := mocks.NewMockRows(2)
+				rows.ScanFunc = func(row int, dest ...interface{}) error {
+					scan(dest...)
+					return nil
+				}
+				return rows, nil
+			}
+			*lookups = append(*lookups, args)
+			rows := mocks.NewMockRows(1)
+			rows.ScanFunc = func(row int, dest ...interface{}) error {
+				*dest[0].(*uuid.UUID) = *author
+				*dest[1].(*string) = "ada@example.com"
+				*dest[2].(*string) = "Ada"
+				*dest[3].(*string) = "Lovelace"
+				return nil
+			}
+			return rows, nil
+		},
+	}
+}
+
+func TestIncludeUser_GetByID(t *testing.T) {
+	author := uuid.New()
+	tests := []struct {
+		name   string
+		query  string
+		author *uuid.UUID
+		user   *UserSummary
+	}{
+		{name: "included", query: "?include=user", author: &author, user: &UserSummary{ID: author, Email: "ada@example.com", Name: "Ada Lovelace"}},
+		{name: "omitted", author: &author},
+		{name: "deleted user", query: "?include=user"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var lookups [][]interface{}
+			config := DefaultConfig()
+			app := fiber.New()
+			RegisterTranslatableRoutes(app, userDatabase(tt.author, &lookups), &config, nil, nil)
+
+			req := httptest.NewRequest(fiber.MethodGet, "/translations/"+uuid.NewString()+tt.query, nil)
+			req.Header.Set(fiber.HeaderAccept, fiber.MIMEApplicationJSON)
+			resp, err := app.Test(req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+			var body map[string]json.RawMessage
+			assert.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+			if tt.user == nil {
+				assert.NotContains(t, body, "user")
+				assert.Empty(t, lookups, "the users table is only read on request")
+				return
+			}
+			var user UserSummary
+			assert.NoError(t, json.Unmarshal(body["user"], &user))
+			assert.Equal(t, *tt.user, user)
+		})
+	}
+}
+
+func TestIncludeUser_GetAll(t *testing.T) {
+	author := uuid.New()
+	var lookups [][]interface{}
+	config := DefaultConfig()
+	config.ResponseFormat = ResponseFormatPlain
+	app := fiber.New()
+	RegisterTranslatableRoutes(app, userDatabase(&author, &lookups), &config, nil, nil)
+
+	resp, err := app.Test(httptest.NewRequest(fiber.MethodGet, "/translations?include=user", nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+	var body struct {
+		Data []TranslatableResponseDTO `json:"data"`
+	}
+	assert.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+	assert.Len(t, body.Data, 2)
+	for _, translation := range body.Data {
+		assert.Equal(t, &UserSummary{ID: author, Email: "ada@example.com", Name: "Ada Lovelace"}, translation.User)
+	}
+	assert.Equal(t, [][]interface{}{{author}}, lookups, "one lookup per page, once per user")
+}
@@ -0,0 +1,51 @@
+package translatable
+
+import (
+	"context"
+
+	"github.com/nicolasbonnici/gorest/database"
+)
+
+const versionGuardKey contextKey = "translatable_version_guard"
+
+// VersionConflictError rejects an update made against an outdated version.
+// Current is the translation as stored, for the client to merge with.
+type VersionConflictError struct {
+	Current *Translatable
+}
+
+func (e *VersionConflictError) Error() string {
+	return "translation was updated by someone else"
+}
+
+// versionGuard makes the CRUD update of a translation conditional on the
+// version it was read at. The update reports the rows it affected; when none
+// were, reload tells a concurrent write from a deleted row.
+type versionGuard struct {
+	version  int
+	affected int64
+	reload   func() (*Translatable, error)
+}
+
+func withVersionGuard(ctx context.Context, guard *versionGuard) context.Context {
+	return context.WithValue(ctx, versionGuardKey, guard)
+}
+
+func versionGuardFromContext(ctx context.Context) *versionGuard {
+	guard, _ := ctx.Value(versionGuardKey).(*versionGuard)
+	return guard
+}
+
+// guardedDatabase records the rows affected by statements run for a request
+// holding a versionGuard, which the CRUD layer does not expose to hooks.
+type guardedDatabase struct {
+	database.Database
+}
+
+func (d guardedDatabase) Exec(ctx context.Context, query string, args ...interface{}) (database.Result, error) {
+	result, err := d.Database.Exec(ctx, query, args...)
+	if guard := versionGuardFromContext(ctx); guard != nil && err == nil {
+		guard.affected, err = result.RowsAffected()
+	}
+	return result, err
+}
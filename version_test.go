@@ -0,0 +1,88 @@
+package translatable
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gofiber/fiber/v3"
+	"github.com/google/uuid"
+	"github.com/nicolasbonnici/gorest-translatable/mocks"
+	"github.com/nicolasbonnici/gorest/database"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTranslatableResource_Update_Version(t *testing.T) {
+	id := uuid.New()
+	tests := []struct {
+		name     string
+		body     string
+		affected int64
+		deleted  bool
+		status   int
+		writes   int
+	}{
+		{name: "current version", body: `{"locale":"fr","content":"Salut","version":3}`, affected: 1, status: fiber.StatusOK, writes: 1},
+		{name: "no version", body: `{"locale":"fr","content":"Salut"}`, affected: 1, status: fiber.StatusOK, writes: 1},
+		{name: "stale version", body: `{"locale":"fr","content":"Salut","version":2}`, affected: 1, status: fiber.StatusConflict},
+		{name: "concurrent write", body: `{"locale":"fr","content":"Salut","version":3}`, status: fiber.StatusConflict, writes: 1},
+		{name: "concurrent delete", body: `{"locale":"fr","content":"Salut","version":3}`, deleted: true, status: fiber.StatusNotFound, writes: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var updateSQL []string
+			var updateArgs []interface{}
+			db := &mocks.MockDatabase{
+				QueryRowFunc: func(ctx context.Context, query string, args ...interface{}) database.Row {
+					return &mocks.MockRow{ScanFunc: func(dest ...interface{}) error {
+						if len(dest) == 1 || (tt.deleted && len(updateSQL) > 0) {
+							return sql.ErrNoRows
+						}
+						*dest[0].(*uuid.UUID) = id
+						*dest[3].(*string) = "post"
+						*dest[4].(*string) = "fr"
+						*dest[5].(*string) = "Bonjour"
+						*dest[16].(*int) = 3
+						return nil
+					}}
+				},
+				ExecFunc: func(ctx context.Context, query string, args ...interface{}) (database.Result, error) {
+					updateSQL = append(updateSQL, query)
+					updateArgs = args
+					return mocks.NewMockResult(tt.affected), nil
+				},
+			}
+			config := DefaultConfig()
+			app, resource := setupTestApp(db, &config)
+			app.Put("/translations/:id", resource.Update)
+
+			req := httptest.NewRequest(fiber.MethodPut, "/translations/"+id.String(), strings.NewReader(tt.body))
+			req.Header.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+			resp, err := app.Test(req)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			assert.Equal(t, tt.status, resp.StatusCode)
+			assert.Len(t, updateSQL, tt.writes)
+			if tt.writes > 0 {
+				assert.Contains(t, updateSQL[0], "version = ")
+				assert.Contains(t, updateArgs, 4)
+				assert.Contains(t, updateArgs, 3)
+			}
+			if tt.status == fiber.StatusConflict {
+				var body ProblemDetails
+				assert.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+				assert.Equal(t, CodeVersionConflict, body.Code)
+				if assert.NotNil(t, body.Current) {
+					assert.Equal(t, 3, body.Current.Version)
+					assert.Equal(t, "Bonjour", body.Current.Content)
+				}
+			}
+		})
+	}
+}
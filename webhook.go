@@ -0,0 +1,166 @@
+package translatable
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+)
+
+const (
+	// HeaderWebhookSignature carries "sha256=" and the hex HMAC-SHA256 of the
+	// request body keyed with Config.WebhookSecret.
+	HeaderWebhookSignature = "X-Translatable-Signature"
+	// HeaderWebhookEvent repeats the event type of the payload.
+	HeaderWebhookEvent = "X-Translatable-Event"
+)
+
+const (
+	defaultWebhookWorkers = 4
+	defaultWebhookRetries = 3
+	defaultWebhookTimeout = 10 * time.Second
+	webhookQueueSize      = 1000
+)
+
+// webhookDelivery is one event payload bound for one URL.
+type webhookDelivery struct {
+	url     string
+	event   string
+	payload []byte
+	log     *slog.Logger
+}
+
+// webhookDispatcher posts translation events to the configured webhook URLs
+// from a bounded pool of workers, so requests never wait on subscribers.
+// Deliveries are retried with exponential backoff; the queue drops events
+// once full rather than block the request that produced them.
+type webhookDispatcher struct {
+	urls    []string
+	secret  string
+	retries int
+	backoff time.Duration
+	workers int
+	client  *http.Client
+
+	start sync.Once
+	queue chan webhookDelivery
+}
+
+// webhookRetries returns WebhookRetries, defaultWebhookRetries when unset.
+func (c *Config) webhookRetries() int {
+	if c.WebhookRetries == nil {
+		return defaultWebhookRetries
+	}
+	return *c.WebhookRetries
+}
+
+func newWebhookDispatcher(config *Config) *webhookDispatcher {
+	return &webhookDispatcher{
+		urls:    config.Webhooks,
+		secret:  config.WebhookSecret,
+		retries: config.webhookRetries(),
+		backoff: 500 * time.Millisecond,
+		workers: config.WebhookWorkers,
+		client:  &http.Client{Timeout: config.WebhookTimeout},
+		queue:   make(chan webhookDelivery, webhookQueueSize),
+	}
+}
+
+// dispatch queues event for every webhook URL and returns immediately.
+func (d *webhookDispatcher) dispatch(ctx context.Context, event TranslationEvent) {
+	log := requestLogger(ctx)
+	payload, err := json.Marshal(event)
+	if err != nil {
+		log.Error("webhook payload encoding failed", "event", event.Type, "id", event.ID, "error", err)
+		return
+	}
+
+	d.start.Do(func() {
+		for range d.workers {
+			go d.work()
+		}
+	})
+	for _, target := range d.urls {
+		select {
+		case d.queue <- webhookDelivery{url: target, event: event.Type, payload: payload, log: log}:
+		default:
+			log.Warn("webhook queue full, event dropped", "url", target, "event", event.Type, "id", event.ID)
+		}
+	}
+}
+
+func (d *webhookDispatcher) work() {
+	for delivery := range d.queue {
+		d.deliver(delivery)
+	}
+}
+
+// deliver posts delivery, retrying failed attempts up to retries times with a
+// backoff doubling after each one.
+func (d *webhookDispatcher) deliver(delivery webhookDelivery) {
+	backoff := d.backoff
+	for attempt := 0; ; attempt++ {
+		err := d.post(delivery)
+		if err == nil {
+			return
+		}
+		if attempt >= d.retries {
+			delivery.log.Error("webhook delivery failed", "url", delivery.url, "event", delivery.event, "attempts", attempt+1, "error", err)
+			return
+		}
+		time.Sleep(backoff)
+		backoff *= 2
+	}
+}
+
+func (d *webhookDispatcher) post(delivery webhookDelivery) error {
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodPost, delivery.url, bytes.NewReader(delivery.payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(HeaderWebhookEvent, delivery.event)
+	if d.secret != "" {
+		req.Header.Set(HeaderWebhookSignature, "sha256="+signWebhookPayload(d.secret, delivery.payload))
+	}
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return err
+	}
+	_ = resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("unexpected status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// signWebhookPayload returns the hex HMAC-SHA256 of payload keyed with secret,
+// which subscribers recompute to authenticate a delivery.
+func signWebhookPayload(secret string, payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(payload)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func (c *Config) validateWebhooks() error {
+	for _, target := range c.Webhooks {
+		u, err := url.Parse(target)
+		if err != nil || (u.Scheme != "https" && u.Scheme != "http") || u.Host == "" {
+			return fmt.Errorf("webhooks contains an invalid URL: %s", target)
+		}
+	}
+	if c.WebhookRetries != nil && *c.WebhookRetries < 0 {
+		return errors.New("webhook_retries cannot be negative")
+	}
+	return nil
+}
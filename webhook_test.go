@@ -0,0 +1,169 @@
+package translatable
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+type webhookRequest struct {
+	body      []byte
+	signature string
+	event     string
+}
+
+func webhookConfig(t *testing.T, url string) *Config {
+	config := DefaultConfig()
+	config.Webhooks = []string{url}
+	config.WebhookSecret = "s3cret"
+	if err := config.Validate(); err != nil {
+		t.Fatal(err)
+	}
+	config.webhooks.backoff = time.Millisecond
+	return &config
+}
+
+func receiveWebhook(t *testing.T, requests <-chan webhookRequest) webhookRequest {
+	select {
+	case req := <-requests:
+		return req
+	case <-time.After(2 * time.Second):
+		t.Fatal("webhook was not delivered")
+		return webhookRequest{}
+	}
+}
+
+func TestWebhooks_DeliverSignedEvent(t *testing.T) {
+	requests := make(chan webhookRequest, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		requests <- webhookRequest{body: body, signature: r.Header.Get(HeaderWebhookSignature), event: r.Header.Get(HeaderWebhookEvent)}
+	}))
+	defer server.Close()
+
+	config := webhookConfig(t, server.URL)
+	translation := &Translatable{ID: uuid.New(), TranslatableID: uuid.New(), Translatable: "post", Locale: "fr", Content: "Bonjour"}
+	emitCreated(context.Background(), config, translation)
+
+	req := receiveWebhook(t, requests)
+	assert.Equal(t, EventCreated, req.event)
+	assert.Equal(t, "sha256="+signWebhookPayload("s3cret", req.body), req.signature)
+
+	var event TranslationEvent
+	assert.NoError(t, json.Unmarshal(req.body, &event))
+	assert.Equal(t, EventCreated, event.Type)
+	assert.Equal(t, translation.ID, event.ID)
+	assert.Equal(t, translation.TranslatableID, event.TranslatableID)
+	assert.Equal(t, "post", event.Translatable)
+	assert.Equal(t, "fr", event.Locale)
+	assert.False(t, event.Timestamp.IsZero())
+}
+
+func TestWebhooks_DoNotBlockTheWrite(t *testing.T) {
+	release := make(chan struct{})
+	requests := make(chan webhookRequest, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+		requests <- webhookRequest{}
+	}))
+	defer server.Close()
+
+	config := webhookConfig(t, server.URL)
+	done := make(chan struct{})
+	go func() {
+		emitDeleted(context.Background(), config, &Translatable{ID: uuid.New(), Content: "Bonjour"})
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("emitting the event waited on the webhook")
+	}
+	close(release)
+	receiveWebhook(t, requests)
+}
+
+func TestWebhooks_Retry(t *testing.T) {
+	tests := []struct {
+		name      string
+		failures  int32
+		retries   int
+		attempts  int32
+		delivered bool
+	}{
+		{name: "delivered after failures", failures: 2, retries: 3, attempts: 3, delivered: true},
+		{name: "gives up after retries", failures: 10, retries: 1, attempts: 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var attempts atomic.Int32
+			delivered := make(chan struct{}, 1)
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if attempts.Add(1) <= tt.failures {
+					w.WriteHeader(http.StatusInternalServerError)
+					return
+				}
+				delivered <- struct{}{}
+			}))
+			defer server.Close()
+
+			config := webhookConfig(t, server.URL)
+			config.webhooks.retries = tt.retries
+			config.webhooks.deliver(webhookDelivery{url: server.URL, event: EventUpdated, payload: []byte(`{}`), log: requestLogger(context.Background())})
+
+			assert.Equal(t, tt.attempts, attempts.Load())
+			assert.Equal(t, tt.delivered, len(delivered) == 1)
+		})
+	}
+}
+
+func TestConfig_ValidateWebhooks(t *testing.T) {
+	for _, url := range []string{"ftp://example.com/hook", "/hook", "https://"} {
+		config := DefaultConfig()
+		config.Webhooks = []string{url}
+		assert.EqualError(t, config.Validate(), "webhooks contains an invalid URL: "+url)
+	}
+
+	config := DefaultConfig()
+	assert.NoError(t, config.Validate())
+	assert.Nil(t, config.webhooks)
+}
+
+func TestConfig_WebhookRetries(t *testing.T) {
+	tests := []struct {
+		name    string
+		retries *int
+		want    int
+		wantErr string
+	}{
+		{name: "unset", want: defaultWebhookRetries},
+		{name: "disabled", retries: new(0), want: 0},
+		{name: "set", retries: new(5), want: 5},
+		{name: "negative", retries: new(-1), wantErr: "webhook_retries cannot be negative"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := DefaultConfig()
+			config.Webhooks = []string{"https://example.com/hook"}
+			config.WebhookRetries = tt.retries
+			err := config.Validate()
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, config.webhooks.retries)
+		})
+	}
+}